@@ -0,0 +1,146 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/pause"
+)
+
+// Client calls the admin API of a running relayer
+type Client struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewClient(url string, token string) *Client {
+	return &Client{
+		url:    strings.TrimSuffix(url, "/"),
+		token:  token,
+		client: &http.Client{Timeout: time.Minute},
+	}
+}
+
+func (c *Client) Domains() ([]Domain, error) {
+	domains := make([]Domain, 0)
+	err := c.call(http.MethodGet, "/domains", nil, &domains)
+	return domains, err
+}
+
+func (c *Client) Proposals(query ProposalsQuery) ([]Proposal, error) {
+	values := url.Values{}
+	if query.Status != "" {
+		values.Set("status", query.Status)
+	}
+	if query.Source != 0 {
+		values.Set("source", strconv.FormatUint(uint64(query.Source), 10))
+	}
+	if query.Destination != 0 {
+		values.Set("destination", strconv.FormatUint(uint64(query.Destination), 10))
+	}
+	if query.DepositNonce != nil {
+		values.Set("depositNonce", strconv.FormatUint(*query.DepositNonce, 10))
+	}
+	if query.Limit != 0 {
+		values.Set("limit", strconv.FormatUint(uint64(query.Limit), 10))
+	}
+
+	proposals := make([]Proposal, 0)
+	err := c.call(http.MethodGet, "/proposals?"+values.Encode(), nil, &proposals)
+	return proposals, err
+}
+
+func (c *Client) Sessions() ([]string, error) {
+	sessions := make([]string, 0)
+	err := c.call(http.MethodGet, "/sessions", nil, &sessions)
+	return sessions, err
+}
+
+func (c *Client) Peers() ([]Peer, error) {
+	peers := make([]Peer, 0)
+	err := c.call(http.MethodGet, "/peers", nil, &peers)
+	return peers, err
+}
+
+func (c *Client) Paused() ([]pause.Pause, error) {
+	pauses := make([]pause.Pause, 0)
+	err := c.call(http.MethodGet, "/paused", nil, &pauses)
+	return pauses, err
+}
+
+func (c *Client) Pause(target pause.Target) ([]pause.Pause, error) {
+	pauses := make([]pause.Pause, 0)
+	err := c.call(http.MethodPost, "/pause", target, &pauses)
+	return pauses, err
+}
+
+func (c *Client) Resume(target pause.Target) ([]pause.Pause, error) {
+	pauses := make([]pause.Pause, 0)
+	err := c.call(http.MethodPost, "/resume", target, &pauses)
+	return pauses, err
+}
+
+func (c *Client) Retry(req RetryRequest) (RetryResponse, error) {
+	resp := RetryResponse{}
+	err := c.call(http.MethodPost, "/retry", req, &resp)
+	return resp, err
+}
+
+func (c *Client) Sweep() (SweepResponse, error) {
+	resp := SweepResponse{}
+	err := c.call(http.MethodPost, "/sweep", struct{}{}, &resp)
+	return resp, err
+}
+
+func (c *Client) DeadLetters() ([]DeadLetter, error) {
+	deadLetters := make([]DeadLetter, 0)
+	err := c.call(http.MethodGet, "/dead-letters", nil, &deadLetters)
+	return deadLetters, err
+}
+
+func (c *Client) Requeue(key string) (RequeueResponse, error) {
+	resp := RequeueResponse{}
+	err := c.call(http.MethodPost, "/dead-letters/requeue", RequeueRequest{Key: key}, &resp)
+	return resp, err
+}
+
+func (c *Client) call(method string, path string, body interface{}, result interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, c.url+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("admin API returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
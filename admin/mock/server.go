@@ -0,0 +1,376 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./admin/server.go
+
+// Package mock_admin is a generated GoMock package.
+package mock_admin
+
+import (
+	big "math/big"
+	reflect "reflect"
+	time "time"
+
+	pause "github.com/ChainSafe/sygma-relayer/relayer/pause"
+	queue "github.com/ChainSafe/sygma-relayer/relayer/queue"
+	store "github.com/ChainSafe/sygma-relayer/store"
+	gomock "github.com/golang/mock/gomock"
+	message "github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+// MockBlockStorer is a mock of BlockStorer interface.
+type MockBlockStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockStorerMockRecorder
+}
+
+// MockBlockStorerMockRecorder is the mock recorder for MockBlockStorer.
+type MockBlockStorerMockRecorder struct {
+	mock *MockBlockStorer
+}
+
+// NewMockBlockStorer creates a new mock instance.
+func NewMockBlockStorer(ctrl *gomock.Controller) *MockBlockStorer {
+	mock := &MockBlockStorer{ctrl: ctrl}
+	mock.recorder = &MockBlockStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockStorer) EXPECT() *MockBlockStorerMockRecorder {
+	return m.recorder
+}
+
+// GetLastStoredBlock mocks base method.
+func (m *MockBlockStorer) GetLastStoredBlock(domainID uint8) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastStoredBlock", domainID)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastStoredBlock indicates an expected call of GetLastStoredBlock.
+func (mr *MockBlockStorerMockRecorder) GetLastStoredBlock(domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastStoredBlock", reflect.TypeOf((*MockBlockStorer)(nil).GetLastStoredBlock), domainID)
+}
+
+// MockPropStorer is a mock of PropStorer interface.
+type MockPropStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPropStorerMockRecorder
+}
+
+// MockPropStorerMockRecorder is the mock recorder for MockPropStorer.
+type MockPropStorerMockRecorder struct {
+	mock *MockPropStorer
+}
+
+// NewMockPropStorer creates a new mock instance.
+func NewMockPropStorer(ctrl *gomock.Controller) *MockPropStorer {
+	mock := &MockPropStorer{ctrl: ctrl}
+	mock.recorder = &MockPropStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPropStorer) EXPECT() *MockPropStorerMockRecorder {
+	return m.recorder
+}
+
+// IterateProps mocks base method.
+func (m *MockPropStorer) IterateProps(fn func(store.PropKey, *store.PropRecord) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateProps", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IterateProps indicates an expected call of IterateProps.
+func (mr *MockPropStorerMockRecorder) IterateProps(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateProps", reflect.TypeOf((*MockPropStorer)(nil).IterateProps), fn)
+}
+
+// PropRecord mocks base method.
+func (m *MockPropStorer) PropRecord(source, destination uint8, depositNonce uint64) (*store.PropRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PropRecord", source, destination, depositNonce)
+	ret0, _ := ret[0].(*store.PropRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PropRecord indicates an expected call of PropRecord.
+func (mr *MockPropStorerMockRecorder) PropRecord(source, destination, depositNonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropRecord", reflect.TypeOf((*MockPropStorer)(nil).PropRecord), source, destination, depositNonce)
+}
+
+// PropsByRoute mocks base method.
+func (m *MockPropStorer) PropsByRoute(source, destination uint8, fn func(store.PropKey, *store.PropRecord) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PropsByRoute", source, destination, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PropsByRoute indicates an expected call of PropsByRoute.
+func (mr *MockPropStorerMockRecorder) PropsByRoute(source, destination, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropsByRoute", reflect.TypeOf((*MockPropStorer)(nil).PropsByRoute), source, destination, fn)
+}
+
+// PropsByStatus mocks base method.
+func (m *MockPropStorer) PropsByStatus(status store.PropStatus, fn func(store.PropKey, *store.PropRecord) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PropsByStatus", status, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PropsByStatus indicates an expected call of PropsByStatus.
+func (mr *MockPropStorerMockRecorder) PropsByStatus(status, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropsByStatus", reflect.TypeOf((*MockPropStorer)(nil).PropsByStatus), status, fn)
+}
+
+// MockSessionTracker is a mock of SessionTracker interface.
+type MockSessionTracker struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionTrackerMockRecorder
+}
+
+// MockSessionTrackerMockRecorder is the mock recorder for MockSessionTracker.
+type MockSessionTrackerMockRecorder struct {
+	mock *MockSessionTracker
+}
+
+// NewMockSessionTracker creates a new mock instance.
+func NewMockSessionTracker(ctrl *gomock.Controller) *MockSessionTracker {
+	mock := &MockSessionTracker{ctrl: ctrl}
+	mock.recorder = &MockSessionTrackerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionTracker) EXPECT() *MockSessionTrackerMockRecorder {
+	return m.recorder
+}
+
+// ActiveSessions mocks base method.
+func (m *MockSessionTracker) ActiveSessions() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveSessions")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// ActiveSessions indicates an expected call of ActiveSessions.
+func (mr *MockSessionTrackerMockRecorder) ActiveSessions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveSessions", reflect.TypeOf((*MockSessionTracker)(nil).ActiveSessions))
+}
+
+// MockPauser is a mock of Pauser interface.
+type MockPauser struct {
+	ctrl     *gomock.Controller
+	recorder *MockPauserMockRecorder
+}
+
+// MockPauserMockRecorder is the mock recorder for MockPauser.
+type MockPauserMockRecorder struct {
+	mock *MockPauser
+}
+
+// NewMockPauser creates a new mock instance.
+func NewMockPauser(ctrl *gomock.Controller) *MockPauser {
+	mock := &MockPauser{ctrl: ctrl}
+	mock.recorder = &MockPauserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPauser) EXPECT() *MockPauserMockRecorder {
+	return m.recorder
+}
+
+// Pause mocks base method.
+func (m *MockPauser) Pause(target pause.Target) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pause", target)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *MockPauserMockRecorder) Pause(target interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockPauser)(nil).Pause), target)
+}
+
+// Resume mocks base method.
+func (m *MockPauser) Resume(target pause.Target) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", target)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockPauserMockRecorder) Resume(target interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockPauser)(nil).Resume), target)
+}
+
+// State mocks base method.
+func (m *MockPauser) State() []pause.Pause {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "State")
+	ret0, _ := ret[0].([]pause.Pause)
+	return ret0
+}
+
+// State indicates an expected call of State.
+func (mr *MockPauserMockRecorder) State() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "State", reflect.TypeOf((*MockPauser)(nil).State))
+}
+
+// MockMessageQueue is a mock of MessageQueue interface.
+type MockMessageQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageQueueMockRecorder
+}
+
+// MockMessageQueueMockRecorder is the mock recorder for MockMessageQueue.
+type MockMessageQueueMockRecorder struct {
+	mock *MockMessageQueue
+}
+
+// NewMockMessageQueue creates a new mock instance.
+func NewMockMessageQueue(ctrl *gomock.Controller) *MockMessageQueue {
+	mock := &MockMessageQueue{ctrl: ctrl}
+	mock.recorder = &MockMessageQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageQueue) EXPECT() *MockMessageQueueMockRecorder {
+	return m.recorder
+}
+
+// DeadLetters mocks base method.
+func (m *MockMessageQueue) DeadLetters() ([]queue.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetters")
+	ret0, _ := ret[0].([]queue.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeadLetters indicates an expected call of DeadLetters.
+func (mr *MockMessageQueueMockRecorder) DeadLetters() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetters", reflect.TypeOf((*MockMessageQueue)(nil).DeadLetters))
+}
+
+// Enqueue mocks base method.
+func (m *MockMessageQueue) Enqueue(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", block, domainMessages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockMessageQueueMockRecorder) Enqueue(block, domainMessages interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockMessageQueue)(nil).Enqueue), block, domainMessages)
+}
+
+// Requeue mocks base method.
+func (m *MockMessageQueue) Requeue(key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Requeue", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Requeue indicates an expected call of Requeue.
+func (mr *MockMessageQueueMockRecorder) Requeue(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Requeue", reflect.TypeOf((*MockMessageQueue)(nil).Requeue), key)
+}
+
+// MockSweeper is a mock of Sweeper interface.
+type MockSweeper struct {
+	ctrl     *gomock.Controller
+	recorder *MockSweeperMockRecorder
+}
+
+// MockSweeperMockRecorder is the mock recorder for MockSweeper.
+type MockSweeperMockRecorder struct {
+	mock *MockSweeper
+}
+
+// NewMockSweeper creates a new mock instance.
+func NewMockSweeper(ctrl *gomock.Controller) *MockSweeper {
+	mock := &MockSweeper{ctrl: ctrl}
+	mock.recorder = &MockSweeperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSweeper) EXPECT() *MockSweeperMockRecorder {
+	return m.recorder
+}
+
+// SweepNow mocks base method.
+func (m *MockSweeper) SweepNow(now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SweepNow", now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SweepNow indicates an expected call of SweepNow.
+func (mr *MockSweeperMockRecorder) SweepNow(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepNow", reflect.TypeOf((*MockSweeper)(nil).SweepNow), now)
+}
+
+// MockAuditor is a mock of Auditor interface.
+type MockAuditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditorMockRecorder
+}
+
+// MockAuditorMockRecorder is the mock recorder for MockAuditor.
+type MockAuditorMockRecorder struct {
+	mock *MockAuditor
+}
+
+// NewMockAuditor creates a new mock instance.
+func NewMockAuditor(ctrl *gomock.Controller) *MockAuditor {
+	mock := &MockAuditor{ctrl: ctrl}
+	mock.recorder = &MockAuditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditor) EXPECT() *MockAuditorMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockAuditor) Record(component, action string, details map[string]interface{}) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", component, action, details)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditorMockRecorder) Record(component, action, details interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditor)(nil).Record), component, action, details)
+}
@@ -0,0 +1,537 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/relayer/pause"
+	"github.com/ChainSafe/sygma-relayer/relayer/queue"
+	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+const (
+	AuditComponent = "admin"
+
+	defaultProposalsLimit = 100
+)
+
+type BlockStorer interface {
+	GetLastStoredBlock(domainID uint8) (*big.Int, error)
+}
+
+type PropStorer interface {
+	PropRecord(source, destination uint8, depositNonce uint64) (*store.PropRecord, error)
+	IterateProps(fn func(key store.PropKey, record *store.PropRecord) error) error
+	PropsByRoute(source, destination uint8, fn func(key store.PropKey, record *store.PropRecord) error) error
+	PropsByStatus(status store.PropStatus, fn func(key store.PropKey, record *store.PropRecord) error) error
+}
+
+type SessionTracker interface {
+	ActiveSessions() []string
+}
+
+type Pauser interface {
+	Pause(target pause.Target) error
+	Resume(target pause.Target) error
+	State() []pause.Pause
+}
+
+type MessageQueue interface {
+	Enqueue(block *big.Int, domainMessages map[uint8][]*message.Message) error
+	DeadLetters() ([]queue.DeadLetter, error)
+	Requeue(key string) error
+}
+
+type Sweeper interface {
+	SweepNow(now time.Time) (int, error)
+}
+
+type Auditor interface {
+	Record(component string, action string, details map[string]interface{})
+}
+
+// Server is the authenticated admin API used by operators to inspect
+// and control the running relayer. Every request is recorded in the audit log.
+type Server struct {
+	config  relayer.AdminConfig
+	domains map[uint8]string
+	// txRetryDomains are source domains whose retry handlers resolve deposits by transaction
+	txRetryDomains map[uint8]bool
+	host           host.Host
+	blockStorer    BlockStorer
+	propStorer     PropStorer
+	sessions       SessionTracker
+	pauser         Pauser
+	msgQueue       MessageQueue
+	sweeper        Sweeper
+	auditor        Auditor
+}
+
+// NewServer creates the admin API server. Domains map domain IDs to domain names.
+// Transaction retries are accepted only for source domains in txRetryDomains.
+// Sweeper can be nil if the sweeper is disabled.
+func NewServer(
+	config relayer.AdminConfig,
+	domains map[uint8]string,
+	txRetryDomains map[uint8]bool,
+	host host.Host,
+	blockStorer BlockStorer,
+	propStorer PropStorer,
+	sessions SessionTracker,
+	pauser Pauser,
+	msgQueue MessageQueue,
+	sweeper Sweeper,
+	auditor Auditor,
+) *Server {
+	return &Server{
+		config:         config,
+		domains:        domains,
+		txRetryDomains: txRetryDomains,
+		host:           host,
+		blockStorer:    blockStorer,
+		propStorer:     propStorer,
+		sessions:       sessions,
+		pauser:         pauser,
+		msgQueue:       msgQueue,
+		sweeper:        sweeper,
+		auditor:        auditor,
+	}
+}
+
+// Start serves the admin API on the configured port until the context is cancelled
+func (s *Server) Start(ctx context.Context) {
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", s.config.Port),
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = server.Shutdown(context.Background())
+	}()
+
+	log.Info().Msgf("Starting admin API on port %d", s.config.Port)
+	err := server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Err(err).Msg("Admin API stopped")
+	}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/domains", s.handle(http.MethodGet, "domains", s.listDomains))
+	mux.HandleFunc("/proposals", s.handle(http.MethodGet, "proposals", s.listProposals))
+	mux.HandleFunc("/sessions", s.handle(http.MethodGet, "sessions", s.listSessions))
+	mux.HandleFunc("/peers", s.handle(http.MethodGet, "peers", s.listPeers))
+	mux.HandleFunc("/paused", s.handle(http.MethodGet, "paused", s.pauseState))
+	mux.HandleFunc("/pause", s.handle(http.MethodPost, "pause", s.pause))
+	mux.HandleFunc("/resume", s.handle(http.MethodPost, "resume", s.resume))
+	mux.HandleFunc("/retry", s.handle(http.MethodPost, "retry", s.retry))
+	mux.HandleFunc("/sweep", s.handle(http.MethodPost, "sweep", s.sweep))
+	mux.HandleFunc("/dead-letters", s.handle(http.MethodGet, "dead-letters", s.listDeadLetters))
+	mux.HandleFunc("/dead-letters/requeue", s.handle(http.MethodPost, "requeue", s.requeue))
+	return mux
+}
+
+type handlerFunc func(r *http.Request, details map[string]interface{}) (interface{}, error)
+
+// statusError is an error returned to the caller with the http status
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &statusError{status: http.StatusBadRequest, err: fmt.Errorf(format, args...)}
+}
+
+// handle authenticates the request, executes the handler and records the action in the audit log
+func (s *Server) handle(method string, action string, handler handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		details := map[string]interface{}{
+			"remoteAddr": r.RemoteAddr,
+		}
+		if !s.authorized(r) {
+			details["error"] = "unauthorized"
+			s.auditor.Record(AuditComponent, action, details)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Method != method {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		result, err := handler(r, details)
+		if err != nil {
+			details["error"] = err.Error()
+		}
+		s.auditor.Record(AuditComponent, action, details)
+		if err != nil {
+			status := http.StatusInternalServerError
+			var statusErr *statusError
+			if errors.As(err, &statusErr) {
+				status = statusErr.status
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(result)
+		if err != nil {
+			log.Err(err).Msgf("Failed writing admin %s response", action)
+		}
+	}
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.config.Token)) == 1
+}
+
+func (s *Server) listDomains(r *http.Request, details map[string]interface{}) (interface{}, error) {
+	domains := make([]Domain, 0, len(s.domains))
+	for id, name := range s.domains {
+		latestBlock, err := s.blockStorer.GetLastStoredBlock(id)
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, Domain{
+			ID:          id,
+			Name:        name,
+			LatestBlock: latestBlock,
+		})
+	}
+	sort.Slice(domains, func(i, j int) bool { return domains[i].ID < domains[j].ID })
+	return domains, nil
+}
+
+func (s *Server) listProposals(r *http.Request, details map[string]interface{}) (interface{}, error) {
+	query := r.URL.Query()
+	for key := range query {
+		details[key] = query.Get(key)
+	}
+	source, err := parseUint(query.Get("source"), 8)
+	if err != nil {
+		return nil, badRequest("invalid source: %s", err)
+	}
+	destination, err := parseUint(query.Get("destination"), 8)
+	if err != nil {
+		return nil, badRequest("invalid destination: %s", err)
+	}
+	if (source == 0) != (destination == 0) {
+		return nil, badRequest("source and destination have to be provided together")
+	}
+	limit, err := parseUint(query.Get("limit"), 32)
+	if err != nil {
+		return nil, badRequest("invalid limit: %s", err)
+	}
+	if limit == 0 {
+		limit = defaultProposalsLimit
+	}
+	status := store.PropStatus(query.Get("status"))
+
+	proposals := make([]Proposal, 0)
+	if query.Get("depositNonce") != "" {
+		depositNonce, err := strconv.ParseUint(query.Get("depositNonce"), 10, 64)
+		if err != nil || source == 0 {
+			return nil, badRequest("deposit nonce requires a valid source and destination")
+		}
+		record, err := s.propStorer.PropRecord(uint8(source), uint8(destination), depositNonce)
+		if err != nil {
+			return nil, err
+		}
+		if record.Status != store.MissingProp && (status == "" || record.Status == status) {
+			proposals = append(proposals, Proposal{
+				Source:       uint8(source),
+				Destination:  uint8(destination),
+				DepositNonce: depositNonce,
+				PropRecord:   *record,
+			})
+		}
+		return proposals, nil
+	}
+
+	errLimitReached := fmt.Errorf("limit reached")
+	appendProp := func(key store.PropKey, record *store.PropRecord) error {
+		if status != "" && record.Status != status {
+			return nil
+		}
+		if uint64(len(proposals)) >= limit {
+			return errLimitReached
+		}
+
+		proposals = append(proposals, Proposal{
+			Source:       key.Source,
+			Destination:  key.Destination,
+			DepositNonce: key.DepositNonce,
+			PropRecord:   *record,
+		})
+		return nil
+	}
+	switch {
+	case source != 0:
+		err = s.propStorer.PropsByRoute(uint8(source), uint8(destination), appendProp)
+	case status != "":
+		err = s.propStorer.PropsByStatus(status, appendProp)
+	default:
+		err = s.propStorer.IterateProps(appendProp)
+	}
+	if err != nil && !errors.Is(err, errLimitReached) {
+		return nil, err
+	}
+	return proposals, nil
+}
+
+func (s *Server) listSessions(r *http.Request, details map[string]interface{}) (interface{}, error) {
+	return s.sessions.ActiveSessions(), nil
+}
+
+func (s *Server) listPeers(r *http.Request, details map[string]interface{}) (interface{}, error) {
+	peers := make([]Peer, 0)
+	for _, p := range s.host.Peerstore().Peers() {
+		if p == s.host.ID() {
+			continue
+		}
+
+		addrs := make([]string, 0)
+		for _, addr := range s.host.Peerstore().Addrs(p) {
+			addrs = append(addrs, addr.String())
+		}
+		peers = append(peers, Peer{
+			ID:        p.String(),
+			Connected: s.host.Network().Connectedness(p) == network.Connected,
+			Addrs:     addrs,
+		})
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].ID < peers[j].ID })
+	return peers, nil
+}
+
+func (s *Server) pauseState(r *http.Request, details map[string]interface{}) (interface{}, error) {
+	return s.pauser.State(), nil
+}
+
+func (s *Server) pause(r *http.Request, details map[string]interface{}) (interface{}, error) {
+	target, err := s.decodePauseTarget(r, details)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.pauser.Pause(target)
+	if err != nil {
+		return nil, err
+	}
+	return s.pauser.State(), nil
+}
+
+func (s *Server) resume(r *http.Request, details map[string]interface{}) (interface{}, error) {
+	target, err := s.decodePauseTarget(r, details)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.pauser.Resume(target)
+	if err != nil {
+		return nil, err
+	}
+	return s.pauser.State(), nil
+}
+
+func (s *Server) decodePauseTarget(r *http.Request, details map[string]interface{}) (pause.Target, error) {
+	target := pause.Target{}
+	err := json.NewDecoder(r.Body).Decode(&target)
+	if err != nil {
+		return target, badRequest("invalid request: %s", err)
+	}
+	details["target"] = target
+
+	key, err := target.Key()
+	if err != nil {
+		return target, badRequest("invalid pause target: %s", err)
+	}
+	for _, domainID := range []uint8{target.DomainID, target.Source, target.Destination} {
+		if _, ok := s.domains[domainID]; domainID != 0 && !ok {
+			return target, badRequest("unknown domain %d", domainID)
+		}
+	}
+	details["key"] = key
+	return target, nil
+}
+
+// retry enqueues a retry message for deposits made in the block or the transaction on the source domain.
+// Retry message IDs are deterministic so relayers retrying the same deposit
+// create the same signing sessions.
+func (s *Server) retry(r *http.Request, details map[string]interface{}) (interface{}, error) {
+	req := &RetryRequest{}
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		return nil, badRequest("invalid request: %s", err)
+	}
+	details["source"] = req.Source
+	if _, ok := s.domains[req.Source]; !ok {
+		return nil, badRequest("unknown source domain %d", req.Source)
+	}
+
+	var messageID string
+	var retryData retry.RetryMessageData
+	var blockHeight *big.Int
+	if req.TxHash != "" {
+		details["txHash"] = req.TxHash
+		if !s.txRetryDomains[req.Source] {
+			return nil, badRequest("transaction retries are not supported on source domain %d", req.Source)
+		}
+		txHash, err := hex.DecodeString(strings.TrimPrefix(req.TxHash, "0x"))
+		if err != nil || len(txHash) != 32 {
+			return nil, badRequest("invalid transaction hash %s", req.TxHash)
+		}
+
+		messageID = fmt.Sprintf("%sadmin-%d-%x", retry.RetryMessageIDPrefix, req.Source, txHash)
+		retryData = retry.RetryMessageData{
+			SourceDomainID: req.Source,
+			TxHash:         fmt.Sprintf("0x%x", txHash),
+		}
+		// the block of the transaction is resolved when the retry is handled
+		blockHeight = big.NewInt(0)
+	} else {
+		details["destination"] = req.Destination
+		details["blockHeight"] = req.BlockHeight.String()
+		details["resourceID"] = req.ResourceID
+		if _, ok := s.domains[req.Destination]; !ok {
+			return nil, badRequest("unknown destination domain %d", req.Destination)
+		}
+		if req.BlockHeight == nil || req.BlockHeight.Sign() <= 0 {
+			return nil, badRequest("invalid block height")
+		}
+		resourceID, err := parseResourceID(req.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		messageID = fmt.Sprintf("%sadmin-%d-%d-%s-%x", retry.RetryMessageIDPrefix, req.Source, req.Destination, req.BlockHeight, resourceID)
+		retryData = retry.RetryMessageData{
+			SourceDomainID:      req.Source,
+			DestinationDomainID: req.Destination,
+			BlockHeight:         req.BlockHeight,
+			ResourceID:          resourceID,
+		}
+		blockHeight = req.BlockHeight
+	}
+
+	details["messageID"] = messageID
+	msg := message.NewMessage(
+		req.Source,
+		req.Source,
+		retryData,
+		messageID,
+		retry.RetryMessageType,
+		time.Now(),
+	)
+	err = s.msgQueue.Enqueue(blockHeight, map[uint8][]*message.Message{
+		req.Source: {msg},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Str("messageID", messageID).Msgf("Admin retried deposits on domain %d", req.Source)
+	return RetryResponse{MessageID: messageID}, nil
+}
+
+func (s *Server) sweep(r *http.Request, details map[string]interface{}) (interface{}, error) {
+	if s.sweeper == nil {
+		return nil, &statusError{status: http.StatusNotFound, err: fmt.Errorf("sweeper is disabled")}
+	}
+
+	retries, err := s.sweeper.SweepNow(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	details["retries"] = retries
+	return SweepResponse{Retries: retries}, nil
+}
+
+func (s *Server) listDeadLetters(r *http.Request, details map[string]interface{}) (interface{}, error) {
+	deadLetters, err := s.msgQueue.DeadLetters()
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]DeadLetter, 0, len(deadLetters))
+	for _, deadLetter := range deadLetters {
+		messages = append(messages, DeadLetter{
+			Key:         deadLetter.Key,
+			Source:      deadLetter.Message.Source,
+			Destination: deadLetter.Message.Destination,
+			MessageID:   deadLetter.Message.ID,
+			Type:        string(deadLetter.Message.Type),
+			Failures:    deadLetter.Failures,
+		})
+	}
+	return messages, nil
+}
+
+// requeue moves the dead letter back to the message queue and relays it again
+func (s *Server) requeue(r *http.Request, details map[string]interface{}) (interface{}, error) {
+	req := &RequeueRequest{}
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		return nil, badRequest("invalid request: %s", err)
+	}
+	details["key"] = req.Key
+	if req.Key == "" {
+		return nil, badRequest("missing dead letter key")
+	}
+
+	err = s.msgQueue.Requeue(req.Key)
+	if errors.Is(err, queue.ErrDeadLetterNotFound) {
+		return nil, &statusError{status: http.StatusNotFound, err: fmt.Errorf("dead letter %s not found", req.Key)}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Msgf("Admin requeued dead letter %s", req.Key)
+	return RequeueResponse{Key: req.Key}, nil
+}
+
+func parseUint(value string, bitSize int) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, bitSize)
+}
+
+func parseResourceID(value string) ([32]byte, error) {
+	var resourceID [32]byte
+	resourceBytes, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil || len(resourceBytes) != len(resourceID) {
+		return resourceID, badRequest("invalid resource ID %s", value)
+	}
+	copy(resourceID[:], resourceBytes)
+	return resourceID, nil
+}
@@ -0,0 +1,315 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package admin_test
+
+import (
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/admin"
+	mock_admin "github.com/ChainSafe/sygma-relayer/admin/mock"
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/relayer/pause"
+	"github.com/ChainSafe/sygma-relayer/relayer/queue"
+	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/ChainSafe/sygma-relayer/store/lvldb"
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+const testToken = "admin-token"
+
+type AdminServerTestSuite struct {
+	suite.Suite
+	db               *lvldb.LVLDB
+	propStore        *store.PropStore
+	hosts            []host.Host
+	pauser           *pause.Pauser
+	pauseController  *pause.Controller
+	mockBlockStorer  *mock_admin.MockBlockStorer
+	mockSessions     *mock_admin.MockSessionTracker
+	mockMessageQueue *mock_admin.MockMessageQueue
+	mockSweeper      *mock_admin.MockSweeper
+	mockAuditor      *mock_admin.MockAuditor
+	httpServer       *httptest.Server
+	client           *admin.Client
+}
+
+func TestRunAdminServerTestSuite(t *testing.T) {
+	suite.Run(t, new(AdminServerTestSuite))
+}
+
+func (s *AdminServerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	s.db = db
+	s.propStore = store.NewPropStore(db)
+
+	s.hosts = []host.Host{}
+	for i := 0; i < 2; i++ {
+		h, err := libp2p.New(libp2p.DisableRelay())
+		s.Nil(err)
+		s.hosts = append(s.hosts, h)
+	}
+	s.hosts[0].Peerstore().AddAddr(s.hosts[1].ID(), s.hosts[1].Addrs()[0], peerstore.PermanentAddrTTL)
+
+	s.pauser, err = pause.NewPauser(db)
+	s.Nil(err)
+	s.mockBlockStorer = mock_admin.NewMockBlockStorer(ctrl)
+	s.mockSessions = mock_admin.NewMockSessionTracker(ctrl)
+	s.mockMessageQueue = mock_admin.NewMockMessageQueue(ctrl)
+	s.mockSweeper = mock_admin.NewMockSweeper(ctrl)
+	s.mockAuditor = mock_admin.NewMockAuditor(ctrl)
+	s.pauseController = pause.NewController(s.pauser, s.hosts[0], nil, s.mockAuditor, false)
+	server := admin.NewServer(
+		relayer.AdminConfig{Token: testToken},
+		map[uint8]string{1: "evm1", 2: "substrate1"},
+		map[uint8]bool{1: true},
+		s.hosts[0],
+		s.mockBlockStorer,
+		s.propStore,
+		s.mockSessions,
+		s.pauseController,
+		s.mockMessageQueue,
+		s.mockSweeper,
+		s.mockAuditor,
+	)
+	s.httpServer = httptest.NewServer(server.Handler())
+	s.client = admin.NewClient(s.httpServer.URL, testToken)
+}
+
+func (s *AdminServerTestSuite) TearDownTest() {
+	s.httpServer.Close()
+	_ = s.db.Close()
+	for _, h := range s.hosts {
+		_ = h.Close()
+	}
+}
+
+func (s *AdminServerTestSuite) Test_InvalidToken_Unauthorized() {
+	s.mockAuditor.EXPECT().Record(admin.AuditComponent, "domains", gomock.Any()).Do(
+		func(component string, action string, details map[string]interface{}) {
+			s.Equal(details["error"], "unauthorized")
+		})
+
+	_, err := admin.NewClient(s.httpServer.URL, "invalid").Domains()
+
+	s.NotNil(err)
+	s.Contains(err.Error(), "401")
+}
+
+func (s *AdminServerTestSuite) Test_Domains() {
+	s.mockAuditor.EXPECT().Record(admin.AuditComponent, "domains", gomock.Any())
+	s.mockBlockStorer.EXPECT().GetLastStoredBlock(uint8(1)).Return(big.NewInt(100), nil)
+	s.mockBlockStorer.EXPECT().GetLastStoredBlock(uint8(2)).Return(big.NewInt(200), nil)
+
+	domains, err := s.client.Domains()
+
+	s.Nil(err)
+	s.Equal(domains, []admin.Domain{
+		{ID: 1, Name: "evm1", LatestBlock: big.NewInt(100)},
+		{ID: 2, Name: "substrate1", LatestBlock: big.NewInt(200)},
+	})
+}
+
+func (s *AdminServerTestSuite) Test_Proposals() {
+	s.mockAuditor.EXPECT().Record(admin.AuditComponent, "proposals", gomock.Any()).Times(3)
+	s.Nil(s.propStore.StorePropStatus(1, 2, 1, store.PendingProp))
+	s.Nil(s.propStore.StorePropStatus(1, 2, 2, store.FailedProp))
+	s.Nil(s.propStore.StorePropStatus(2, 1, 3, store.FailedProp))
+
+	proposals, err := s.client.Proposals(admin.ProposalsQuery{Status: string(store.FailedProp)})
+	s.Nil(err)
+	s.Equal(len(proposals), 2)
+
+	proposals, err = s.client.Proposals(admin.ProposalsQuery{Source: 1, Destination: 2, Limit: 1})
+	s.Nil(err)
+	s.Equal(len(proposals), 1)
+	s.Equal(proposals[0].DepositNonce, uint64(1))
+
+	nonce := uint64(3)
+	proposals, err = s.client.Proposals(admin.ProposalsQuery{Source: 2, Destination: 1, DepositNonce: &nonce})
+	s.Nil(err)
+	s.Equal(len(proposals), 1)
+	s.Equal(proposals[0].Status, store.FailedProp)
+}
+
+func (s *AdminServerTestSuite) Test_SessionsAndPeers() {
+	s.mockAuditor.EXPECT().Record(admin.AuditComponent, gomock.Any(), gomock.Any()).Times(2)
+	s.mockSessions.EXPECT().ActiveSessions().Return([]string{"1-2-0"})
+
+	sessions, err := s.client.Sessions()
+	s.Nil(err)
+	s.Equal(sessions, []string{"1-2-0"})
+
+	peers, err := s.client.Peers()
+	s.Nil(err)
+	s.Equal(len(peers), 1)
+	s.Equal(peers[0].ID, s.hosts[1].ID().String())
+	s.False(peers[0].Connected)
+}
+
+func (s *AdminServerTestSuite) Test_PauseAndResume() {
+	s.mockAuditor.EXPECT().Record(admin.AuditComponent, "pause", gomock.Any()).Times(4)
+	s.mockAuditor.EXPECT().Record(admin.AuditComponent, "resume", gomock.Any())
+
+	_, err := s.client.Pause(pause.Target{DomainID: 2})
+	s.Nil(err)
+	pauses, err := s.client.Pause(pause.Target{ResourceID: "0x0000000000000000000000000000000000000000000000000000000000000001"})
+	s.Nil(err)
+	s.Equal(len(pauses), 2)
+	s.Equal(pauses[0].Key, "pause:domain:2")
+	s.Equal(pauses[0].PausedBy, pause.OperatorOrigin)
+	s.Equal(pauses[1].Key, "pause:resource:0000000000000000000000000000000000000000000000000000000000000001")
+	s.True(s.pauser.RoutePaused(1, 2))
+
+	_, err = s.client.Pause(pause.Target{Source: 1, Destination: 5})
+	s.NotNil(err)
+	s.Contains(err.Error(), "400")
+	_, err = s.client.Pause(pause.Target{DomainID: 1, ResourceID: "0x01"})
+	s.NotNil(err)
+	s.Contains(err.Error(), "400")
+
+	pauses, err = s.client.Resume(pause.Target{DomainID: 2})
+	s.Nil(err)
+	s.Equal(len(pauses), 1)
+	s.False(s.pauser.RoutePaused(1, 2))
+}
+
+func (s *AdminServerTestSuite) Test_Retry() {
+	s.mockAuditor.EXPECT().Record(admin.AuditComponent, "retry", gomock.Any())
+	expectedMessageID := "retry-admin-1-2-100-0000000000000000000000000000000000000000000000000000000000000001"
+	s.mockMessageQueue.EXPECT().Enqueue(big.NewInt(100), gomock.Any()).DoAndReturn(
+		func(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+			msgs := domainMessages[1]
+			s.Equal(len(msgs), 1)
+			s.Equal(msgs[0].ID, expectedMessageID)
+			s.Equal(msgs[0].Type, retry.RetryMessageType)
+			s.Equal(msgs[0].Data, retry.RetryMessageData{
+				SourceDomainID:      1,
+				DestinationDomainID: 2,
+				BlockHeight:         big.NewInt(100),
+				ResourceID:          [32]byte{31: 1},
+			})
+			return nil
+		})
+
+	resp, err := s.client.Retry(admin.RetryRequest{
+		Source:      1,
+		Destination: 2,
+		BlockHeight: big.NewInt(100),
+		ResourceID:  "0x0000000000000000000000000000000000000000000000000000000000000001",
+	})
+
+	s.Nil(err)
+	s.Equal(resp.MessageID, expectedMessageID)
+}
+
+func (s *AdminServerTestSuite) Test_RetryTransaction() {
+	s.mockAuditor.EXPECT().Record(admin.AuditComponent, "retry", gomock.Any()).Times(3)
+	txHash := "0x00000000000000000000000000000000000000000000000000000000000000ab"
+	expectedMessageID := "retry-admin-1-00000000000000000000000000000000000000000000000000000000000000ab"
+	s.mockMessageQueue.EXPECT().Enqueue(big.NewInt(0), gomock.Any()).DoAndReturn(
+		func(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+			msgs := domainMessages[1]
+			s.Equal(len(msgs), 1)
+			s.Equal(msgs[0].ID, expectedMessageID)
+			s.Equal(msgs[0].Data, retry.RetryMessageData{
+				SourceDomainID: 1,
+				TxHash:         txHash,
+			})
+			return nil
+		})
+
+	resp, err := s.client.Retry(admin.RetryRequest{
+		Source: 1,
+		TxHash: txHash,
+	})
+	s.Nil(err)
+	s.Equal(resp.MessageID, expectedMessageID)
+
+	_, err = s.client.Retry(admin.RetryRequest{
+		Source: 1,
+		TxHash: "0xab",
+	})
+	s.NotNil(err)
+	s.Contains(err.Error(), "400")
+
+	_, err = s.client.Retry(admin.RetryRequest{
+		Source: 2,
+		TxHash: txHash,
+	})
+	s.NotNil(err)
+	s.Contains(err.Error(), "transaction retries are not supported")
+}
+
+func (s *AdminServerTestSuite) Test_Sweep() {
+	s.mockAuditor.EXPECT().Record(admin.AuditComponent, "sweep", gomock.Any()).Do(
+		func(component string, action string, details map[string]interface{}) {
+			s.Equal(details["retries"], 2)
+		})
+	s.mockSweeper.EXPECT().SweepNow(gomock.Any()).Return(2, nil)
+
+	resp, err := s.client.Sweep()
+
+	s.Nil(err)
+	s.Equal(resp.Retries, 2)
+}
+
+func (s *AdminServerTestSuite) Test_DeadLetters() {
+	s.mockAuditor.EXPECT().Record(admin.AuditComponent, "dead-letters", gomock.Any())
+	s.mockMessageQueue.EXPECT().DeadLetters().Return([]queue.DeadLetter{
+		{
+			Key:      "source:1:block:00000000000000000005:seq:00000000000000000001:index:00000",
+			Message:  message.NewMessage(1, 2, nil, "messageID", "Transfer", time.Unix(100, 0)),
+			Failures: 5,
+		},
+	}, nil)
+
+	deadLetters, err := s.client.DeadLetters()
+
+	s.Nil(err)
+	s.Equal(deadLetters, []admin.DeadLetter{
+		{
+			Key:         "source:1:block:00000000000000000005:seq:00000000000000000001:index:00000",
+			Source:      1,
+			Destination: 2,
+			MessageID:   "messageID",
+			Type:        "Transfer",
+			Failures:    5,
+		},
+	})
+}
+
+func (s *AdminServerTestSuite) Test_Requeue() {
+	key := "source:1:block:00000000000000000005:seq:00000000000000000001:index:00000"
+	s.mockAuditor.EXPECT().Record(admin.AuditComponent, "requeue", gomock.Any()).Do(
+		func(component string, action string, details map[string]interface{}) {
+			s.Equal(details["key"], key)
+		})
+	s.mockMessageQueue.EXPECT().Requeue(key).Return(nil)
+
+	resp, err := s.client.Requeue(key)
+
+	s.Nil(err)
+	s.Equal(resp.Key, key)
+}
+
+func (s *AdminServerTestSuite) Test_Requeue_MissingDeadLetter() {
+	s.mockAuditor.EXPECT().Record(admin.AuditComponent, "requeue", gomock.Any())
+	s.mockMessageQueue.EXPECT().Requeue("missing").Return(queue.ErrDeadLetterNotFound)
+
+	_, err := s.client.Requeue("missing")
+
+	s.NotNil(err)
+	s.Contains(err.Error(), "not found")
+}
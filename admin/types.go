@@ -0,0 +1,78 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package admin
+
+import (
+	"math/big"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+)
+
+// Domain is a relayed domain with the latest block processed by its listener
+type Domain struct {
+	ID          uint8    `json:"id"`
+	Name        string   `json:"name"`
+	LatestBlock *big.Int `json:"latestBlock"`
+}
+
+type Proposal struct {
+	Source       uint8  `json:"source"`
+	Destination  uint8  `json:"destination"`
+	DepositNonce uint64 `json:"depositNonce"`
+	store.PropRecord
+}
+
+type Peer struct {
+	ID        string   `json:"id"`
+	Connected bool     `json:"connected"`
+	Addrs     []string `json:"addrs"`
+}
+
+// ProposalsQuery filters listed proposals. Source and destination
+// have to be provided together and are required by the deposit nonce.
+type ProposalsQuery struct {
+	Status       string
+	Source       uint8
+	Destination  uint8
+	DepositNonce *uint64
+	Limit        uint32
+}
+
+// RetryRequest retries deposits of the resource to the destination
+// made in the block on the source domain. If the transaction hash is
+// provided, deposits made in the transaction are retried instead.
+type RetryRequest struct {
+	Source      uint8    `json:"source"`
+	Destination uint8    `json:"destination,omitempty"`
+	BlockHeight *big.Int `json:"blockHeight,omitempty"`
+	ResourceID  string   `json:"resourceID,omitempty"`
+	TxHash      string   `json:"txHash,omitempty"`
+}
+
+type RetryResponse struct {
+	MessageID string `json:"messageID"`
+}
+
+type SweepResponse struct {
+	Retries int `json:"retries"`
+}
+
+// DeadLetter is a queued message that failed delivery too many times
+// and is not relayed until it is requeued
+type DeadLetter struct {
+	Key         string `json:"key"`
+	Source      uint8  `json:"source"`
+	Destination uint8  `json:"destination"`
+	MessageID   string `json:"messageID"`
+	Type        string `json:"type"`
+	Failures    uint64 `json:"failures"`
+}
+
+type RequeueRequest struct {
+	Key string `json:"key"`
+}
+
+type RequeueResponse struct {
+	Key string `json:"key"`
+}
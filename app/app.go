@@ -6,6 +6,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"net/http"
 	"os"
 	"os/signal"
@@ -21,13 +22,27 @@ import (
 	"github.com/ChainSafe/sygma-relayer/chains/evm"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/contracts/bridge"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/events"
+	evmClient "github.com/ChainSafe/sygma-relayer/chains/evm/client"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/executor"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/handlers"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/depositHandlers"
 	evmEventHandlers "github.com/ChainSafe/sygma-relayer/chains/evm/listener/eventHandlers"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/sender"
 	"github.com/ChainSafe/sygma-relayer/chains/substrate"
+	"github.com/ChainSafe/sygma-relayer/relayer/audit"
+	"github.com/ChainSafe/sygma-relayer/relayer/dedup"
+	"github.com/ChainSafe/sygma-relayer/relayer/funding"
+	"github.com/ChainSafe/sygma-relayer/relayer/limits"
+	"github.com/ChainSafe/sygma-relayer/relayer/pause"
+	"github.com/ChainSafe/sygma-relayer/relayer/policy"
+	"github.com/ChainSafe/sygma-relayer/relayer/queue"
+	"github.com/ChainSafe/sygma-relayer/relayer/reorg"
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	propStore "github.com/ChainSafe/sygma-relayer/store"
+	"github.com/ChainSafe/sygma-relayer/store/lvldb"
 	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/gas"
 	coreSubstrate "github.com/sygmaprotocol/sygma-core/chains/substrate"
 	"github.com/sygmaprotocol/sygma-core/crypto/secp256k1"
@@ -35,7 +50,6 @@ import (
 	"github.com/sygmaprotocol/sygma-core/relayer"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 	"github.com/sygmaprotocol/sygma-core/store"
-	"github.com/sygmaprotocol/sygma-core/store/lvldb"
 
 	btcConfig "github.com/ChainSafe/sygma-relayer/chains/btc/config"
 	btcConnection "github.com/ChainSafe/sygma-relayer/chains/btc/connection"
@@ -47,14 +61,13 @@ import (
 	"github.com/ChainSafe/sygma-relayer/metrics"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	coreEvm "github.com/sygmaprotocol/sygma-core/chains/evm"
-	evmClient "github.com/sygmaprotocol/sygma-core/chains/evm/client"
-	"github.com/sygmaprotocol/sygma-core/chains/evm/listener"
 	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/monitored"
 	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/transaction"
 	substrateClient "github.com/sygmaprotocol/sygma-core/chains/substrate/client"
 	"github.com/sygmaprotocol/sygma-core/chains/substrate/connection"
 	coreSubstrateListener "github.com/sygmaprotocol/sygma-core/chains/substrate/listener"
 
+	"github.com/ChainSafe/sygma-relayer/admin"
 	"github.com/ChainSafe/sygma-relayer/comm/elector"
 	"github.com/ChainSafe/sygma-relayer/comm/p2p"
 	"github.com/ChainSafe/sygma-relayer/config"
@@ -71,6 +84,11 @@ import (
 
 var Version string
 
+var propPruningInterval = 24 * time.Hour
+
+// fundingStatusTTL is the period after which funding statuses of relayers that stopped broadcasting expire
+var fundingStatusTTL = time.Hour
+
 func Run() error {
 	var err error
 
@@ -125,6 +143,7 @@ func Run() error {
 	communication := p2p.NewCommunication(host, "p2p/sygma")
 	electorFactory := elector.NewCoordinatorElectorFactory(host, configuration.RelayerConfig.BullyConfig)
 	coordinator := tss.NewCoordinator(host, communication, electorFactory)
+	coordinator.RequireMessageHashes = configuration.RelayerConfig.MpcConfig.RequireMessageHashes
 
 	// this is temporary solution related to specifics of aws deployment
 	// effectively it waits until old instance is killed
@@ -142,7 +161,20 @@ func Run() error {
 	blockstore := store.NewBlockStore(db)
 	keyshareStore := keyshare.NewECDSAKeyshareStore(configuration.RelayerConfig.MpcConfig.KeysharePath)
 	frostKeyshareStore := keyshare.NewFrostKeyshareStore(configuration.RelayerConfig.MpcConfig.FrostKeysharePath)
+	seenStore := propStore.NewSeenStore(db)
+	blockHashStore := propStore.NewBlockHashStore(db)
 	propStore := propStore.NewPropStore(db)
+	err = propStore.Migrate()
+	panicOnError(err)
+	volumeLimiter := limits.NewVolumeLimiter(db, configuration.RelayerConfig.VolumeLimitsConfig)
+	signingPolicy := policy.NewPolicy(configuration.RelayerConfig.SigningPolicyConfig)
+	auditLog, err := audit.NewLog(configuration.RelayerConfig.AuditLogFile)
+	panicOnError(err)
+	screener, err := screening.NewScreener(configuration.RelayerConfig.ScreeningConfig, propStore, auditLog)
+	panicOnError(err)
+	pauser, err := pause.NewPauser(db)
+	panicOnError(err)
+	pauseController := pause.NewController(pauser, host, p2p.NewCommunication(host, "p2p/pause"), auditLog, configuration.RelayerConfig.PauseConfig.Broadcast)
 
 	// wait until executions are done and then stop further executions before exiting
 	exitLock := &sync.RWMutex{}
@@ -160,14 +192,22 @@ func Run() error {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	go pauseController.Start(ctx)
 
 	sygmaMetrics, err := metrics.NewSygmaMetrics(ctx, mp.Meter("relayer-metric-provider"), configuration.RelayerConfig.Env, configuration.RelayerConfig.Id, Version)
 	if err != nil {
 		panic(err)
 	}
 	msgChan := make(chan []*message.Message)
+	msgQueue := queue.NewMessageQueue(db, msgChan)
 
 	domains := make(map[uint8]relayer.RelayedChain)
+	domainNames := make(map[uint8]string)
+	txRetryDomains := make(map[uint8]bool)
+	executionCheckers := make(map[uint8]jobs.ExecutionChecker)
+	balanceFetchers := make(map[uint8]jobs.BalanceFetcher)
+	fundingTracker := funding.NewTracker(host, p2p.NewCommunication(host, "p2p/funding"), fundingStatusTTL)
+	go fundingTracker.Start(ctx)
 	for _, chainConfig := range configuration.ChainConfigs {
 		switch chainConfig["type"] {
 		case "evm":
@@ -177,7 +217,9 @@ func Run() error {
 				kp, err := secp256k1.NewKeypairFromString(config.GeneralChainConfig.Key)
 				panicOnError(err)
 
-				client, err := evmClient.NewEVMClient(config.GeneralChainConfig.Endpoint, kp)
+				endpoints, err := evmClient.DialEndpoints(config.RPCEndpoints(), kp)
+				panicOnError(err)
+				client, err := evmClient.NewMultiClient(*config.GeneralChainConfig.Id, endpoints, kp, sygmaMetrics)
 				panicOnError(err)
 
 				log.Info().Str("domain", config.String()).Msgf("Registering EVM domain")
@@ -190,49 +232,52 @@ func Run() error {
 				})
 				t := monitored.NewMonitoredTransactor(*config.GeneralChainConfig.Id, transaction.NewTransaction, gasPricer, sygmaMetrics, client, config.MaxGasPrice, config.GasIncreasePercentage)
 				go t.Monitor(ctx, time.Minute*3, time.Minute*10, time.Minute)
-				bridgeContract := bridge.NewBridgeContract(client, bridgeAddress, t)
+				senders := []sender.Sender{{Client: client, Transactor: t}}
+				for _, senderKey := range config.SenderKeys {
+					senderKp, err := secp256k1.NewKeypairFromString(senderKey)
+					panicOnError(err)
+					senderClient := client.WithSigner(senderKp)
+					senderTransactor := monitored.NewMonitoredTransactor(*config.GeneralChainConfig.Id, transaction.NewTransaction, gasPricer, sygmaMetrics, senderClient, config.MaxGasPrice, config.GasIncreasePercentage)
+					go senderTransactor.Monitor(ctx, time.Minute*3, time.Minute*10, time.Minute)
+					senders = append(senders, sender.Sender{Client: senderClient, Transactor: senderTransactor})
+				}
+				keyPool := sender.NewKeyPool(*config.GeneralChainConfig.Id, senders, config.SenderStuckTimeout, config.MinSenderBalance)
+				go keyPool.Monitor(ctx)
+				bridgeContract := bridge.NewBridgeContract(client, bridgeAddress, keyPool)
 
 				depositHandler := depositHandlers.NewETHDepositHandler(bridgeContract)
-				for _, handler := range config.Handlers {
-					switch handler.Type {
-					case "erc20", "native":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.Erc20DepositHandler{})
-						}
-					case "permissionlessGeneric":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.PermissionlessGenericDepositHandler{})
-						}
-					case "erc721":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.Erc721DepositHandler{})
-						}
-					case "erc1155":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.Erc1155DepositHandler{})
-						}
-					}
-				}
-				depositListener := events.NewListener(client)
-				tssListener := events.NewListener(client)
+				transferHandler := executor.NewTransferMessageHandler(screener)
+				err = handlers.NewRegistry().Setup(config.Handlers, depositHandler, transferHandler)
+				panicOnError(err)
+				confirmedHead := evmClient.NewConfirmedHead(client, config.ConfirmationMode, config.BlockConfirmations)
+				depositListener := events.NewListener(evmClient.NewConfirmedClient(evmClient.NewQuorumClient(client, config.DepositQuorum), confirmedHead), config.LogConcurrency)
+				tssListener := events.NewListener(evmClient.NewConfirmedClient(client, confirmedHead), config.LogConcurrency)
 				eventHandlers := make([]listener.EventHandler, 0)
+				if configuration.RelayerConfig.PauseConfig.HaltListeners {
+					eventHandlers = append(eventHandlers, pause.NewListenerGate(pauser, *config.GeneralChainConfig.Id))
+				}
 				l := log.With().Str("chain", fmt.Sprintf("%v", config.GeneralChainConfig.Name)).Uint8("domainID", *config.GeneralChainConfig.Id)
 
-				depositEventHandler := evmEventHandlers.NewDepositEventHandler(depositListener, depositHandler, bridgeAddress, *config.GeneralChainConfig.Id, msgChan)
+				depositEventHandler := evmEventHandlers.NewDepositEventHandler(depositListener, depositHandler, bridgeAddress, *config.GeneralChainConfig.Id, msgQueue, screener)
+				eventHandlers = append(eventHandlers, reorg.NewDetector(*config.GeneralChainConfig.Id, client, blockHashStore, depositEventHandler, propStore, msgQueue, sygmaMetrics))
 				eventHandlers = append(eventHandlers, depositEventHandler)
 				eventHandlers = append(eventHandlers, evmEventHandlers.NewKeygenEventHandler(l, tssListener, coordinator, host, communication, keyshareStore, bridgeAddress, networkTopology.Threshold))
 				eventHandlers = append(eventHandlers, evmEventHandlers.NewFrostKeygenEventHandler(l, tssListener, coordinator, host, communication, frostKeyshareStore, frostAddress, networkTopology.Threshold))
 				eventHandlers = append(eventHandlers, evmEventHandlers.NewRefreshEventHandler(l, topologyProvider, topologyStore, tssListener, coordinator, host, communication, connectionGate, keyshareStore, frostKeyshareStore, bridgeAddress))
-				eventHandlers = append(eventHandlers, evmEventHandlers.NewRetryV1EventHandler(l, tssListener, depositHandler, propStore, bridgeAddress, *config.GeneralChainConfig.Id, config.BlockConfirmations, msgChan))
+				eventHandlers = append(eventHandlers, evmEventHandlers.NewRetryV1EventHandler(l, tssListener, depositHandler, propStore, bridgeAddress, *config.GeneralChainConfig.Id, big.NewInt(0), msgQueue, screener))
 				if config.Retry != "" {
-					eventHandlers = append(eventHandlers, evmEventHandlers.NewRetryV2EventHandler(l, tssListener, common.HexToAddress(config.Retry), *config.GeneralChainConfig.Id, msgChan))
+					eventHandlers = append(eventHandlers, evmEventHandlers.NewRetryV2EventHandler(l, tssListener, common.HexToAddress(config.Retry), *config.GeneralChainConfig.Id, msgQueue))
+				}
+				var headSubscriber listener.HeadSubscriber
+				if config.HeadSubscription {
+					headSubscriber = client
 				}
-				evmListener := listener.NewEVMListener(client, eventHandlers, blockstore, sygmaMetrics, *config.GeneralChainConfig.Id, config.BlockRetryInterval, config.BlockConfirmations, config.BlockInterval)
+				evmListener := listener.NewEVMListener(confirmedHead, headSubscriber, eventHandlers, blockstore, sygmaMetrics, *config.GeneralChainConfig.Id, config.BlockRetryInterval, big.NewInt(0), config.BlockInterval)
 
 				mh := message.NewMessageHandler()
-				mh.RegisterMessageHandler(retry.RetryMessageType, executor.NewRetryMessageHandler(depositEventHandler, client, propStore, config.BlockConfirmations, msgChan))
-				mh.RegisterMessageHandler(transfer.TransferMessageType, &executor.TransferMessageHandler{})
-				executor := executor.NewExecutor(host, communication, coordinator, bridgeContract, keyshareStore, exitLock, config.GasLimit.Uint64(), config.TransferGas)
+				mh.RegisterMessageHandler(retry.RetryMessageType, executor.NewRetryMessageHandler(depositEventHandler, depositListener, bridgeAddress, confirmedHead, propStore, big.NewInt(0), msgQueue))
+				mh.RegisterMessageHandler(transfer.TransferMessageType, transferHandler)
+				executor := executor.NewExecutor(propStore, host, communication, coordinator, bridgeContract, volumeLimiter, keyshareStore, exitLock, config.GasLimit.Uint64(), config.TransferGas, fundingTracker)
 
 				startBlock, err := blockstore.GetStartBlock(*config.GeneralChainConfig.Id, config.StartBlock, config.GeneralChainConfig.LatestBlock, config.GeneralChainConfig.FreshStart)
 				if err != nil {
@@ -251,7 +296,11 @@ func Run() error {
 				}
 				chain := coreEvm.NewEVMChain(evmListener, mh, executor, *config.GeneralChainConfig.Id, startBlock)
 
-				domains[*config.GeneralChainConfig.Id] = chain
+				executionCheckers[*config.GeneralChainConfig.Id] = bridgeContract
+				balanceFetchers[*config.GeneralChainConfig.Id] = keyPool
+				domains[*config.GeneralChainConfig.Id] = queue.NewAcknowledgingChain(pause.NewPausingChain(dedup.NewDeduplicatingChain(policy.NewSigningPolicyChain(chain, signingPolicy, auditLog, sygmaMetrics), seenStore, propStore), pauser, propStore), msgQueue)
+				domainNames[*config.GeneralChainConfig.Id] = config.GeneralChainConfig.Name
+				txRetryDomains[*config.GeneralChainConfig.Id] = true
 			}
 		case "substrate":
 			{
@@ -278,16 +327,20 @@ func Run() error {
 				depositHandler := substrateListener.NewSubstrateDepositHandler()
 				depositHandler.RegisterDepositHandler(transfer.FungibleTransfer, substrateListener.FungibleTransferHandler)
 				eventHandlers := make([]coreSubstrateListener.EventHandler, 0)
-				depositEventHandler := substrateListener.NewFungibleTransferEventHandler(l, *config.GeneralChainConfig.Id, depositHandler, msgChan, conn)
-				eventHandlers = append(eventHandlers, substrateListener.NewRetryEventHandler(l, conn, depositHandler, *config.GeneralChainConfig.Id, msgChan))
+				if configuration.RelayerConfig.PauseConfig.HaltListeners {
+					eventHandlers = append(eventHandlers, pause.NewListenerGate(pauser, *config.GeneralChainConfig.Id))
+				}
+				depositEventHandler := substrateListener.NewFungibleTransferEventHandler(l, *config.GeneralChainConfig.Id, depositHandler, msgQueue, conn, screener)
+				eventHandlers = append(eventHandlers, reorg.NewDetector(*config.GeneralChainConfig.Id, substrateListener.NewBlockHashFetcher(conn), blockHashStore, depositEventHandler, propStore, msgQueue, sygmaMetrics))
+				eventHandlers = append(eventHandlers, substrateListener.NewRetryEventHandler(l, conn, depositHandler, *config.GeneralChainConfig.Id, msgQueue, screener, propStore))
 				eventHandlers = append(eventHandlers, depositEventHandler)
 				substrateListener := coreSubstrateListener.NewSubstrateListener(conn, eventHandlers, blockstore, sygmaMetrics, *config.GeneralChainConfig.Id, config.BlockRetryInterval, config.BlockInterval)
 
 				mh := message.NewMessageHandler()
-				mh.RegisterMessageHandler(transfer.TransferMessageType, &substrateExecutor.SubstrateMessageHandler{})
-				mh.RegisterMessageHandler(retry.RetryMessageType, substrateExecutor.NewRetryMessageHandler(depositEventHandler, conn, propStore, msgChan))
+				mh.RegisterMessageHandler(transfer.TransferMessageType, substrateExecutor.NewSubstrateMessageHandler(screener))
+				mh.RegisterMessageHandler(retry.RetryMessageType, substrateExecutor.NewRetryMessageHandler(depositEventHandler, conn, propStore, msgQueue))
 
-				sExecutor := substrateExecutor.NewExecutor(host, communication, coordinator, bridgePallet, keyshareStore, conn, exitLock)
+				sExecutor := substrateExecutor.NewExecutor(propStore, host, communication, coordinator, bridgePallet, volumeLimiter, keyshareStore, conn, exitLock, fundingTracker)
 
 				startBlock, err := blockstore.GetStartBlock(*config.GeneralChainConfig.Id, config.StartBlock, config.GeneralChainConfig.LatestBlock, config.GeneralChainConfig.FreshStart)
 				if err != nil {
@@ -306,7 +359,10 @@ func Run() error {
 				}
 				substrateChain := coreSubstrate.NewSubstrateChain(substrateListener, mh, sExecutor, *config.GeneralChainConfig.Id, startBlock)
 
-				domains[*config.GeneralChainConfig.Id] = substrateChain
+				executionCheckers[*config.GeneralChainConfig.Id] = bridgePallet
+				balanceFetchers[*config.GeneralChainConfig.Id] = substratePallet.NewBalanceFetcher(conn, keyPair)
+				domains[*config.GeneralChainConfig.Id] = queue.NewAcknowledgingChain(pause.NewPausingChain(dedup.NewDeduplicatingChain(policy.NewSigningPolicyChain(substrateChain, signingPolicy, auditLog, sygmaMetrics), seenStore, propStore), pauser, propStore), msgQueue)
+				domainNames[*config.GeneralChainConfig.Id] = config.GeneralChainConfig.Name
 			}
 		case "btc":
 			{
@@ -331,15 +387,18 @@ func Run() error {
 					resources[resource.ResourceID] = resource
 				}
 				depositHandler := &btcListener.BtcDepositHandler{}
-				depositEventHandler := btcListener.NewFungibleTransferEventHandler(l, *config.GeneralChainConfig.Id, depositHandler, msgChan, conn, resources, config.FeeAddress)
+				depositEventHandler := btcListener.NewFungibleTransferEventHandler(l, *config.GeneralChainConfig.Id, depositHandler, msgQueue, conn, resources, config.FeeAddress, screener)
 				eventHandlers := make([]btcListener.EventHandler, 0)
+				if configuration.RelayerConfig.PauseConfig.HaltListeners {
+					eventHandlers = append(eventHandlers, pause.NewBlockListenerGate(pauser, *config.GeneralChainConfig.Id))
+				}
 				eventHandlers = append(eventHandlers, depositEventHandler)
 				listener := btcListener.NewBtcListener(conn, eventHandlers, config, blockstore)
 
 				mempool := mempool.NewMempoolAPI(config.MempoolUrl)
 				mh := message.NewMessageHandler()
-				mh.RegisterMessageHandler(transfer.TransferMessageType, &btcExecutor.FungibleMessageHandler{})
-				mh.RegisterMessageHandler(retry.RetryMessageType, btcExecutor.NewRetryMessageHandler(depositEventHandler, conn, config.BlockConfirmations, propStore, msgChan))
+				mh.RegisterMessageHandler(transfer.TransferMessageType, btcExecutor.NewFungibleMessageHandler(screener))
+				mh.RegisterMessageHandler(retry.RetryMessageType, btcExecutor.NewRetryMessageHandler(depositEventHandler, conn, config.BlockConfirmations, propStore, msgQueue))
 				uploader := uploader.NewIPFSUploader(configuration.RelayerConfig.UploaderConfig)
 
 				executor := btcExecutor.NewExecutor(
@@ -348,6 +407,7 @@ func Run() error {
 					communication,
 					coordinator,
 					frostKeyshareStore,
+					volumeLimiter,
 					conn,
 					mempool,
 					resources,
@@ -356,7 +416,8 @@ func Run() error {
 					uploader)
 
 				btcChain := btc.NewBtcChain(listener, executor, mh, *config.GeneralChainConfig.Id)
-				domains[*config.GeneralChainConfig.Id] = btcChain
+				domains[*config.GeneralChainConfig.Id] = queue.NewAcknowledgingChain(pause.NewPausingChain(dedup.NewDeduplicatingChain(policy.NewSigningPolicyChain(btcChain, signingPolicy, auditLog, sygmaMetrics), seenStore, propStore), pauser, propStore), msgQueue)
+				domainNames[*config.GeneralChainConfig.Id] = config.GeneralChainConfig.Name
 
 			}
 		default:
@@ -365,9 +426,35 @@ func Run() error {
 	}
 
 	go jobs.StartCommunicationHealthCheckJob(host, configuration.RelayerConfig.MpcConfig.CommHealthCheckInterval, sygmaMetrics)
+	if configuration.RelayerConfig.PropRetentionDays > 0 {
+		retention := time.Duration(configuration.RelayerConfig.PropRetentionDays) * 24 * time.Hour
+		go jobs.StartPropPruningJob(propStore, retention, propPruningInterval)
+	}
+	var adminSweeper admin.Sweeper
+	if configuration.RelayerConfig.SweeperConfig.Enabled {
+		sweeperComm := p2p.NewCommunication(host, "p2p/sweeper")
+		sweeper := jobs.NewPropSweeper(host, sweeperComm, topologyStore, propStore, executionCheckers, msgQueue, configuration.RelayerConfig.SweeperConfig)
+		go sweeper.Start(ctx)
+		adminSweeper = sweeper
+	}
+	if configuration.RelayerConfig.BalanceMonitorConfig.Enabled {
+		balanceMonitor := jobs.NewBalanceMonitor(configuration.RelayerConfig.Id, balanceFetchers, sygmaMetrics, fundingTracker, configuration.RelayerConfig.BalanceMonitorConfig)
+		go balanceMonitor.Start(ctx)
+	}
+	go screener.Start(ctx)
+	if configuration.RelayerConfig.AdminConfig.Token != "" {
+		adminServer := admin.NewServer(configuration.RelayerConfig.AdminConfig, domainNames, txRetryDomains, host, blockstore, propStore, coordinator, pauseController, msgQueue, adminSweeper, auditLog)
+		go adminServer.Start(ctx)
+	}
 
 	r := relayer.NewRelayer(domains, sygmaMetrics)
 	go r.Start(ctx, msgChan)
+	go func() {
+		err := msgQueue.Replay()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed replaying queued messages")
+		}
+	}()
 
 	sysErr := make(chan os.Signal, 1)
 	signal.Notify(sysErr,
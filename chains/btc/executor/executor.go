@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"sync"
 	"time"
 
@@ -41,6 +42,10 @@ type MempoolAPI interface {
 	Utxos(address string) ([]mempool.Utxo, error)
 }
 
+type VolumeLimiter interface {
+	Allow(source, destination uint8, depositNonce uint64, resourceID [32]byte, amount *big.Int) error
+}
+
 type Executor struct {
 	coordinator *tss.Coordinator
 	host        host.Host
@@ -51,6 +56,7 @@ type Executor struct {
 	chainCfg  chaincfg.Params
 	mempool   MempoolAPI
 	fetcher   signing.SaveDataFetcher
+	limiter   VolumeLimiter
 
 	propStorer PropStorer
 	propMutex  sync.Mutex
@@ -65,6 +71,7 @@ func NewExecutor(
 	comm comm.Communication,
 	coordinator *tss.Coordinator,
 	fetcher signing.SaveDataFetcher,
+	limiter VolumeLimiter,
 	conn *connection.Connection,
 	mempool MempoolAPI,
 	resources map[[32]byte]config.Resource,
@@ -79,6 +86,7 @@ func NewExecutor(
 		coordinator: coordinator,
 		exitLock:    exitLock,
 		fetcher:     fetcher,
+		limiter:     limiter,
 		conn:        conn,
 		resources:   resources,
 		mempool:     mempool,
@@ -135,7 +143,7 @@ func (e *Executor) executeResourceProps(props []*BtcTransferProposal, resource c
 	p := pool.New().WithErrors()
 	executionContext, cancelExecution := context.WithCancel(context.Background())
 	watchContext, cancelWatch := context.WithCancel(context.Background())
-	sessionID := fmt.Sprintf("%s-%s", messageID, hex.EncodeToString(resource.ResourceID[:]))
+	sessionID := executionSessionID(messageID, resource.ResourceID)
 	defer cancelWatch()
 	p.Go(func() error {
 		return e.watchExecution(watchContext, cancelExecution, tx, props, sigChn, sessionID, messageID)
@@ -215,11 +223,11 @@ func (e *Executor) watchExecution(
 				hash, err := e.sendTx(tx, signatures, messageID)
 				if err != nil {
 					_ = e.comm.Broadcast(e.host.Peerstore().Peers(), []byte{}, comm.TssFailMsg, sessionID)
-					e.storeProposalsStatus(proposals, store.FailedProp)
+					e.storeProposalsFailure(proposals, err)
 					return err
 				}
 
-				e.storeProposalsStatus(proposals, store.ExecutedProp)
+				e.storeProposalsExecution(proposals, hash.String())
 				log.Info().Str("messageID", messageID).Msgf("Sent proposals execution with hash: %s", hash)
 				return nil
 			}
@@ -388,7 +396,18 @@ func (e *Executor) proposalsForExecution(proposals []*proposal.Proposal, message
 			continue
 		}
 
-		err = e.propStorer.StorePropStatus(prop.Source, prop.Destination, prop.Data.(BtcTransferProposalData).DepositNonce, store.PendingProp)
+		data := prop.Data.(BtcTransferProposalData)
+		err = e.limiter.Allow(prop.Source, prop.Destination, data.DepositNonce, data.ResourceId, volumeAmount(data.Amount))
+		if err != nil {
+			log.Err(err).Str("messageID", messageID).Msgf("Proposal %s rejected by volume limits", fmt.Sprintf("%d-%d-%d", prop.Source, prop.Destination, data.DepositNonce))
+			err = e.propStorer.StorePropFailure(prop.Source, prop.Destination, data.DepositNonce, err)
+			if err != nil {
+				log.Err(err).Str("messageID", messageID).Msgf("Failed storing proposal %+v failure", prop)
+			}
+			continue
+		}
+
+		err = e.propStorer.StorePropAttempt(prop.Source, prop.Destination, data.DepositNonce, messageID, executionSessionID(messageID, data.ResourceId))
 		if err != nil {
 			return props, err
 		}
@@ -414,17 +433,44 @@ func (e *Executor) isExecuted(prop *proposal.Proposal) (bool, error) {
 	return true, err
 }
 
-func (e *Executor) storeProposalsStatus(props []*BtcTransferProposal, status store.PropStatus) {
+func (e *Executor) storeProposalsExecution(props []*BtcTransferProposal, txHash string) {
+	e.propMutex.Lock()
+	for _, prop := range props {
+		err := e.propStorer.StorePropExecution(
+			prop.Source,
+			prop.Destination,
+			prop.Data.DepositNonce,
+			txHash)
+		if err != nil {
+			log.Err(err).Msgf("Failed storing proposal %+v execution", prop)
+		}
+	}
+	e.propMutex.Unlock()
+}
+
+func (e *Executor) storeProposalsFailure(props []*BtcTransferProposal, failure error) {
 	e.propMutex.Lock()
 	for _, prop := range props {
-		err := e.propStorer.StorePropStatus(
+		err := e.propStorer.StorePropFailure(
 			prop.Source,
 			prop.Destination,
 			prop.Data.DepositNonce,
-			status)
+			failure)
 		if err != nil {
-			log.Err(err).Msgf("Failed storing proposal %+v status %s", prop, status)
+			log.Err(err).Msgf("Failed storing proposal %+v failure", prop)
 		}
 	}
 	e.propMutex.Unlock()
 }
+
+// volumeAmount converts the amount in satoshis back to the 18 decimals
+// used by volume limits on other domains
+func volumeAmount(amount uint64) *big.Int {
+	multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(10), nil)
+	return new(big.Int).Mul(new(big.Int).SetUint64(amount), multiplier)
+}
+
+// executionSessionID is the ID of the session executing proposals for a resource
+func executionSessionID(messageID string, resourceID [32]byte) string {
+	return fmt.Sprintf("%s-%s", messageID, hex.EncodeToString(resourceID[:]))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
 
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ChainSafe/sygma-relayer/store"
 )
@@ -31,8 +32,22 @@ type BtcTransferProposal struct {
 	Data        BtcTransferProposalData
 }
 
-type FungibleMessageHandler struct{}
+type Screener interface {
+	Screen(m *message.Message, format screening.Format, address string) bool
+}
+
+type FungibleMessageHandler struct {
+	screener Screener
+}
+
+func NewFungibleMessageHandler(screener Screener) *FungibleMessageHandler {
+	return &FungibleMessageHandler{
+		screener: screener,
+	}
+}
 
+// HandleMessage converts the transfer message into a proposal.
+// Transfers to screened recipients are quarantined and no proposal is returned.
 func (h *FungibleMessageHandler) HandleMessage(msg *message.Message) (*proposal.Proposal, error) {
 	transferMessage := &transfer.TransferMessage{
 		Source:      msg.Source,
@@ -41,6 +56,12 @@ func (h *FungibleMessageHandler) HandleMessage(msg *message.Message) (*proposal.
 		Type:        msg.Type,
 		ID:          msg.ID,
 	}
+	if len(transferMessage.Data.Payload) == 2 {
+		recipient, ok := transferMessage.Data.Payload[1].([]byte)
+		if ok && h.screener.Screen(msg, screening.BTCFormat, string(recipient)) {
+			return nil, nil
+		}
+	}
 
 	switch transferMessage.Data.Type {
 	case transfer.FungibleTransfer:
@@ -84,18 +105,25 @@ type BlockFetcher interface {
 type PropStorer interface {
 	StorePropStatus(source, destination uint8, depositNonce uint64, status store.PropStatus) error
 	PropStatus(source, destination uint8, depositNonce uint64) (store.PropStatus, error)
+	StorePropAttempt(source, destination uint8, depositNonce uint64, messageID string, sessionID string) error
+	StorePropExecution(source, destination uint8, depositNonce uint64, txHash string) error
+	StorePropFailure(source, destination uint8, depositNonce uint64, failure error) error
 }
 
 type DepositProcessor interface {
 	ProcessDeposits(blockNumber *big.Int) (map[uint8][]*message.Message, error)
 }
 
+type MessageQueue interface {
+	Enqueue(block *big.Int, domainMessages map[uint8][]*message.Message) error
+}
+
 type RetryMessageHandler struct {
 	depositProcessor   DepositProcessor
 	blockFetcher       BlockFetcher
 	blockConfirmations *big.Int
 	propStorer         PropStorer
-	msgChan            chan []*message.Message
+	msgQueue           MessageQueue
 }
 
 func NewRetryMessageHandler(
@@ -103,18 +131,21 @@ func NewRetryMessageHandler(
 	blockFetcher BlockFetcher,
 	blockConfirmations *big.Int,
 	propStorer PropStorer,
-	msgChan chan []*message.Message) *RetryMessageHandler {
+	msgQueue MessageQueue) *RetryMessageHandler {
 	return &RetryMessageHandler{
 		depositProcessor:   depositProcessor,
 		blockFetcher:       blockFetcher,
 		blockConfirmations: blockConfirmations,
 		propStorer:         propStorer,
-		msgChan:            msgChan,
+		msgQueue:           msgQueue,
 	}
 }
 
 func (h *RetryMessageHandler) HandleMessage(msg *message.Message) (*proposal.Proposal, error) {
 	retryData := msg.Data.(retry.RetryMessageData)
+	if retryData.TxHash != "" {
+		return nil, fmt.Errorf("retry of transaction %s not supported", retryData.TxHash)
+	}
 	hash, err := h.blockFetcher.GetBestBlockHash()
 	if err != nil {
 		return nil, err
@@ -144,6 +175,8 @@ func (h *RetryMessageHandler) HandleMessage(msg *message.Message) (*proposal.Pro
 		return nil, nil
 	}
 
-	h.msgChan <- filteredDeposits
-	return nil, nil
+	retry.MarkRetried(filteredDeposits, msg.ID)
+	return nil, h.msgQueue.Enqueue(retryData.BlockHeight, map[uint8][]*message.Message{
+		retryData.DestinationDomainID: filteredDeposits,
+	})
 }
@@ -2,12 +2,15 @@ package executor_test
 
 import (
 	"math/big"
+	"sort"
 	"testing"
 
 	"github.com/ChainSafe/sygma-relayer/chains/btc/executor"
 	mock_executor "github.com/ChainSafe/sygma-relayer/chains/btc/executor/mock"
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
 	"github.com/ChainSafe/sygma-relayer/e2e/evm"
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ChainSafe/sygma-relayer/store"
 	"github.com/btcsuite/btcd/btcjson"
@@ -18,6 +21,9 @@ import (
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
 )
 
+// unscreened is a screener without a screening list that flags no address
+var unscreened, _ = screening.NewScreener(relayer.ScreeningConfig{}, nil, nil)
+
 type BtcMessageHandlerTestSuite struct {
 	suite.Suite
 }
@@ -42,7 +48,7 @@ func (s *BtcMessageHandlerTestSuite) Test_ERC20HandleMessage_ValidMessage() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.FungibleMessageHandler{}
+	mh := executor.NewFungibleMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(err)
@@ -75,7 +81,7 @@ func (s *BtcMessageHandlerTestSuite) Test_ERC20HandleMessage_ValidMessage_Dust()
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.FungibleMessageHandler{}
+	mh := executor.NewFungibleMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(err)
@@ -107,7 +113,7 @@ func (s *BtcMessageHandlerTestSuite) Test_ERC20HandleMessage_IncorrectDataLen()
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.FungibleMessageHandler{}
+	mh := executor.NewFungibleMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -131,7 +137,7 @@ func (s *BtcMessageHandlerTestSuite) Test_ERC20HandleMessage_IncorrectAmount() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.FungibleMessageHandler{}
+	mh := executor.NewFungibleMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -154,7 +160,7 @@ func (s *BtcMessageHandlerTestSuite) Test_ERC20HandleMessage_IncorrectRecipient(
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.FungibleMessageHandler{}
+	mh := executor.NewFungibleMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -177,7 +183,7 @@ func (s *BtcMessageHandlerTestSuite) Test_HandleMessage_InvalidType() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.FungibleMessageHandler{}
+	mh := executor.NewFungibleMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -192,6 +198,7 @@ type RetryMessageHandlerTestSuite struct {
 	mockDepositProcessor *mock_executor.MockDepositProcessor
 	mockPropStorer       *mock_executor.MockPropStorer
 	msgChan              chan []*message.Message
+	mockMessageQueue     *mock_executor.MockMessageQueue
 }
 
 func TestRunRetryMessageHandlerTestSuite(t *testing.T) {
@@ -204,12 +211,24 @@ func (s *RetryMessageHandlerTestSuite) SetupTest() {
 	s.mockDepositProcessor = mock_executor.NewMockDepositProcessor(ctrl)
 	s.mockPropStorer = mock_executor.NewMockPropStorer(ctrl)
 	s.msgChan = make(chan []*message.Message, 1)
+	s.mockMessageQueue = mock_executor.NewMockMessageQueue(ctrl)
+	s.mockMessageQueue.EXPECT().Enqueue(gomock.Any(), gomock.Any()).DoAndReturn(func(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+		domains := make([]int, 0)
+		for domain := range domainMessages {
+			domains = append(domains, int(domain))
+		}
+		sort.Ints(domains)
+		for _, domain := range domains {
+			s.msgChan <- domainMessages[uint8(domain)]
+		}
+		return nil
+	}).AnyTimes()
 	s.messageHandler = executor.NewRetryMessageHandler(
 		s.mockDepositProcessor,
 		s.mockBlockFetcher,
 		big.NewInt(5),
 		s.mockPropStorer,
-		s.msgChan)
+		s.mockMessageQueue)
 }
 
 func (s *RetryMessageHandlerTestSuite) Test_HandleMessage_RetryTooNew() {
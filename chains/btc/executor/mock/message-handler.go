@@ -8,6 +8,7 @@ import (
 	big "math/big"
 	reflect "reflect"
 
+	screening "github.com/ChainSafe/sygma-relayer/relayer/screening"
 	store "github.com/ChainSafe/sygma-relayer/store"
 	btcjson "github.com/btcsuite/btcd/btcjson"
 	chainhash "github.com/btcsuite/btcd/chaincfg/chainhash"
@@ -15,6 +16,43 @@ import (
 	message "github.com/sygmaprotocol/sygma-core/relayer/message"
 )
 
+// MockScreener is a mock of Screener interface.
+type MockScreener struct {
+	ctrl     *gomock.Controller
+	recorder *MockScreenerMockRecorder
+}
+
+// MockScreenerMockRecorder is the mock recorder for MockScreener.
+type MockScreenerMockRecorder struct {
+	mock *MockScreener
+}
+
+// NewMockScreener creates a new mock instance.
+func NewMockScreener(ctrl *gomock.Controller) *MockScreener {
+	mock := &MockScreener{ctrl: ctrl}
+	mock.recorder = &MockScreenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScreener) EXPECT() *MockScreenerMockRecorder {
+	return m.recorder
+}
+
+// Screen mocks base method.
+func (m_2 *MockScreener) Screen(m *message.Message, format screening.Format, address string) bool {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Screen", m, format, address)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Screen indicates an expected call of Screen.
+func (mr *MockScreenerMockRecorder) Screen(m, format, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Screen", reflect.TypeOf((*MockScreener)(nil).Screen), m, format, address)
+}
+
 // MockBlockFetcher is a mock of BlockFetcher interface.
 type MockBlockFetcher struct {
 	ctrl     *gomock.Controller
@@ -106,6 +144,48 @@ func (mr *MockPropStorerMockRecorder) PropStatus(source, destination, depositNon
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropStatus", reflect.TypeOf((*MockPropStorer)(nil).PropStatus), source, destination, depositNonce)
 }
 
+// StorePropAttempt mocks base method.
+func (m *MockPropStorer) StorePropAttempt(source, destination uint8, depositNonce uint64, messageID, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorePropAttempt", source, destination, depositNonce, messageID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StorePropAttempt indicates an expected call of StorePropAttempt.
+func (mr *MockPropStorerMockRecorder) StorePropAttempt(source, destination, depositNonce, messageID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePropAttempt", reflect.TypeOf((*MockPropStorer)(nil).StorePropAttempt), source, destination, depositNonce, messageID, sessionID)
+}
+
+// StorePropExecution mocks base method.
+func (m *MockPropStorer) StorePropExecution(source, destination uint8, depositNonce uint64, txHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorePropExecution", source, destination, depositNonce, txHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StorePropExecution indicates an expected call of StorePropExecution.
+func (mr *MockPropStorerMockRecorder) StorePropExecution(source, destination, depositNonce, txHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePropExecution", reflect.TypeOf((*MockPropStorer)(nil).StorePropExecution), source, destination, depositNonce, txHash)
+}
+
+// StorePropFailure mocks base method.
+func (m *MockPropStorer) StorePropFailure(source, destination uint8, depositNonce uint64, failure error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorePropFailure", source, destination, depositNonce, failure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StorePropFailure indicates an expected call of StorePropFailure.
+func (mr *MockPropStorerMockRecorder) StorePropFailure(source, destination, depositNonce, failure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePropFailure", reflect.TypeOf((*MockPropStorer)(nil).StorePropFailure), source, destination, depositNonce, failure)
+}
+
 // StorePropStatus mocks base method.
 func (m *MockPropStorer) StorePropStatus(source, destination uint8, depositNonce uint64, status store.PropStatus) error {
 	m.ctrl.T.Helper()
@@ -157,3 +237,40 @@ func (mr *MockDepositProcessorMockRecorder) ProcessDeposits(blockNumber interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessDeposits", reflect.TypeOf((*MockDepositProcessor)(nil).ProcessDeposits), blockNumber)
 }
+
+// MockMessageQueue is a mock of MessageQueue interface.
+type MockMessageQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageQueueMockRecorder
+}
+
+// MockMessageQueueMockRecorder is the mock recorder for MockMessageQueue.
+type MockMessageQueueMockRecorder struct {
+	mock *MockMessageQueue
+}
+
+// NewMockMessageQueue creates a new mock instance.
+func NewMockMessageQueue(ctrl *gomock.Controller) *MockMessageQueue {
+	mock := &MockMessageQueue{ctrl: ctrl}
+	mock.recorder = &MockMessageQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageQueue) EXPECT() *MockMessageQueueMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockMessageQueue) Enqueue(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", block, domainMessages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockMessageQueueMockRecorder) Enqueue(block, domainMessages interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockMessageQueue)(nil).Enqueue), block, domainMessages)
+}
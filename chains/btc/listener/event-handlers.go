@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/ChainSafe/sygma-relayer/chains/btc/config"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/btcsuite/btcd/btcjson"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/rs/zerolog"
@@ -27,6 +29,10 @@ type Deposit struct {
 	Data   string
 }
 
+type MessageQueue interface {
+	Enqueue(block *big.Int, domainMessages map[uint8][]*message.Message) error
+}
+
 type DepositHandler interface {
 	HandleDeposit(
 		sourceID uint8,
@@ -39,32 +45,39 @@ type DepositHandler interface {
 	) (*message.Message, error)
 }
 
+type Screener interface {
+	Screen(m *message.Message, format screening.Format, address string) bool
+}
+
 type FungibleTransferEventHandler struct {
 	depositHandler DepositHandler
 	domainID       uint8
 	feeAddress     btcutil.Address
 	log            zerolog.Logger
 	conn           Connection
-	msgChan        chan []*message.Message
+	msgQueue       MessageQueue
 	resources      map[[32]byte]config.Resource
+	screener       Screener
 }
 
 func NewFungibleTransferEventHandler(
 	logC zerolog.Context,
 	domainID uint8,
 	depositHandler DepositHandler,
-	msgChan chan []*message.Message,
+	msgQueue MessageQueue,
 	conn Connection,
 	resources map[[32]byte]config.Resource,
-	feeAddress btcutil.Address) *FungibleTransferEventHandler {
+	feeAddress btcutil.Address,
+	screener Screener) *FungibleTransferEventHandler {
 	return &FungibleTransferEventHandler{
 		depositHandler: depositHandler,
 		domainID:       domainID,
 		feeAddress:     feeAddress,
 		log:            logC.Logger(),
 		conn:           conn,
-		msgChan:        msgChan,
+		msgQueue:       msgQueue,
 		resources:      resources,
+		screener:       screener,
 	}
 }
 
@@ -74,12 +87,7 @@ func (eh *FungibleTransferEventHandler) HandleEvents(blockNumber *big.Int) error
 		return err
 	}
 
-	for _, deposits := range domainDeposits {
-		go func(d []*message.Message) {
-			eh.msgChan <- d
-		}(deposits)
-	}
-	return nil
+	return eh.msgQueue.Enqueue(blockNumber, domainDeposits)
 }
 
 func (eh *FungibleTransferEventHandler) ProcessDeposits(blockNumber *big.Int) (map[uint8][]*message.Message, error) {
@@ -114,6 +122,11 @@ func (eh *FungibleTransferEventHandler) ProcessDeposits(blockNumber *big.Int) (m
 				if err != nil {
 					return err
 				}
+				transfer.SetDepositBlock(m, blockNumber.Uint64())
+				transfer.SetDepositor(m, d.SenderAddress)
+				if eh.screener.Screen(m, screening.BTCFormat, d.SenderAddress) {
+					return nil
+				}
 
 				log.Debug().Str("messageID", m.ID).Msgf("Resolved message %+v in block: %s", m, blockNumber.String())
 				domainDeposits[m.Destination] = append(domainDeposits[m.Destination], m)
@@ -6,6 +6,7 @@ package listener_test
 import (
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 
 	"github.com/ChainSafe/sygma-relayer/chains/btc/config"
@@ -33,8 +34,10 @@ type DepositHandlerTestSuite struct {
 	domainID                     uint8
 	resources                    map[[32]byte]config.Resource
 	msgChan                      chan []*message.Message
+	mockMessageQueue             *mock_listener.MockMessageQueue
 	mockConn                     *mock_listener.MockConnection
 	feeAddress                   btcutil.Address
+	mockScreener                 *mock_listener.MockScreener
 }
 
 func TestRunDepositHandlerTestSuite(t *testing.T) {
@@ -53,8 +56,22 @@ func (s *DepositHandlerTestSuite) SetupTest() {
 	s.resources[[32]byte{2}] = config.Resource{Address: address2, ResourceID: [32]byte{2}, FeeAmount: big.NewInt(10001)}
 	s.mockDepositHandler = mock_listener.NewMockDepositHandler(ctrl)
 	s.msgChan = make(chan []*message.Message, 2)
+	s.mockMessageQueue = mock_listener.NewMockMessageQueue(ctrl)
+	s.mockMessageQueue.EXPECT().Enqueue(gomock.Any(), gomock.Any()).DoAndReturn(func(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+		domains := make([]int, 0)
+		for domain := range domainMessages {
+			domains = append(domains, int(domain))
+		}
+		sort.Ints(domains)
+		for _, domain := range domains {
+			s.msgChan <- domainMessages[uint8(domain)]
+		}
+		return nil
+	}).AnyTimes()
 	s.mockConn = mock_listener.NewMockConnection(ctrl)
-	s.fungibleTransferEventHandler = listener.NewFungibleTransferEventHandler(zerolog.Context{}, s.domainID, s.mockDepositHandler, s.msgChan, s.mockConn, s.resources, s.feeAddress)
+	s.mockScreener = mock_listener.NewMockScreener(ctrl)
+	s.mockScreener.EXPECT().Screen(gomock.Any(), gomock.Any(), gomock.Any()).Return(false).AnyTimes()
+	s.fungibleTransferEventHandler = listener.NewFungibleTransferEventHandler(zerolog.Context{}, s.domainID, s.mockDepositHandler, s.mockMessageQueue, s.mockConn, s.resources, s.feeAddress, s.mockScreener)
 }
 
 func (s *DepositHandlerTestSuite) Test_FetchDepositFails_GetBlockHashError() {
@@ -9,10 +9,48 @@ import (
 	reflect "reflect"
 	time "time"
 
+	screening "github.com/ChainSafe/sygma-relayer/relayer/screening"
 	gomock "github.com/golang/mock/gomock"
 	message "github.com/sygmaprotocol/sygma-core/relayer/message"
 )
 
+// MockMessageQueue is a mock of MessageQueue interface.
+type MockMessageQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageQueueMockRecorder
+}
+
+// MockMessageQueueMockRecorder is the mock recorder for MockMessageQueue.
+type MockMessageQueueMockRecorder struct {
+	mock *MockMessageQueue
+}
+
+// NewMockMessageQueue creates a new mock instance.
+func NewMockMessageQueue(ctrl *gomock.Controller) *MockMessageQueue {
+	mock := &MockMessageQueue{ctrl: ctrl}
+	mock.recorder = &MockMessageQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageQueue) EXPECT() *MockMessageQueueMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockMessageQueue) Enqueue(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", block, domainMessages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockMessageQueueMockRecorder) Enqueue(block, domainMessages interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockMessageQueue)(nil).Enqueue), block, domainMessages)
+}
+
 // MockDepositHandler is a mock of DepositHandler interface.
 type MockDepositHandler struct {
 	ctrl     *gomock.Controller
@@ -50,3 +88,40 @@ func (mr *MockDepositHandlerMockRecorder) HandleDeposit(sourceID, depositNonce,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDeposit", reflect.TypeOf((*MockDepositHandler)(nil).HandleDeposit), sourceID, depositNonce, resourceID, amount, data, blockNumber, timestamp)
 }
+
+// MockScreener is a mock of Screener interface.
+type MockScreener struct {
+	ctrl     *gomock.Controller
+	recorder *MockScreenerMockRecorder
+}
+
+// MockScreenerMockRecorder is the mock recorder for MockScreener.
+type MockScreenerMockRecorder struct {
+	mock *MockScreener
+}
+
+// NewMockScreener creates a new mock instance.
+func NewMockScreener(ctrl *gomock.Controller) *MockScreener {
+	mock := &MockScreener{ctrl: ctrl}
+	mock.recorder = &MockScreenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScreener) EXPECT() *MockScreenerMockRecorder {
+	return m.recorder
+}
+
+// Screen mocks base method.
+func (m_2 *MockScreener) Screen(m *message.Message, format screening.Format, address string) bool {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Screen", m, format, address)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Screen indicates an expected call of Screen.
+func (mr *MockScreenerMockRecorder) Screen(m, format, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Screen", reflect.TypeOf((*MockScreener)(nil).Screen), m, format, address)
+}
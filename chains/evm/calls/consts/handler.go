@@ -0,0 +1,34 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package consts
+
+// HandlerABI contains the executeProposal function implemented by every handler
+const HandlerABI = `
+[
+	{
+		"inputs": [
+			{
+				"internalType": "bytes32",
+				"name": "resourceID",
+				"type": "bytes32"
+			},
+			{
+				"internalType": "bytes",
+				"name": "data",
+				"type": "bytes"
+			}
+		],
+		"name": "executeProposal",
+		"outputs": [
+			{
+				"internalType": "bytes",
+				"name": "",
+				"type": "bytes"
+			}
+		],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]
+`
@@ -5,16 +5,21 @@ package bridge
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/ChainSafe/sygma-relayer/chains"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/consts"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/rs/zerolog/log"
 
 	"github.com/sygmaprotocol/sygma-core/chains/evm/client"
@@ -24,6 +29,9 @@ import (
 
 const bridgeVersion = "3.1.0"
 
+// revertErrorCode is the JSON-RPC error code of reverted calls
+const revertErrorCode = 3
+
 type BridgeProposal struct {
 	OriginDomainID uint8
 	ResourceID     [32]byte
@@ -34,11 +42,26 @@ type BridgeProposal struct {
 type ChainClient interface {
 	client.Client
 	ChainID(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+}
+
+// RevertError is returned if the simulated proposal execution reverts
+type RevertError struct {
+	Reason string
+}
+
+func (e *RevertError) Error() string {
+	return fmt.Sprintf("proposal execution reverted: %s", e.Reason)
 }
 
 type BridgeContract struct {
 	contracts.Contract
-	client ChainClient
+	client       ChainClient
+	handlerABI   abi.ABI
+	multicallABI abi.ABI
+
+	multicallLock     sync.Mutex
+	multicallDeployed *bool
 }
 
 func NewBridgeContract(
@@ -47,9 +70,13 @@ func NewBridgeContract(
 	transactor transactor.Transactor,
 ) *BridgeContract {
 	a, _ := abi.JSON(strings.NewReader(consts.BridgeABI))
+	handlerABI, _ := abi.JSON(strings.NewReader(consts.HandlerABI))
+	multicallABI, _ := abi.JSON(strings.NewReader(consts.MulticallABI))
 	return &BridgeContract{
-		Contract: contracts.NewContract(bridgeContractAddress, a, nil, client, transactor),
-		client:   client,
+		Contract:     contracts.NewContract(bridgeContractAddress, a, nil, client, transactor),
+		client:       client,
+		handlerABI:   handlerABI,
+		multicallABI: multicallABI,
 	}
 }
 
@@ -126,6 +153,62 @@ func (c *BridgeContract) GetHandlerAddressForResourceID(
 	return out, nil
 }
 
+// SimulateProposal executes the proposal on its handler with eth_call as if it was called by the bridge
+// and returns the estimated gas of the handler execution.
+// RevertError is returned if the execution would revert.
+func (c *BridgeContract) SimulateProposal(p *transfer.TransferProposal) (uint64, error) {
+	handler, err := c.GetHandlerAddressForResourceID(p.Data.ResourceId)
+	if err != nil {
+		return 0, err
+	}
+	if handler == (common.Address{}) {
+		return 0, &RevertError{Reason: fmt.Sprintf("resource %s not mapped to handler", hexutil.Encode(p.Data.ResourceId[:]))}
+	}
+
+	input, err := c.handlerABI.Pack("executeProposal", p.Data.ResourceId, p.Data.Data)
+	if err != nil {
+		return 0, err
+	}
+	msg := ethereum.CallMsg{From: *c.ContractAddress(), To: &handler, Data: input}
+	_, err = c.client.CallContract(context.Background(), client.ToCallArg(msg), nil)
+	if err != nil {
+		return 0, revertError(err)
+	}
+	gas, err := c.client.EstimateGas(context.Background(), msg)
+	if err != nil {
+		return 0, revertError(err)
+	}
+	return gas, nil
+}
+
+// revertError converts errors of reverted calls to RevertError with the decoded revert reason
+func revertError(err error) error {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return err
+	}
+	if rpcErr.ErrorCode() != revertErrorCode && !strings.Contains(rpcErr.Error(), "revert") {
+		return err
+	}
+
+	reason := rpcErr.Error()
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			revertData, decodeErr := hexutil.Decode(data)
+			if decodeErr != nil {
+				return &RevertError{Reason: reason}
+			}
+			unpacked, unpackErr := abi.UnpackRevert(revertData)
+			if unpackErr != nil {
+				return &RevertError{Reason: fmt.Sprintf("%s %s", reason, data)}
+			}
+			reason = unpacked
+		}
+	}
+	return &RevertError{Reason: reason}
+}
+
 func (c *BridgeContract) Retry(hash common.Hash, opts transactor.TransactOptions) (*common.Hash, error) {
 	log.Debug().Msgf("Retrying deposit from transaction: %s", hash.Hex())
 	return c.ExecuteTransaction("retry", opts, hash.Hex())
@@ -0,0 +1,228 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/calls/contracts/bridge/bridge.go
+
+// Package mock_bridge is a generated GoMock package.
+package mock_bridge
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	common "github.com/ethereum/go-ethereum/common"
+	types "github.com/ethereum/go-ethereum/core/types"
+	gomock "github.com/golang/mock/gomock"
+	client "github.com/sygmaprotocol/sygma-core/chains/evm/client"
+)
+
+// MockChainClient is a mock of ChainClient interface.
+type MockChainClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainClientMockRecorder
+}
+
+// MockChainClientMockRecorder is the mock recorder for MockChainClient.
+type MockChainClientMockRecorder struct {
+	mock *MockChainClient
+}
+
+// NewMockChainClient creates a new mock instance.
+func NewMockChainClient(ctrl *gomock.Controller) *MockChainClient {
+	mock := &MockChainClient{ctrl: ctrl}
+	mock.recorder = &MockChainClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChainClient) EXPECT() *MockChainClientMockRecorder {
+	return m.recorder
+}
+
+// CallContract mocks base method.
+func (m *MockChainClient) CallContract(ctx context.Context, callArgs map[string]interface{}, blockNumber *big.Int) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CallContract", ctx, callArgs, blockNumber)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CallContract indicates an expected call of CallContract.
+func (mr *MockChainClientMockRecorder) CallContract(ctx, callArgs, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallContract", reflect.TypeOf((*MockChainClient)(nil).CallContract), ctx, callArgs, blockNumber)
+}
+
+// ChainID mocks base method.
+func (m *MockChainClient) ChainID(ctx context.Context) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainID", ctx)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainID indicates an expected call of ChainID.
+func (mr *MockChainClientMockRecorder) ChainID(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainID", reflect.TypeOf((*MockChainClient)(nil).ChainID), ctx)
+}
+
+// CodeAt mocks base method.
+func (m *MockChainClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CodeAt", ctx, contract, blockNumber)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CodeAt indicates an expected call of CodeAt.
+func (mr *MockChainClientMockRecorder) CodeAt(ctx, contract, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CodeAt", reflect.TypeOf((*MockChainClient)(nil).CodeAt), ctx, contract, blockNumber)
+}
+
+// EstimateGas mocks base method.
+func (m *MockChainClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateGas", ctx, msg)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateGas indicates an expected call of EstimateGas.
+func (mr *MockChainClientMockRecorder) EstimateGas(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateGas", reflect.TypeOf((*MockChainClient)(nil).EstimateGas), ctx, msg)
+}
+
+// From mocks base method.
+func (m *MockChainClient) From() common.Address {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "From")
+	ret0, _ := ret[0].(common.Address)
+	return ret0
+}
+
+// From indicates an expected call of From.
+func (mr *MockChainClientMockRecorder) From() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "From", reflect.TypeOf((*MockChainClient)(nil).From))
+}
+
+// GetTransactionByHash mocks base method.
+func (m *MockChainClient) GetTransactionByHash(h common.Hash) (*types.Transaction, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByHash", h)
+	ret0, _ := ret[0].(*types.Transaction)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTransactionByHash indicates an expected call of GetTransactionByHash.
+func (mr *MockChainClientMockRecorder) GetTransactionByHash(h interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByHash", reflect.TypeOf((*MockChainClient)(nil).GetTransactionByHash), h)
+}
+
+// LockNonce mocks base method.
+func (m *MockChainClient) LockNonce() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LockNonce")
+}
+
+// LockNonce indicates an expected call of LockNonce.
+func (mr *MockChainClientMockRecorder) LockNonce() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockNonce", reflect.TypeOf((*MockChainClient)(nil).LockNonce))
+}
+
+// SignAndSendTransaction mocks base method.
+func (m *MockChainClient) SignAndSendTransaction(ctx context.Context, tx client.CommonTransaction) (common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignAndSendTransaction", ctx, tx)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignAndSendTransaction indicates an expected call of SignAndSendTransaction.
+func (mr *MockChainClientMockRecorder) SignAndSendTransaction(ctx, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignAndSendTransaction", reflect.TypeOf((*MockChainClient)(nil).SignAndSendTransaction), ctx, tx)
+}
+
+// TransactionReceipt mocks base method.
+func (m *MockChainClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransactionReceipt", ctx, txHash)
+	ret0, _ := ret[0].(*types.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransactionReceipt indicates an expected call of TransactionReceipt.
+func (mr *MockChainClientMockRecorder) TransactionReceipt(ctx, txHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionReceipt", reflect.TypeOf((*MockChainClient)(nil).TransactionReceipt), ctx, txHash)
+}
+
+// UnlockNonce mocks base method.
+func (m *MockChainClient) UnlockNonce() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UnlockNonce")
+}
+
+// UnlockNonce indicates an expected call of UnlockNonce.
+func (mr *MockChainClientMockRecorder) UnlockNonce() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockNonce", reflect.TypeOf((*MockChainClient)(nil).UnlockNonce))
+}
+
+// UnsafeIncreaseNonce mocks base method.
+func (m *MockChainClient) UnsafeIncreaseNonce() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsafeIncreaseNonce")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnsafeIncreaseNonce indicates an expected call of UnsafeIncreaseNonce.
+func (mr *MockChainClientMockRecorder) UnsafeIncreaseNonce() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsafeIncreaseNonce", reflect.TypeOf((*MockChainClient)(nil).UnsafeIncreaseNonce))
+}
+
+// UnsafeNonce mocks base method.
+func (m *MockChainClient) UnsafeNonce() (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsafeNonce")
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnsafeNonce indicates an expected call of UnsafeNonce.
+func (mr *MockChainClientMockRecorder) UnsafeNonce() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsafeNonce", reflect.TypeOf((*MockChainClient)(nil).UnsafeNonce))
+}
+
+// WaitAndReturnTxReceipt mocks base method.
+func (m *MockChainClient) WaitAndReturnTxReceipt(h common.Hash) (*types.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitAndReturnTxReceipt", h)
+	ret0, _ := ret[0].(*types.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitAndReturnTxReceipt indicates an expected call of WaitAndReturnTxReceipt.
+func (mr *MockChainClientMockRecorder) WaitAndReturnTxReceipt(h interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitAndReturnTxReceipt", reflect.TypeOf((*MockChainClient)(nil).WaitAndReturnTxReceipt), h)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/calls/contracts/bridge/multicall.go
+
+// Package mock_bridge is a generated GoMock package.
+package mock_bridge
+
+import (
+	context "context"
+	reflect "reflect"
+
+	rpc "github.com/ethereum/go-ethereum/rpc"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBatchCaller is a mock of BatchCaller interface.
+type MockBatchCaller struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchCallerMockRecorder
+}
+
+// MockBatchCallerMockRecorder is the mock recorder for MockBatchCaller.
+type MockBatchCallerMockRecorder struct {
+	mock *MockBatchCaller
+}
+
+// NewMockBatchCaller creates a new mock instance.
+func NewMockBatchCaller(ctrl *gomock.Controller) *MockBatchCaller {
+	mock := &MockBatchCaller{ctrl: ctrl}
+	mock.recorder = &MockBatchCallerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchCaller) EXPECT() *MockBatchCallerMockRecorder {
+	return m.recorder
+}
+
+// BatchCallContext mocks base method.
+func (m *MockBatchCaller) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCallContext", ctx, b)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchCallContext indicates an expected call of BatchCallContext.
+func (mr *MockBatchCallerMockRecorder) BatchCallContext(ctx, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCallContext", reflect.TypeOf((*MockBatchCaller)(nil).BatchCallContext), ctx, b)
+}
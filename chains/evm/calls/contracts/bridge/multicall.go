@@ -0,0 +1,174 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/client"
+)
+
+var (
+	// MulticallAddress is the address Multicall3 is deployed at on most EVM chains
+	MulticallAddress = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+	// StatusBatchSize is the maximum number of status reads sent in a single request
+	StatusBatchSize = 100
+)
+
+// BatchCaller sends multiple JSON-RPC requests in a single batch
+type BatchCaller interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+type multicallCall struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+type multicallResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// AreProposalsExecuted returns the execution status of each proposal.
+// Statuses are read through Multicall3 if it is deployed on the chain, with JSON-RPC batch
+// requests if the client supports them, or with a call per proposal otherwise.
+func (c *BridgeContract) AreProposalsExecuted(proposals []*transfer.TransferProposal) ([]bool, error) {
+	executed := make([]bool, 0, len(proposals))
+	for start := 0; start < len(proposals); start += StatusBatchSize {
+		end := start + StatusBatchSize
+		if end > len(proposals) {
+			end = len(proposals)
+		}
+
+		calls := make([][]byte, end-start)
+		for i, p := range proposals[start:end] {
+			input, err := c.PackMethod("isProposalExecuted", p.Source, new(big.Int).SetUint64(p.Data.DepositNonce))
+			if err != nil {
+				return nil, err
+			}
+			calls[i] = input
+		}
+
+		var results [][]byte
+		var err error
+		batchCaller, isBatchCaller := c.client.(BatchCaller)
+		switch {
+		case c.hasMulticall():
+			results, err = c.multicall(calls)
+		case isBatchCaller:
+			results, err = c.batchCall(batchCaller, calls)
+		default:
+			results, err = c.sequentialCall(calls)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range results {
+			res, err := c.UnpackResult("isProposalExecuted", result)
+			if err != nil {
+				return nil, err
+			}
+			executed = append(executed, *abi.ConvertType(res[0], new(bool)).(*bool))
+		}
+	}
+	return executed, nil
+}
+
+// hasMulticall checks once if Multicall3 is deployed on the chain
+func (c *BridgeContract) hasMulticall() bool {
+	c.multicallLock.Lock()
+	defer c.multicallLock.Unlock()
+
+	if c.multicallDeployed == nil {
+		code, err := c.client.CodeAt(context.Background(), MulticallAddress, nil)
+		if err != nil {
+			return false
+		}
+
+		deployed := len(code) > 0
+		c.multicallDeployed = &deployed
+	}
+	return *c.multicallDeployed
+}
+
+func (c *BridgeContract) multicall(calls [][]byte) ([][]byte, error) {
+	multicallCalls := make([]multicallCall, len(calls))
+	for i, call := range calls {
+		multicallCalls[i] = multicallCall{
+			Target:   *c.ContractAddress(),
+			CallData: call,
+		}
+	}
+	input, err := c.multicallABI.Pack("aggregate3", multicallCalls)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := ethereum.CallMsg{From: c.client.From(), To: &MulticallAddress, Data: input}
+	out, err := c.client.CallContract(context.Background(), client.ToCallArg(msg), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.multicallABI.Unpack("aggregate3", out)
+	if err != nil {
+		return nil, err
+	}
+
+	multicallResults := *abi.ConvertType(res[0], new([]multicallResult)).(*[]multicallResult)
+	results := make([][]byte, len(multicallResults))
+	for i, result := range multicallResults {
+		results[i] = result.ReturnData
+	}
+	return results, nil
+}
+
+func (c *BridgeContract) batchCall(batchCaller BatchCaller, calls [][]byte) ([][]byte, error) {
+	outputs := make([]hexutil.Bytes, len(calls))
+	batch := make([]rpc.BatchElem, len(calls))
+	for i, call := range calls {
+		msg := ethereum.CallMsg{From: c.client.From(), To: c.ContractAddress(), Data: call}
+		batch[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args:   []interface{}{client.ToCallArg(msg), "latest"},
+			Result: &outputs[i],
+		}
+	}
+	err := batchCaller.BatchCallContext(context.Background(), batch)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]byte, len(calls))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("batched status read failed: %w", elem.Error)
+		}
+		results[i] = outputs[i]
+	}
+	return results, nil
+}
+
+func (c *BridgeContract) sequentialCall(calls [][]byte) ([][]byte, error) {
+	results := make([][]byte, len(calls))
+	for i, call := range calls {
+		msg := ethereum.CallMsg{From: c.client.From(), To: c.ContractAddress(), Data: call}
+		out, err := c.client.CallContract(context.Background(), client.ToCallArg(msg), nil)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = out
+	}
+	return results, nil
+}
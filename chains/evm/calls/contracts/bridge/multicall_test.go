@@ -0,0 +1,173 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package bridge_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/consts"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/contracts/bridge"
+	mock_bridge "github.com/ChainSafe/sygma-relayer/chains/evm/calls/contracts/bridge/mock"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+var bridgeAddress = common.HexToAddress("0x6CdE2Cd82a4F8B74693Ff5e194c19CA08c2d1c68")
+
+type batchCallingClient struct {
+	*mock_bridge.MockChainClient
+	*mock_bridge.MockBatchCaller
+}
+
+type AreProposalsExecutedTestSuite struct {
+	suite.Suite
+	mockClient      *mock_bridge.MockChainClient
+	mockBatchCaller *mock_bridge.MockBatchCaller
+	bridgeABI       abi.ABI
+	multicallABI    abi.ABI
+}
+
+func TestRunAreProposalsExecutedTestSuite(t *testing.T) {
+	suite.Run(t, new(AreProposalsExecutedTestSuite))
+}
+
+func (s *AreProposalsExecutedTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockClient = mock_bridge.NewMockChainClient(ctrl)
+	s.mockClient.EXPECT().From().Return(common.Address{}).AnyTimes()
+	s.mockBatchCaller = mock_bridge.NewMockBatchCaller(ctrl)
+	s.bridgeABI, _ = abi.JSON(strings.NewReader(consts.BridgeABI))
+	s.multicallABI, _ = abi.JSON(strings.NewReader(consts.MulticallABI))
+}
+
+func (s *AreProposalsExecutedTestSuite) proposals(count int) []*transfer.TransferProposal {
+	proposals := make([]*transfer.TransferProposal, count)
+	for i := range proposals {
+		proposals[i] = &transfer.TransferProposal{
+			Source: 1,
+			Data: transfer.TransferProposalData{
+				DepositNonce: uint64(i),
+			},
+		}
+	}
+	return proposals
+}
+
+// status returns the encoded isProposalExecuted result of the call, treating odd nonces as executed
+func (s *AreProposalsExecutedTestSuite) status(callData []byte) []byte {
+	args, err := s.bridgeABI.Methods["isProposalExecuted"].Inputs.Unpack(callData[4:])
+	s.Nil(err)
+	nonce := args[1].(*big.Int)
+	out, err := s.bridgeABI.Methods["isProposalExecuted"].Outputs.Pack(nonce.Bit(0) == 1)
+	s.Nil(err)
+	return out
+}
+
+func (s *AreProposalsExecutedTestSuite) aggregate3(ctx context.Context, callArgs map[string]interface{}, blockNumber *big.Int) ([]byte, error) {
+	s.Equal(*callArgs["to"].(*common.Address), bridge.MulticallAddress)
+	data := callArgs["data"].(hexutil.Bytes)
+	args, err := s.multicallABI.Methods["aggregate3"].Inputs.Unpack(data[4:])
+	s.Nil(err)
+
+	calls := *abi.ConvertType(args[0], new([]struct {
+		Target       common.Address
+		AllowFailure bool
+		CallData     []byte
+	})).(*[]struct {
+		Target       common.Address
+		AllowFailure bool
+		CallData     []byte
+	})
+	results := make([]struct {
+		Success    bool
+		ReturnData []byte
+	}, len(calls))
+	for i, call := range calls {
+		s.Equal(call.Target, bridgeAddress)
+		results[i].Success = true
+		results[i].ReturnData = s.status(call.CallData)
+	}
+	return s.multicallABI.Methods["aggregate3"].Outputs.Pack(results)
+}
+
+func (s *AreProposalsExecutedTestSuite) Test_Multicall_RoundTrip() {
+	s.mockClient.EXPECT().CodeAt(gomock.Any(), bridge.MulticallAddress, gomock.Any()).Return([]byte{1}, nil)
+	s.mockClient.EXPECT().CallContract(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(s.aggregate3)
+	bridgeContract := bridge.NewBridgeContract(s.mockClient, bridgeAddress, nil)
+
+	executed, err := bridgeContract.AreProposalsExecuted(s.proposals(3))
+
+	s.Nil(err)
+	s.Equal(executed, []bool{false, true, false})
+}
+
+func (s *AreProposalsExecutedTestSuite) Test_Multicall_SplitsIntoStatusBatches() {
+	s.mockClient.EXPECT().CodeAt(gomock.Any(), bridge.MulticallAddress, gomock.Any()).Return([]byte{1}, nil).Times(1)
+	s.mockClient.EXPECT().CallContract(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(s.aggregate3).Times(2)
+	bridgeContract := bridge.NewBridgeContract(s.mockClient, bridgeAddress, nil)
+
+	executed, err := bridgeContract.AreProposalsExecuted(s.proposals(bridge.StatusBatchSize + 1))
+
+	s.Nil(err)
+	s.Equal(len(executed), bridge.StatusBatchSize+1)
+	s.True(executed[bridge.StatusBatchSize-1])
+	s.False(executed[bridge.StatusBatchSize])
+}
+
+func (s *AreProposalsExecutedTestSuite) Test_BatchCall_RoundTrip() {
+	s.mockClient.EXPECT().CodeAt(gomock.Any(), bridge.MulticallAddress, gomock.Any()).Return([]byte{}, nil)
+	s.mockBatchCaller.EXPECT().BatchCallContext(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, b []rpc.BatchElem) error {
+		s.Equal(len(b), 2)
+		for _, elem := range b {
+			s.Equal(elem.Method, "eth_call")
+			callArgs := elem.Args[0].(map[string]interface{})
+			*elem.Result.(*hexutil.Bytes) = s.status(callArgs["data"].(hexutil.Bytes))
+		}
+		return nil
+	})
+	bridgeContract := bridge.NewBridgeContract(&batchCallingClient{s.mockClient, s.mockBatchCaller}, bridgeAddress, nil)
+
+	executed, err := bridgeContract.AreProposalsExecuted(s.proposals(2))
+
+	s.Nil(err)
+	s.Equal(executed, []bool{false, true})
+}
+
+func (s *AreProposalsExecutedTestSuite) Test_BatchCall_ElementError() {
+	s.mockClient.EXPECT().CodeAt(gomock.Any(), bridge.MulticallAddress, gomock.Any()).Return([]byte{}, nil)
+	s.mockBatchCaller.EXPECT().BatchCallContext(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, b []rpc.BatchElem) error {
+		*b[0].Result.(*hexutil.Bytes) = s.status(b[0].Args[0].(map[string]interface{})["data"].(hexutil.Bytes))
+		b[1].Error = errors.New("execution reverted")
+		return nil
+	})
+	bridgeContract := bridge.NewBridgeContract(&batchCallingClient{s.mockClient, s.mockBatchCaller}, bridgeAddress, nil)
+
+	_, err := bridgeContract.AreProposalsExecuted(s.proposals(2))
+
+	s.NotNil(err)
+}
+
+func (s *AreProposalsExecutedTestSuite) Test_CodeAtFailure_FallsBackToSequentialCalls() {
+	s.mockClient.EXPECT().CodeAt(gomock.Any(), bridge.MulticallAddress, gomock.Any()).Return(nil, errors.New("error"))
+	s.mockClient.EXPECT().CallContract(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, callArgs map[string]interface{}, blockNumber *big.Int) ([]byte, error) {
+			s.Equal(*callArgs["to"].(*common.Address), bridgeAddress)
+			return s.status(callArgs["data"].(hexutil.Bytes)), nil
+		}).Times(2)
+	bridgeContract := bridge.NewBridgeContract(s.mockClient, bridgeAddress, nil)
+
+	executed, err := bridgeContract.AreProposalsExecuted(s.proposals(2))
+
+	s.Nil(err)
+	s.Equal(executed, []bool{false, true})
+}
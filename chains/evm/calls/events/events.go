@@ -62,4 +62,6 @@ type Deposit struct {
 	HandlerResponse []byte
 	// Timestamp is the timestamp of the block that the deposit event is in
 	Timestamp time.Time
+	// BlockNumber is the number of the block that the deposit event is in
+	BlockNumber uint64
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -29,27 +30,39 @@ type Listener struct {
 	client   ChainClient
 	abi      abi.ABI
 	retryAbi abi.ABI
+	// sem bounds the number of concurrent requests of the listener
+	sem chan struct{}
+
+	timestampLock   sync.Mutex
+	timestamps      map[uint64]time.Time
+	timestampBlocks []uint64
 }
 
-func NewListener(client ChainClient) *Listener {
+// NewListener creates a listener that sends at most concurrency requests at the same time
+func NewListener(client ChainClient, concurrency int) *Listener {
 	retryAbi, _ := abi.JSON(strings.NewReader(consts.RetryABI))
 	abi, _ := abi.JSON(strings.NewReader(consts.BridgeABI))
+	if concurrency < 1 {
+		concurrency = 1
+	}
 	return &Listener{
-		client:   client,
-		abi:      abi,
-		retryAbi: retryAbi,
+		client:     client,
+		abi:        abi,
+		retryAbi:   retryAbi,
+		sem:        make(chan struct{}, concurrency),
+		timestamps: make(map[uint64]time.Time),
 	}
 }
 
 func (l *Listener) FetchDeposits(ctx context.Context, contractAddress common.Address, startBlock *big.Int, endBlock *big.Int) ([]*Deposit, error) {
-	logs, err := l.client.FetchEventLogs(ctx, contractAddress, string(DepositSig), startBlock, endBlock)
+	logs, err := l.fetchLogs(ctx, contractAddress, string(DepositSig), startBlock, endBlock)
 	if err != nil {
 		return nil, err
 	}
 	deposits := make([]*Deposit, 0)
 
 	for _, dl := range logs {
-		d, err := l.parseDeposit(ctx, dl)
+		d, err := l.parseDeposit(dl)
 		if err != nil {
 			log.Error().Msgf("failed unpacking deposit event log: %v", err)
 			continue
@@ -58,11 +71,15 @@ func (l *Listener) FetchDeposits(ctx context.Context, contractAddress common.Add
 		log.Debug().Msgf("Found deposit log in block: %d, TxHash: %s, contractAddress: %s, sender: %s", dl.BlockNumber, dl.TxHash, dl.Address, d.SenderAddress)
 		deposits = append(deposits, d)
 	}
+	err = l.setTimestamps(ctx, deposits)
+	if err != nil {
+		return nil, err
+	}
 
 	return deposits, nil
 }
 
-func (l *Listener) parseDeposit(ctx context.Context, dl ethTypes.Log) (*Deposit, error) {
+func (l *Listener) parseDeposit(dl ethTypes.Log) (*Deposit, error) {
 	var d Deposit
 	err := l.abi.UnpackIntoInterface(&d, "Deposit", dl.Data)
 	if err != nil {
@@ -70,15 +87,27 @@ func (l *Listener) parseDeposit(ctx context.Context, dl ethTypes.Log) (*Deposit,
 	}
 
 	d.SenderAddress = common.BytesToAddress(dl.Topics[1].Bytes())
-	block, err := l.client.BlockByNumber(ctx, new(big.Int).SetUint64(dl.BlockNumber))
-	if err == nil {
-		log.Warn().Msgf("Failed fetching block with number %d because of: %+v", dl.BlockNumber, err)
-		d.Timestamp = time.Unix(int64(block.Time()), 0)
-	} else {
-		d.Timestamp = time.Now()
+	d.BlockNumber = dl.BlockNumber
+	return &d, nil
+}
+
+// setTimestamps sets timestamps of deposit blocks to deposits.
+// Timestamps have to be the same on all relayers, so deposits are not
+// returned without them and the block range is retried instead.
+func (l *Listener) setTimestamps(ctx context.Context, deposits []*Deposit) error {
+	blocks := make([]uint64, len(deposits))
+	for i, d := range deposits {
+		blocks[i] = d.BlockNumber
 	}
 
-	return &d, nil
+	timestamps, err := l.blockTimestamps(ctx, blocks)
+	if err != nil {
+		return err
+	}
+	for _, d := range deposits {
+		d.Timestamp = timestamps[d.BlockNumber]
+	}
+	return nil
 }
 
 func (l *Listener) FetchRetryDepositEvents(event RetryV1Event, bridgeAddress common.Address, blockConfirmations *big.Int) ([]Deposit, error) {
@@ -94,32 +123,41 @@ func (l *Listener) FetchRetryDepositEvents(event RetryV1Event, bridgeAddress com
 	if err != nil {
 		return depositEvents, err
 	}
-	if latestBlock.Cmp(receipt.BlockNumber.Add(receipt.BlockNumber, blockConfirmations)) != 1 {
+	confirmedBlock := new(big.Int).Add(receipt.BlockNumber, blockConfirmations)
+	if latestBlock.Cmp(confirmedBlock) != 1 {
 		return depositEvents, fmt.Errorf(
 			"latest block %s higher than receipt block number + block confirmations %s",
 			latestBlock,
-			receipt.BlockNumber.Add(receipt.BlockNumber, blockConfirmations),
+			confirmedBlock,
 		)
 	}
 
+	deposits := make([]*Deposit, 0)
 	for _, lg := range receipt.Logs {
 		if lg.Address != bridgeAddress {
 			continue
 		}
 
-		d, err := l.parseDeposit(context.Background(), *lg)
+		d, err := l.parseDeposit(*lg)
 		if err != nil {
 			log.Error().Msgf("failed unpacking deposit event log: %v", err)
 			continue
 		}
-		depositEvents = append(depositEvents, *d)
+		deposits = append(deposits, d)
+	}
+	err = l.setTimestamps(context.Background(), deposits)
+	if err != nil {
+		return depositEvents, err
 	}
 
+	for _, d := range deposits {
+		depositEvents = append(depositEvents, *d)
+	}
 	return depositEvents, nil
 }
 
 func (l *Listener) FetchRetryV1Events(ctx context.Context, contractAddress common.Address, startBlock *big.Int, endBlock *big.Int) ([]RetryV1Event, error) {
-	logs, err := l.client.FetchEventLogs(ctx, contractAddress, string(RetrySig), startBlock, endBlock)
+	logs, err := l.fetchLogs(ctx, contractAddress, string(RetrySig), startBlock, endBlock)
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +179,7 @@ func (l *Listener) FetchRetryV1Events(ctx context.Context, contractAddress commo
 }
 
 func (l *Listener) FetchRetryV2Events(ctx context.Context, contractAddress common.Address, startBlock *big.Int, endBlock *big.Int) ([]RetryV2Event, error) {
-	logs, err := l.client.FetchEventLogs(ctx, contractAddress, string(RetrySig), startBlock, endBlock)
+	logs, err := l.fetchLogs(ctx, contractAddress, string(RetrySig), startBlock, endBlock)
 	if err != nil {
 		return nil, err
 	}
@@ -163,7 +201,7 @@ func (l *Listener) FetchRetryV2Events(ctx context.Context, contractAddress commo
 }
 
 func (l *Listener) FetchKeygenEvents(ctx context.Context, contractAddress common.Address, startBlock *big.Int, endBlock *big.Int) ([]ethTypes.Log, error) {
-	logs, err := l.client.FetchEventLogs(ctx, contractAddress, string(StartKeygenSig), startBlock, endBlock)
+	logs, err := l.fetchLogs(ctx, contractAddress, string(StartKeygenSig), startBlock, endBlock)
 	if err != nil {
 		return nil, err
 	}
@@ -172,7 +210,7 @@ func (l *Listener) FetchKeygenEvents(ctx context.Context, contractAddress common
 }
 
 func (l *Listener) FetchFrostKeygenEvents(ctx context.Context, contractAddress common.Address, startBlock *big.Int, endBlock *big.Int) ([]ethTypes.Log, error) {
-	logs, err := l.client.FetchEventLogs(ctx, contractAddress, string(StartFrostKeygenSig), startBlock, endBlock)
+	logs, err := l.fetchLogs(ctx, contractAddress, string(StartFrostKeygenSig), startBlock, endBlock)
 	if err != nil {
 		return nil, err
 	}
@@ -181,7 +219,7 @@ func (l *Listener) FetchFrostKeygenEvents(ctx context.Context, contractAddress c
 }
 
 func (l *Listener) FetchRefreshEvents(ctx context.Context, contractAddress common.Address, startBlock *big.Int, endBlock *big.Int) ([]*Refresh, error) {
-	logs, err := l.client.FetchEventLogs(ctx, contractAddress, string(KeyRefreshSig), startBlock, endBlock)
+	logs, err := l.fetchLogs(ctx, contractAddress, string(KeyRefreshSig), startBlock, endBlock)
 	if err != nil {
 		return nil, err
 	}
@@ -4,12 +4,15 @@
 package events_test
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
 
@@ -17,6 +20,14 @@ import (
 	mock_listener "github.com/ChainSafe/sygma-relayer/chains/evm/calls/events/mock"
 )
 
+type rpcError struct {
+	code    int
+	message string
+}
+
+func (e *rpcError) Error() string  { return e.message }
+func (e *rpcError) ErrorCode() int { return e.code }
+
 type ListenerTestSuite struct {
 	suite.Suite
 	mockClient *mock_listener.MockChainClient
@@ -30,7 +41,7 @@ func TestRunListenerTestSuite(t *testing.T) {
 func (s *ListenerTestSuite) SetupTest() {
 	ctrl := gomock.NewController(s.T())
 	s.mockClient = mock_listener.NewMockChainClient(ctrl)
-	s.listener = events.NewListener(s.mockClient)
+	s.listener = events.NewListener(s.mockClient, 1)
 }
 
 func (s *ListenerTestSuite) Test_FetchRetryDepositEvents_FetchingTxFails() {
@@ -124,7 +135,7 @@ func (s *ListenerTestSuite) Test_FetchRetryDepositEvents_ValidEvent() {
 		},
 	}, nil)
 	s.mockClient.EXPECT().LatestBlock().Return(big.NewInt(20), nil)
-	s.mockClient.EXPECT().BlockByNumber(gomock.Any(), big.NewInt(14)).Return(nil, fmt.Errorf("error"))
+	s.mockClient.EXPECT().BlockByNumber(gomock.Any(), big.NewInt(14)).Return(types.NewBlockWithHeader(&types.Header{Time: 1000}), nil)
 
 	deposits, err := s.listener.FetchRetryDepositEvents(
 		events.RetryV1Event{TxHash: "0xf25ed4a14bf7ad20354b46fe38d7d4525f2ea3042db9a9954ef8d73c558b500c"},
@@ -134,4 +145,99 @@ func (s *ListenerTestSuite) Test_FetchRetryDepositEvents_ValidEvent() {
 
 	s.Nil(err)
 	s.Equal(deposits[0].DestinationDomainID, uint8(2))
+	s.Equal(deposits[0].Timestamp, time.Unix(1000, 0))
+}
+
+func (s *ListenerTestSuite) Test_FetchKeygenEvents_SplitsRangeRejectedByProvider() {
+	s.mockClient.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), big.NewInt(1), big.NewInt(10)).Return(nil, fmt.Errorf("query returned more than 10000 results"))
+	s.mockClient.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), big.NewInt(1), big.NewInt(5)).Return([]types.Log{{BlockNumber: 2}}, nil)
+	s.mockClient.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), big.NewInt(6), big.NewInt(10)).Return([]types.Log{{BlockNumber: 8}}, nil)
+
+	logs, err := s.listener.FetchKeygenEvents(context.Background(), common.Address{}, big.NewInt(1), big.NewInt(10))
+
+	s.Nil(err)
+	s.Equal(logs, []types.Log{{BlockNumber: 2}, {BlockNumber: 8}})
+}
+
+func (s *ListenerTestSuite) Test_FetchKeygenEvents_SingleBlockRejectedByProvider() {
+	s.mockClient.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), big.NewInt(1), big.NewInt(2)).Return(nil, fmt.Errorf("Log response size exceeded"))
+	s.mockClient.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), big.NewInt(1), big.NewInt(1)).Return(nil, fmt.Errorf("Log response size exceeded"))
+	s.mockClient.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), big.NewInt(2), big.NewInt(2)).Return([]types.Log{}, nil).MaxTimes(1)
+
+	_, err := s.listener.FetchKeygenEvents(context.Background(), common.Address{}, big.NewInt(1), big.NewInt(2))
+
+	s.NotNil(err)
+}
+
+func (s *ListenerTestSuite) Test_FetchKeygenEvents_OtherErrorNotSplit() {
+	s.mockClient.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), big.NewInt(1), big.NewInt(10)).Return(nil, fmt.Errorf("connection refused"))
+
+	_, err := s.listener.FetchKeygenEvents(context.Background(), common.Address{}, big.NewInt(1), big.NewInt(10))
+
+	s.NotNil(err)
+}
+
+func (s *ListenerTestSuite) Test_FetchKeygenEvents_SplitsRangeRejectedWithErrorCode() {
+	s.mockClient.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), big.NewInt(1), big.NewInt(2)).Return(nil, &rpcError{code: -32614, message: "eth_getLogs is limited to a 10,000 range"})
+	s.mockClient.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), big.NewInt(1), big.NewInt(1)).Return([]types.Log{{BlockNumber: 1}}, nil)
+	s.mockClient.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), big.NewInt(2), big.NewInt(2)).Return([]types.Log{{BlockNumber: 2}}, nil)
+
+	logs, err := s.listener.FetchKeygenEvents(context.Background(), common.Address{}, big.NewInt(1), big.NewInt(2))
+
+	s.Nil(err)
+	s.Equal(logs, []types.Log{{BlockNumber: 1}, {BlockNumber: 2}})
+}
+
+func (s *ListenerTestSuite) Test_FetchKeygenEvents_RateLimitNotSplit() {
+	rateLimitErrors := []error{
+		&rpcError{code: -32005, message: "daily request count exceeded, request rate limited"},
+		&rpcError{code: 429, message: "Your app has exceeded its compute units per second capacity"},
+		&rpcError{code: -32005, message: "project ID request rate exceeded"},
+		&rpcError{code: -32000, message: "requests limited to 25/sec, more than allowed"},
+		rpc.HTTPError{StatusCode: 429, Status: "429 Too Many Requests"},
+		fmt.Errorf("query timeout exceeded"),
+	}
+
+	for _, rateLimitErr := range rateLimitErrors {
+		s.mockClient.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), big.NewInt(1), big.NewInt(10)).Return(nil, rateLimitErr)
+
+		_, err := s.listener.FetchKeygenEvents(context.Background(), common.Address{}, big.NewInt(1), big.NewInt(10))
+
+		s.Equal(err, rateLimitErr)
+	}
+}
+
+func (s *ListenerTestSuite) Test_FetchDeposits_CachesBlockTimestamps() {
+	depositEvent := common.Hex2Bytes("00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000000000000000000000000000000000000001d00000000000000000000000000000000000000000000000000000000000000a00000000000000000000000000000000000000000000000000000000000000120000000000000000000000000000000000000000000000000000000000000005600000000000000000000000000000000000000000000000000000000000f424000000000000000000000000000000000000000000000000000000000000000148e0a907331554af72563bd8d43051c2e64be5d350102000000000000000000000000000000000000000000000000000000000000000000000000000000000000")
+	depositLogs := []types.Log{
+		{Data: depositEvent, Topics: []common.Hash{{}, {}}, BlockNumber: 14},
+		{Data: depositEvent, Topics: []common.Hash{{}, {}}, BlockNumber: 14},
+	}
+	s.mockClient.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(depositLogs, nil).Times(2)
+	s.mockClient.EXPECT().BlockByNumber(gomock.Any(), big.NewInt(14)).Return(types.NewBlockWithHeader(&types.Header{Time: 1000}), nil).Times(1)
+
+	deposits, err := s.listener.FetchDeposits(context.Background(), common.Address{}, big.NewInt(10), big.NewInt(15))
+	s.Nil(err)
+	s.Equal(len(deposits), 2)
+	s.Equal(deposits[0].Timestamp, time.Unix(1000, 0))
+
+	deposits, err = s.listener.FetchDeposits(context.Background(), common.Address{}, big.NewInt(10), big.NewInt(15))
+	s.Nil(err)
+	s.Equal(deposits[1].Timestamp, time.Unix(1000, 0))
+}
+
+func (s *ListenerTestSuite) Test_FetchDeposits_TimestampFetchFails() {
+	depositEvent := common.Hex2Bytes("00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000000000000000000000000000000000000001d00000000000000000000000000000000000000000000000000000000000000a00000000000000000000000000000000000000000000000000000000000000120000000000000000000000000000000000000000000000000000000000000005600000000000000000000000000000000000000000000000000000000000f424000000000000000000000000000000000000000000000000000000000000000148e0a907331554af72563bd8d43051c2e64be5d350102000000000000000000000000000000000000000000000000000000000000000000000000000000000000")
+	depositLogs := []types.Log{
+		{Data: depositEvent, Topics: []common.Hash{{}, {}}, BlockNumber: 14},
+		{Data: depositEvent, Topics: []common.Hash{{}, {}}, BlockNumber: 15},
+	}
+	s.mockClient.EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(depositLogs, nil)
+	s.mockClient.EXPECT().BlockByNumber(gomock.Any(), big.NewInt(14)).Return(types.NewBlockWithHeader(&types.Header{Time: 1000}), nil)
+	s.mockClient.EXPECT().BlockByNumber(gomock.Any(), big.NewInt(15)).Return(nil, fmt.Errorf("error"))
+
+	deposits, err := s.listener.FetchDeposits(context.Background(), common.Address{}, big.NewInt(10), big.NewInt(15))
+
+	s.NotNil(err)
+	s.Nil(deposits)
 }
@@ -0,0 +1,169 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rs/zerolog/log"
+	"github.com/sourcegraph/conc/pool"
+)
+
+// TimestampCacheSize is the number of block timestamps kept by the listener
+var TimestampCacheSize = 1000
+
+// rangeErrorCodes are JSON-RPC error codes providers use only for
+// log queries over a too large block range
+var rangeErrorCodes = map[int]bool{
+	-32614: true,
+}
+
+// rangeErrorMessages are parts of error messages providers return when
+// the log query range is too large or matches too many logs
+var rangeErrorMessages = []string{
+	"query returned more than",
+	"response size exceeded",
+	"block range",
+	"range limit",
+	"range is too",
+	"exceeds max results",
+}
+
+// rateLimitMessages are parts of error messages of rate limited requests that
+// share error codes or wording with range errors
+var rateLimitMessages = []string{
+	"rate limit",
+	"rate exceeded",
+	"too many requests",
+	"request count",
+	"capacity",
+}
+
+// isRangeError returns true if the provider rejected the log query because of its size.
+// Rate limited queries are not range errors as splitting them only adds requests.
+func isRangeError(err error) bool {
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	if containsAny(msg, rateLimitMessages) {
+		return false
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rangeErrorCodes[rpcErr.ErrorCode()] {
+		return true
+	}
+	return containsAny(msg, rangeErrorMessages)
+}
+
+func containsAny(msg string, parts []string) bool {
+	for _, part := range parts {
+		if strings.Contains(msg, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchLogs fetches event logs in the block range and recursively splits the range
+// in halves while the provider rejects it as too large
+func (l *Listener) fetchLogs(ctx context.Context, contractAddress common.Address, event string, startBlock *big.Int, endBlock *big.Int) ([]ethTypes.Log, error) {
+	l.sem <- struct{}{}
+	logs, err := l.client.FetchEventLogs(ctx, contractAddress, event, startBlock, endBlock)
+	<-l.sem
+	if err == nil || !isRangeError(err) || startBlock.Cmp(endBlock) >= 0 {
+		return logs, err
+	}
+
+	middle := new(big.Int).Add(startBlock, endBlock)
+	middle.Div(middle, big.NewInt(2))
+	log.Debug().Err(err).Msgf("Splitting log range %s-%s at block %s", startBlock, endBlock, middle)
+
+	var lowerLogs, upperLogs []ethTypes.Log
+	p := pool.New().WithErrors()
+	p.Go(func() error {
+		var err error
+		lowerLogs, err = l.fetchLogs(ctx, contractAddress, event, startBlock, middle)
+		return err
+	})
+	p.Go(func() error {
+		var err error
+		upperLogs, err = l.fetchLogs(ctx, contractAddress, event, new(big.Int).Add(middle, big.NewInt(1)), endBlock)
+		return err
+	})
+	err = p.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return append(lowerLogs, upperLogs...), nil
+}
+
+// blockTimestamps returns timestamps of the blocks. Timestamps missing from the cache
+// are fetched concurrently and an error is returned if any of them could not be fetched.
+func (l *Listener) blockTimestamps(ctx context.Context, blocks []uint64) (map[uint64]time.Time, error) {
+	timestamps := make(map[uint64]time.Time)
+	missing := make(map[uint64]bool)
+	l.timestampLock.Lock()
+	for _, block := range blocks {
+		if timestamp, ok := l.timestamps[block]; ok {
+			timestamps[block] = timestamp
+		} else {
+			missing[block] = true
+		}
+	}
+	l.timestampLock.Unlock()
+
+	lock := sync.Mutex{}
+	p := pool.New().WithErrors()
+	for block := range missing {
+		block := block
+		p.Go(func() error {
+			l.sem <- struct{}{}
+			b, err := l.client.BlockByNumber(ctx, new(big.Int).SetUint64(block))
+			<-l.sem
+			if err != nil {
+				return fmt.Errorf("failed fetching timestamp of block %d: %w", block, err)
+			}
+
+			timestamp := time.Unix(int64(b.Time()), 0)
+			lock.Lock()
+			timestamps[block] = timestamp
+			lock.Unlock()
+			l.cacheTimestamp(block, timestamp)
+			return nil
+		})
+	}
+	err := p.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return timestamps, nil
+}
+
+func (l *Listener) cacheTimestamp(block uint64, timestamp time.Time) {
+	l.timestampLock.Lock()
+	defer l.timestampLock.Unlock()
+
+	if _, ok := l.timestamps[block]; ok {
+		return
+	}
+	if len(l.timestampBlocks) >= TimestampCacheSize {
+		delete(l.timestamps, l.timestampBlocks[0])
+		l.timestampBlocks = l.timestampBlocks[1:]
+	}
+	l.timestamps[block] = timestamp
+	l.timestampBlocks = append(l.timestampBlocks, block)
+}
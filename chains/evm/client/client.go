@@ -0,0 +1,479 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/client"
+)
+
+// UnhealthyPeriod is the period an endpoint is tried only after healthy endpoints
+// once a request to it failed
+var UnhealthyPeriod = time.Second * 30
+
+type RPCClient interface {
+	LatestBlock() (*big.Int, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BaseFee() (*big.Int, error)
+	FetchEventLogs(ctx context.Context, contractAddress common.Address, event string, startBlock *big.Int, endBlock *big.Int) ([]types.Log, error)
+	CallContract(ctx context.Context, callArgs map[string]interface{}, blockNumber *big.Int) ([]byte, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	GetTransactionByHash(h common.Hash) (tx *types.Transaction, isPending bool, err error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	SendRawTransaction(ctx context.Context, tx []byte) error
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+type EndpointMetrics interface {
+	TrackEndpointRequest(domainID uint8, endpoint string, success bool)
+	TrackEndpointHealth(domainID uint8, endpoint string, healthy bool)
+}
+
+// Endpoint is an RPC client of the domain with its name used in logs and metrics
+type Endpoint struct {
+	Name   string
+	Client RPCClient
+
+	healthLock     sync.Mutex
+	unhealthyUntil time.Time
+}
+
+// DialEndpoints connects to the configured endpoints of the domain
+func DialEndpoints(configs []evm.EndpointConfig, signer client.Signer) ([]*Endpoint, error) {
+	endpoints := make([]*Endpoint, len(configs))
+	for i, config := range configs {
+		c, err := client.NewEVMClient(config.Url, signer)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect to endpoint %s: %w", config.EndpointName(), err)
+		}
+
+		endpoints[i] = &Endpoint{
+			Name:   config.EndpointName(),
+			Client: &evmClient{EVMClient: c},
+		}
+	}
+	return endpoints, nil
+}
+
+// evmClient extends the EVM client with JSON-RPC batch requests
+type evmClient struct {
+	*client.EVMClient
+}
+
+func (c *evmClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return c.EVMClient.Client.Client().BatchCallContext(ctx, b)
+}
+
+// MultiClient is an EVM client that sends requests to several RPC endpoints of the same domain.
+// Reads fail over to the next endpoint ordered by priority if the endpoint is unreachable,
+// while transactions are broadcasted to every endpoint.
+type MultiClient struct {
+	domainID  uint8
+	endpoints []*Endpoint
+	signer    client.Signer
+	metrics   EndpointMetrics
+	logger    zerolog.Logger
+
+	nonce     *big.Int
+	nonceLock sync.Mutex
+}
+
+// NewMultiClient creates a client from endpoints ordered by priority
+func NewMultiClient(domainID uint8, endpoints []*Endpoint, signer client.Signer, metrics EndpointMetrics) (*MultiClient, error) {
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no endpoints defined for domain %d", domainID)
+	}
+
+	for _, endpoint := range endpoints {
+		metrics.TrackEndpointHealth(domainID, endpoint.Name, true)
+	}
+	return &MultiClient{
+		domainID:  domainID,
+		endpoints: endpoints,
+		signer:    signer,
+		metrics:   metrics,
+		logger:    log.With().Uint8("domainID", domainID).Logger(),
+	}, nil
+}
+
+// WithSigner returns a client that shares endpoints with the client and signs transactions
+// with the signer. Nonces of the signer are tracked separately by the returned client.
+func (c *MultiClient) WithSigner(signer client.Signer) *MultiClient {
+	return &MultiClient{
+		domainID:  c.domainID,
+		endpoints: c.endpoints,
+		signer:    signer,
+		metrics:   c.metrics,
+		logger:    c.logger,
+	}
+}
+
+// ordered returns healthy endpoints ordered by priority followed by unhealthy endpoints
+// that are tried as a last resort
+func (c *MultiClient) ordered() []*Endpoint {
+	now := time.Now()
+	healthy := make([]*Endpoint, 0, len(c.endpoints))
+	unhealthy := make([]*Endpoint, 0)
+	for _, endpoint := range c.endpoints {
+		endpoint.healthLock.Lock()
+		isUnhealthy := now.Before(endpoint.unhealthyUntil)
+		endpoint.healthLock.Unlock()
+		if isUnhealthy {
+			unhealthy = append(unhealthy, endpoint)
+			continue
+		}
+		healthy = append(healthy, endpoint)
+	}
+	return append(healthy, unhealthy...)
+}
+
+// track records the result of a request to the endpoint.
+// Errors returned by the node itself, like reverts, do not make the endpoint unhealthy.
+func (c *MultiClient) track(endpoint *Endpoint, err error) bool {
+	failed := err != nil && !isNodeResponse(err)
+	c.metrics.TrackEndpointRequest(c.domainID, endpoint.Name, !failed)
+
+	endpoint.healthLock.Lock()
+	defer endpoint.healthLock.Unlock()
+	if !failed {
+		if !endpoint.unhealthyUntil.IsZero() {
+			endpoint.unhealthyUntil = time.Time{}
+			c.metrics.TrackEndpointHealth(c.domainID, endpoint.Name, true)
+			c.logger.Info().Msgf("Endpoint %s recovered", endpoint.Name)
+		}
+		return false
+	}
+
+	if endpoint.unhealthyUntil.IsZero() {
+		c.metrics.TrackEndpointHealth(c.domainID, endpoint.Name, false)
+		c.logger.Warn().Err(err).Msgf("Endpoint %s failed", endpoint.Name)
+	}
+	endpoint.unhealthyUntil = time.Now().Add(UnhealthyPeriod)
+	return true
+}
+
+func isNodeResponse(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) || errors.Is(err, ethereum.NotFound)
+}
+
+// failover calls the function with endpoints in order until an endpoint responds
+func (c *MultiClient) failover(fn func(endpoint *Endpoint) error) error {
+	var err error
+	for _, endpoint := range c.ordered() {
+		err = fn(endpoint)
+		if !c.track(endpoint, err) {
+			return err
+		}
+	}
+	return err
+}
+
+// LatestBlock returns the latest block from the current chain
+func (c *MultiClient) LatestBlock() (*big.Int, error) {
+	var block *big.Int
+	err := c.failover(func(endpoint *Endpoint) error {
+		var err error
+		block, err = endpoint.Client.LatestBlock()
+		return err
+	})
+	return block, err
+}
+
+func (c *MultiClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	var block *types.Block
+	err := c.failover(func(endpoint *Endpoint) error {
+		var err error
+		block, err = endpoint.Client.BlockByNumber(ctx, number)
+		return err
+	})
+	return block, err
+}
+
+func (c *MultiClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var header *types.Header
+	err := c.failover(func(endpoint *Endpoint) error {
+		var err error
+		header, err = endpoint.Client.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+// BlockHash returns the hash of the block
+func (c *MultiClient) BlockHash(block *big.Int) (string, error) {
+	header, err := c.HeaderByNumber(context.Background(), block)
+	if err != nil {
+		return "", err
+	}
+	return header.Hash().Hex(), nil
+}
+
+// ParentHash returns the parent hash of the block
+func (c *MultiClient) ParentHash(block *big.Int) (string, error) {
+	header, err := c.HeaderByNumber(context.Background(), block)
+	if err != nil {
+		return "", err
+	}
+	return header.ParentHash.Hex(), nil
+}
+
+func (c *MultiClient) BaseFee() (*big.Int, error) {
+	var baseFee *big.Int
+	err := c.failover(func(endpoint *Endpoint) error {
+		var err error
+		baseFee, err = endpoint.Client.BaseFee()
+		return err
+	})
+	return baseFee, err
+}
+
+func (c *MultiClient) FetchEventLogs(ctx context.Context, contractAddress common.Address, event string, startBlock *big.Int, endBlock *big.Int) ([]types.Log, error) {
+	var logs []types.Log
+	err := c.failover(func(endpoint *Endpoint) error {
+		var err error
+		logs, err = endpoint.Client.FetchEventLogs(ctx, contractAddress, event, startBlock, endBlock)
+		return err
+	})
+	return logs, err
+}
+
+func (c *MultiClient) CallContract(ctx context.Context, callArgs map[string]interface{}, blockNumber *big.Int) ([]byte, error) {
+	var result []byte
+	err := c.failover(func(endpoint *Endpoint) error {
+		var err error
+		result, err = endpoint.Client.CallContract(ctx, callArgs, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+func (c *MultiClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	var gas uint64
+	err := c.failover(func(endpoint *Endpoint) error {
+		var err error
+		gas, err = endpoint.Client.EstimateGas(ctx, msg)
+		return err
+	})
+	return gas, err
+}
+
+// BatchCallContext sends the batch of requests to a single endpoint
+func (c *MultiClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return c.failover(func(endpoint *Endpoint) error {
+		return endpoint.Client.BatchCallContext(ctx, b)
+	})
+}
+
+// SubscribeNewHead subscribes to new heads on the first endpoint that supports subscriptions
+func (c *MultiClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	var err error
+	for _, endpoint := range c.ordered() {
+		var sub ethereum.Subscription
+		sub, err = endpoint.Client.SubscribeNewHead(ctx, ch)
+		if err == nil {
+			return sub, nil
+		}
+	}
+	return nil, err
+}
+
+func (c *MultiClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	var nonce uint64
+	err := c.failover(func(endpoint *Endpoint) error {
+		var err error
+		nonce, err = endpoint.Client.NonceAt(ctx, account, blockNumber)
+		return err
+	})
+	return nonce, err
+}
+
+func (c *MultiClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var balance *big.Int
+	err := c.failover(func(endpoint *Endpoint) error {
+		var err error
+		balance, err = endpoint.Client.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return balance, err
+}
+
+func (c *MultiClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	var code []byte
+	err := c.failover(func(endpoint *Endpoint) error {
+		var err error
+		code, err = endpoint.Client.CodeAt(ctx, contract, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+func (c *MultiClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	var receipt *types.Receipt
+	err := c.failover(func(endpoint *Endpoint) error {
+		var err error
+		receipt, err = endpoint.Client.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
+
+func (c *MultiClient) GetTransactionByHash(h common.Hash) (*types.Transaction, bool, error) {
+	var tx *types.Transaction
+	var isPending bool
+	err := c.failover(func(endpoint *Endpoint) error {
+		var err error
+		tx, isPending, err = endpoint.Client.GetTransactionByHash(h)
+		return err
+	})
+	return tx, isPending, err
+}
+
+func (c *MultiClient) ChainID(ctx context.Context) (*big.Int, error) {
+	var chainID *big.Int
+	err := c.failover(func(endpoint *Endpoint) error {
+		var err error
+		chainID, err = endpoint.Client.ChainID(ctx)
+		return err
+	})
+	return chainID, err
+}
+
+func (c *MultiClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var gasPrice *big.Int
+	err := c.failover(func(endpoint *Endpoint) error {
+		var err error
+		gasPrice, err = endpoint.Client.SuggestGasPrice(ctx)
+		return err
+	})
+	return gasPrice, err
+}
+
+func (c *MultiClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var gasTipCap *big.Int
+	err := c.failover(func(endpoint *Endpoint) error {
+		var err error
+		gasTipCap, err = endpoint.Client.SuggestGasTipCap(ctx)
+		return err
+	})
+	return gasTipCap, err
+}
+
+// SignAndSendTransaction signs the transaction and broadcasts it to every endpoint.
+// Sending succeeds if at least one endpoint accepted the transaction.
+func (c *MultiClient) SignAndSendTransaction(ctx context.Context, tx client.CommonTransaction) (common.Hash, error) {
+	id, err := c.ChainID(ctx)
+	if err != nil {
+		// chain probably does not support chainID eg. CELO
+		id = nil
+	}
+	rawTx, err := tx.RawWithSignature(c.signer, id)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	endpoints := c.ordered()
+	errs := make([]error, len(endpoints))
+	wg := sync.WaitGroup{}
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint *Endpoint) {
+			defer wg.Done()
+			errs[i] = endpoint.Client.SendRawTransaction(ctx, rawTx)
+			c.track(endpoint, errs[i])
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil {
+			return tx.Hash(), nil
+		}
+		c.logger.Debug().Err(err).Msgf("Endpoint %s refused transaction %s", endpoints[i].Name, tx.Hash())
+	}
+	return common.Hash{}, errs[0]
+}
+
+func (c *MultiClient) WaitAndReturnTxReceipt(h common.Hash) (*types.Receipt, error) {
+	retry := 50
+	for retry > 0 {
+		receipt, err := c.TransactionReceipt(context.Background(), h)
+		if err != nil {
+			retry--
+			time.Sleep(5 * time.Second)
+			continue
+		}
+		if receipt.Status != 1 {
+			return receipt, fmt.Errorf("transaction failed on chain. Receipt status %v", receipt.Status)
+		}
+		return receipt, nil
+	}
+	return nil, errors.New("tx did not appear")
+}
+
+func (c *MultiClient) From() common.Address {
+	return c.signer.CommonAddress()
+}
+
+func (c *MultiClient) LockNonce() {
+	c.nonceLock.Lock()
+}
+
+func (c *MultiClient) UnlockNonce() {
+	c.nonceLock.Unlock()
+}
+
+func (c *MultiClient) UnsafeNonce() (*big.Int, error) {
+	var err error
+	for i := 0; i <= 10; i++ {
+		if c.nonce != nil {
+			return c.nonce, nil
+		}
+
+		var nonce uint64
+		err = c.failover(func(endpoint *Endpoint) error {
+			var err error
+			nonce, err = endpoint.Client.PendingNonceAt(context.Background(), c.signer.CommonAddress())
+			return err
+		})
+		if err != nil {
+			time.Sleep(1 * time.Second)
+			continue
+		}
+		c.nonce = big.NewInt(0).SetUint64(nonce)
+		return c.nonce, nil
+	}
+	return nil, err
+}
+
+func (c *MultiClient) UnsafeIncreaseNonce() error {
+	nonce, err := c.UnsafeNonce()
+	if err != nil {
+		return err
+	}
+	c.nonce = nonce.Add(nonce, big.NewInt(1))
+	return nil
+}
@@ -0,0 +1,197 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm/client"
+	mock_client "github.com/ChainSafe/sygma-relayer/chains/evm/client/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	coreClient "github.com/sygmaprotocol/sygma-core/chains/evm/client"
+)
+
+type revertError struct{}
+
+func (e revertError) Error() string  { return "execution reverted" }
+func (e revertError) ErrorCode() int { return 3 }
+
+type testSigner struct{}
+
+func (s testSigner) CommonAddress() common.Address          { return common.Address{1} }
+func (s testSigner) Sign(digestHash []byte) ([]byte, error) { return []byte{}, nil }
+
+type testTransaction struct{}
+
+func (t testTransaction) Hash() common.Hash { return common.Hash{2} }
+func (t testTransaction) RawWithSignature(signer coreClient.Signer, domainID *big.Int) ([]byte, error) {
+	return []byte{3}, nil
+}
+
+type MultiClientTestSuite struct {
+	suite.Suite
+	mockClients []*mock_client.MockRPCClient
+	mockMetrics *mock_client.MockEndpointMetrics
+	client      *client.MultiClient
+}
+
+func TestRunMultiClientTestSuite(t *testing.T) {
+	suite.Run(t, new(MultiClientTestSuite))
+}
+
+func (s *MultiClientTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockMetrics = mock_client.NewMockEndpointMetrics(ctrl)
+	s.mockMetrics.EXPECT().TrackEndpointRequest(uint8(1), gomock.Any(), gomock.Any()).AnyTimes()
+	s.mockMetrics.EXPECT().TrackEndpointHealth(uint8(1), gomock.Any(), true).Times(3)
+
+	s.mockClients = []*mock_client.MockRPCClient{}
+	endpoints := []*client.Endpoint{}
+	for _, name := range []string{"primary", "secondary", "tertiary"} {
+		mockClient := mock_client.NewMockRPCClient(ctrl)
+		s.mockClients = append(s.mockClients, mockClient)
+		endpoints = append(endpoints, &client.Endpoint{Name: name, Client: mockClient})
+	}
+
+	var err error
+	s.client, err = client.NewMultiClient(1, endpoints, testSigner{}, s.mockMetrics)
+	s.Nil(err)
+}
+
+func (s *MultiClientTestSuite) Test_NoEndpoints() {
+	_, err := client.NewMultiClient(1, []*client.Endpoint{}, testSigner{}, s.mockMetrics)
+
+	s.NotNil(err)
+}
+
+func (s *MultiClientTestSuite) Test_LatestBlock_FailsOver() {
+	s.mockMetrics.EXPECT().TrackEndpointHealth(uint8(1), "primary", false)
+	s.mockClients[0].EXPECT().LatestBlock().Return(nil, errors.New("connection refused"))
+	s.mockClients[1].EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+
+	block, err := s.client.LatestBlock()
+
+	s.Nil(err)
+	s.Equal(block, big.NewInt(100))
+
+	// unhealthy endpoint is tried last
+	s.mockClients[1].EXPECT().LatestBlock().Return(big.NewInt(101), nil)
+
+	block, err = s.client.LatestBlock()
+
+	s.Nil(err)
+	s.Equal(block, big.NewInt(101))
+}
+
+func (s *MultiClientTestSuite) Test_LatestBlock_AllEndpointsFail() {
+	s.mockMetrics.EXPECT().TrackEndpointHealth(uint8(1), gomock.Any(), false).Times(3)
+	for _, mockClient := range s.mockClients {
+		mockClient.EXPECT().LatestBlock().Return(nil, errors.New("connection refused"))
+	}
+
+	_, err := s.client.LatestBlock()
+
+	s.NotNil(err)
+}
+
+func (s *MultiClientTestSuite) Test_CallContract_NodeErrorNotFailedOver() {
+	s.mockClients[0].EXPECT().CallContract(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, revertError{})
+
+	_, err := s.client.CallContract(context.Background(), map[string]interface{}{}, nil)
+
+	s.Equal(err, revertError{})
+}
+
+func (s *MultiClientTestSuite) Test_SignAndSendTransaction_Broadcasted() {
+	s.mockMetrics.EXPECT().TrackEndpointHealth(uint8(1), "primary", false)
+	s.mockClients[0].EXPECT().ChainID(gomock.Any()).Return(big.NewInt(1), nil)
+	s.mockClients[0].EXPECT().SendRawTransaction(gomock.Any(), []byte{3}).Return(errors.New("connection refused"))
+	s.mockClients[1].EXPECT().SendRawTransaction(gomock.Any(), []byte{3}).Return(nil)
+	s.mockClients[2].EXPECT().SendRawTransaction(gomock.Any(), []byte{3}).Return(revertError{})
+
+	hash, err := s.client.SignAndSendTransaction(context.Background(), testTransaction{})
+
+	s.Nil(err)
+	s.Equal(hash, common.Hash{2})
+}
+
+func (s *MultiClientTestSuite) Test_SignAndSendTransaction_RefusedByAllEndpoints() {
+	s.mockClients[0].EXPECT().ChainID(gomock.Any()).Return(big.NewInt(1), nil)
+	for _, mockClient := range s.mockClients {
+		mockClient.EXPECT().SendRawTransaction(gomock.Any(), []byte{3}).Return(revertError{})
+	}
+
+	_, err := s.client.SignAndSendTransaction(context.Background(), testTransaction{})
+
+	s.NotNil(err)
+}
+
+func (s *MultiClientTestSuite) Test_UnsafeNonce_FailsOver() {
+	s.mockMetrics.EXPECT().TrackEndpointHealth(uint8(1), "primary", false)
+	s.mockClients[0].EXPECT().PendingNonceAt(gomock.Any(), common.Address{1}).Return(uint64(0), errors.New("timeout"))
+	s.mockClients[1].EXPECT().PendingNonceAt(gomock.Any(), common.Address{1}).Return(uint64(5), nil)
+
+	nonce, err := s.client.UnsafeNonce()
+	s.Nil(err)
+	s.Equal(nonce, big.NewInt(5))
+
+	s.Nil(s.client.UnsafeIncreaseNonce())
+	nonce, err = s.client.UnsafeNonce()
+	s.Nil(err)
+	s.Equal(nonce, big.NewInt(6))
+}
+
+type otherSigner struct{}
+
+func (s otherSigner) CommonAddress() common.Address          { return common.Address{2} }
+func (s otherSigner) Sign(digestHash []byte) ([]byte, error) { return []byte{}, nil }
+
+func (s *MultiClientTestSuite) Test_WithSigner_TracksNonceSeparately() {
+	s.mockClients[0].EXPECT().PendingNonceAt(gomock.Any(), common.Address{1}).Return(uint64(5), nil)
+	s.mockClients[0].EXPECT().PendingNonceAt(gomock.Any(), common.Address{2}).Return(uint64(1), nil)
+	signerClient := s.client.WithSigner(otherSigner{})
+
+	s.Nil(s.client.UnsafeIncreaseNonce())
+	nonce, err := signerClient.UnsafeNonce()
+	s.Nil(err)
+	s.Equal(nonce, big.NewInt(1))
+	s.Equal(signerClient.From(), common.Address{2})
+}
+
+func (s *MultiClientTestSuite) Test_FetchEventLogs_QuorumReached() {
+	logs := []types.Log{{TxHash: common.Hash{1}, Index: 1}}
+	s.mockClients[0].EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), "Deposit", big.NewInt(1), big.NewInt(5)).Return([]types.Log{}, nil)
+	s.mockClients[1].EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), "Deposit", big.NewInt(1), big.NewInt(5)).Return(logs, nil)
+	s.mockClients[2].EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), "Deposit", big.NewInt(1), big.NewInt(5)).Return(logs, nil)
+
+	fetchedLogs, err := client.NewQuorumClient(s.client, 2).FetchEventLogs(context.Background(), common.Address{}, "Deposit", big.NewInt(1), big.NewInt(5))
+
+	s.Nil(err)
+	s.Equal(fetchedLogs, logs)
+}
+
+func (s *MultiClientTestSuite) Test_FetchEventLogs_QuorumNotReached() {
+	s.mockMetrics.EXPECT().TrackEndpointHealth(uint8(1), "tertiary", false)
+	s.mockClients[0].EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), "Deposit", gomock.Any(), gomock.Any()).Return([]types.Log{}, nil)
+	s.mockClients[1].EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), "Deposit", gomock.Any(), gomock.Any()).Return([]types.Log{{TxHash: common.Hash{1}}}, nil)
+	s.mockClients[2].EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), "Deposit", gomock.Any(), gomock.Any()).Return(nil, errors.New("timeout"))
+
+	_, err := client.NewQuorumClient(s.client, 2).FetchEventLogs(context.Background(), common.Address{}, "Deposit", big.NewInt(1), big.NewInt(5))
+
+	s.NotNil(err)
+}
+
+func (s *MultiClientTestSuite) Test_FetchEventLogs_NoQuorumFailsOver() {
+	s.mockClients[0].EXPECT().FetchEventLogs(gomock.Any(), gomock.Any(), "Deposit", gomock.Any(), gomock.Any()).Return([]types.Log{}, nil)
+
+	_, err := client.NewQuorumClient(s.client, 1).FetchEventLogs(context.Background(), common.Address{}, "Deposit", big.NewInt(1), big.NewInt(5))
+
+	s.Nil(err)
+}
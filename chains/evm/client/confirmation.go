@@ -0,0 +1,158 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	tagProbeInterval = 10 * time.Minute
+	// invalidParamsCode is returned by nodes that do not recognize the block tag
+	invalidParamsCode = -32602
+)
+
+var unsupportedTagMessages = []string{
+	"block tag",
+	"unknown block",
+	"invalid block",
+}
+
+type HeadFetcher interface {
+	LatestBlock() (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// ConfirmedHead returns the latest block of the domain that is considered confirmed
+// according to the confirmation mode of the domain
+type ConfirmedHead struct {
+	client        HeadFetcher
+	mode          evm.ConfirmationMode
+	confirmations *big.Int
+
+	// TagProbeInterval is the period after which a node that did not support
+	// the block tag is asked for it again
+	TagProbeInterval time.Duration
+
+	lock             sync.Mutex
+	unsupportedUntil time.Time
+}
+
+// NewConfirmedHead creates a confirmed head for the mode.
+// In depth mode blocks are confirmed once they are confirmations deep while in safe and finalized
+// modes blocks are confirmed up to the safe or finalized block reported by the node.
+// If the node does not support the block tag the confirmed head falls back to depth mode
+// until the tag is probed again.
+func NewConfirmedHead(client HeadFetcher, mode evm.ConfirmationMode, confirmations *big.Int) *ConfirmedHead {
+	return &ConfirmedHead{
+		client:           client,
+		mode:             mode,
+		confirmations:    confirmations,
+		TagProbeInterval: tagProbeInterval,
+	}
+}
+
+// LatestBlock returns the latest confirmed block
+func (h *ConfirmedHead) LatestBlock() (*big.Int, error) {
+	var tag rpc.BlockNumber
+	switch h.mode {
+	case evm.SafeConfirmation:
+		tag = rpc.SafeBlockNumber
+	case evm.FinalizedConfirmation:
+		tag = rpc.FinalizedBlockNumber
+	default:
+		return h.depthHead()
+	}
+	if h.isUnsupported() {
+		return h.depthHead()
+	}
+
+	header, err := h.client.HeaderByNumber(context.Background(), big.NewInt(int64(tag)))
+	if err != nil {
+		if !isUnsupportedTag(err) {
+			return nil, err
+		}
+
+		h.lock.Lock()
+		h.unsupportedUntil = time.Now().Add(h.TagProbeInterval)
+		h.lock.Unlock()
+		log.Warn().Err(err).Msgf("Node does not support %s block tag, falling back to %s block confirmations for %s", h.mode, h.confirmations, h.TagProbeInterval)
+		return h.depthHead()
+	}
+	return header.Number, nil
+}
+
+func (h *ConfirmedHead) isUnsupported() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return time.Now().Before(h.unsupportedUntil)
+}
+
+func (h *ConfirmedHead) depthHead() (*big.Int, error) {
+	latest, err := h.client.LatestBlock()
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Sub(latest, h.confirmations), nil
+}
+
+// isUnsupportedTag returns true if the node responded that it does not know the block tag.
+// Other node errors, such as rate limits, do not mean the tag is unsupported.
+func isUnsupportedTag(err error) bool {
+	if errors.Is(err, ethereum.NotFound) {
+		return true
+	}
+
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	if rpcErr.ErrorCode() == invalidParamsCode {
+		return true
+	}
+
+	msg := strings.ToLower(rpcErr.Error())
+	for _, unsupportedMsg := range unsupportedTagMessages {
+		if strings.Contains(msg, unsupportedMsg) {
+			return true
+		}
+	}
+	return false
+}
+
+type EventClient interface {
+	FetchEventLogs(ctx context.Context, contractAddress common.Address, event string, startBlock *big.Int, endBlock *big.Int) ([]types.Log, error)
+	WaitAndReturnTxReceipt(h common.Hash) (*types.Receipt, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+}
+
+// ConfirmedClient is an event client whose latest block is the confirmed head
+// so event listeners only accept events from confirmed blocks
+type ConfirmedClient struct {
+	EventClient
+	head *ConfirmedHead
+}
+
+func NewConfirmedClient(client EventClient, head *ConfirmedHead) *ConfirmedClient {
+	return &ConfirmedClient{
+		EventClient: client,
+		head:        head,
+	}
+}
+
+func (c *ConfirmedClient) LatestBlock() (*big.Int, error) {
+	return c.head.LatestBlock()
+}
@@ -0,0 +1,120 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package client_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/client"
+	mock_client "github.com/ChainSafe/sygma-relayer/chains/evm/client/mock"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type unsupportedTagError struct{}
+
+func (e unsupportedTagError) Error() string  { return "invalid block tag" }
+func (e unsupportedTagError) ErrorCode() int { return -32602 }
+
+type rateLimitError struct{}
+
+func (e rateLimitError) Error() string  { return "rate limit exceeded" }
+func (e rateLimitError) ErrorCode() int { return -32005 }
+
+type ConfirmedHeadTestSuite struct {
+	suite.Suite
+	mockHeadFetcher *mock_client.MockHeadFetcher
+}
+
+func TestRunConfirmedHeadTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfirmedHeadTestSuite))
+}
+
+func (s *ConfirmedHeadTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockHeadFetcher = mock_client.NewMockHeadFetcher(ctrl)
+}
+
+func (s *ConfirmedHeadTestSuite) Test_DepthMode_SubtractsConfirmations() {
+	s.mockHeadFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	head := client.NewConfirmedHead(s.mockHeadFetcher, evm.DepthConfirmation, big.NewInt(10))
+
+	block, err := head.LatestBlock()
+
+	s.Nil(err)
+	s.Equal(block, big.NewInt(90))
+}
+
+func (s *ConfirmedHeadTestSuite) Test_FinalizedMode_ReturnsFinalizedBlock() {
+	s.mockHeadFetcher.EXPECT().HeaderByNumber(gomock.Any(), big.NewInt(int64(rpc.FinalizedBlockNumber))).Return(&types.Header{Number: big.NewInt(64)}, nil)
+	head := client.NewConfirmedHead(s.mockHeadFetcher, evm.FinalizedConfirmation, big.NewInt(10))
+
+	block, err := head.LatestBlock()
+
+	s.Nil(err)
+	s.Equal(block, big.NewInt(64))
+}
+
+func (s *ConfirmedHeadTestSuite) Test_SafeMode_ReturnsSafeBlock() {
+	s.mockHeadFetcher.EXPECT().HeaderByNumber(gomock.Any(), big.NewInt(int64(rpc.SafeBlockNumber))).Return(&types.Header{Number: big.NewInt(96)}, nil)
+	head := client.NewConfirmedHead(s.mockHeadFetcher, evm.SafeConfirmation, big.NewInt(10))
+
+	block, err := head.LatestBlock()
+
+	s.Nil(err)
+	s.Equal(block, big.NewInt(96))
+}
+
+func (s *ConfirmedHeadTestSuite) Test_UnsupportedTag_FallsBackToDepth() {
+	s.mockHeadFetcher.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).Return(nil, unsupportedTagError{}).Times(1)
+	s.mockHeadFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil).Times(2)
+	head := client.NewConfirmedHead(s.mockHeadFetcher, evm.FinalizedConfirmation, big.NewInt(10))
+
+	block, err := head.LatestBlock()
+	s.Nil(err)
+	s.Equal(block, big.NewInt(90))
+
+	block, err = head.LatestBlock()
+	s.Nil(err)
+	s.Equal(block, big.NewInt(90))
+}
+
+func (s *ConfirmedHeadTestSuite) Test_ConnectionError_ReturnsError() {
+	s.mockHeadFetcher.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+	head := client.NewConfirmedHead(s.mockHeadFetcher, evm.FinalizedConfirmation, big.NewInt(10))
+
+	_, err := head.LatestBlock()
+
+	s.NotNil(err)
+}
+
+func (s *ConfirmedHeadTestSuite) Test_UnsupportedTag_ProbedAgainAfterInterval() {
+	s.mockHeadFetcher.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).Return(nil, unsupportedTagError{})
+	s.mockHeadFetcher.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockHeadFetcher.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).Return(&types.Header{Number: big.NewInt(64)}, nil)
+	head := client.NewConfirmedHead(s.mockHeadFetcher, evm.FinalizedConfirmation, big.NewInt(10))
+	head.TagProbeInterval = 0
+
+	block, err := head.LatestBlock()
+	s.Nil(err)
+	s.Equal(block, big.NewInt(90))
+
+	block, err = head.LatestBlock()
+	s.Nil(err)
+	s.Equal(block, big.NewInt(64))
+}
+
+func (s *ConfirmedHeadTestSuite) Test_RateLimit_ReturnsError() {
+	s.mockHeadFetcher.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).Return(nil, rateLimitError{})
+	head := client.NewConfirmedHead(s.mockHeadFetcher, evm.FinalizedConfirmation, big.NewInt(10))
+
+	_, err := head.LatestBlock()
+
+	s.NotNil(err)
+}
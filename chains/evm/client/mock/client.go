@@ -0,0 +1,371 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/client/client.go
+
+// Package mock_client is a generated GoMock package.
+package mock_client
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	common "github.com/ethereum/go-ethereum/common"
+	types "github.com/ethereum/go-ethereum/core/types"
+	rpc "github.com/ethereum/go-ethereum/rpc"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRPCClient is a mock of RPCClient interface.
+type MockRPCClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockRPCClientMockRecorder
+}
+
+// MockRPCClientMockRecorder is the mock recorder for MockRPCClient.
+type MockRPCClientMockRecorder struct {
+	mock *MockRPCClient
+}
+
+// NewMockRPCClient creates a new mock instance.
+func NewMockRPCClient(ctrl *gomock.Controller) *MockRPCClient {
+	mock := &MockRPCClient{ctrl: ctrl}
+	mock.recorder = &MockRPCClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRPCClient) EXPECT() *MockRPCClientMockRecorder {
+	return m.recorder
+}
+
+// BalanceAt mocks base method.
+func (m *MockRPCClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalanceAt", ctx, account, blockNumber)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceAt indicates an expected call of BalanceAt.
+func (mr *MockRPCClientMockRecorder) BalanceAt(ctx, account, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceAt", reflect.TypeOf((*MockRPCClient)(nil).BalanceAt), ctx, account, blockNumber)
+}
+
+// BaseFee mocks base method.
+func (m *MockRPCClient) BaseFee() (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseFee")
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BaseFee indicates an expected call of BaseFee.
+func (mr *MockRPCClientMockRecorder) BaseFee() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseFee", reflect.TypeOf((*MockRPCClient)(nil).BaseFee))
+}
+
+// BatchCallContext mocks base method.
+func (m *MockRPCClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCallContext", ctx, b)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchCallContext indicates an expected call of BatchCallContext.
+func (mr *MockRPCClientMockRecorder) BatchCallContext(ctx, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCallContext", reflect.TypeOf((*MockRPCClient)(nil).BatchCallContext), ctx, b)
+}
+
+// BlockByNumber mocks base method.
+func (m *MockRPCClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockByNumber", ctx, number)
+	ret0, _ := ret[0].(*types.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockByNumber indicates an expected call of BlockByNumber.
+func (mr *MockRPCClientMockRecorder) BlockByNumber(ctx, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockByNumber", reflect.TypeOf((*MockRPCClient)(nil).BlockByNumber), ctx, number)
+}
+
+// CallContract mocks base method.
+func (m *MockRPCClient) CallContract(ctx context.Context, callArgs map[string]interface{}, blockNumber *big.Int) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CallContract", ctx, callArgs, blockNumber)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CallContract indicates an expected call of CallContract.
+func (mr *MockRPCClientMockRecorder) CallContract(ctx, callArgs, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallContract", reflect.TypeOf((*MockRPCClient)(nil).CallContract), ctx, callArgs, blockNumber)
+}
+
+// ChainID mocks base method.
+func (m *MockRPCClient) ChainID(ctx context.Context) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainID", ctx)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainID indicates an expected call of ChainID.
+func (mr *MockRPCClientMockRecorder) ChainID(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainID", reflect.TypeOf((*MockRPCClient)(nil).ChainID), ctx)
+}
+
+// CodeAt mocks base method.
+func (m *MockRPCClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CodeAt", ctx, contract, blockNumber)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CodeAt indicates an expected call of CodeAt.
+func (mr *MockRPCClientMockRecorder) CodeAt(ctx, contract, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CodeAt", reflect.TypeOf((*MockRPCClient)(nil).CodeAt), ctx, contract, blockNumber)
+}
+
+// EstimateGas mocks base method.
+func (m *MockRPCClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateGas", ctx, msg)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateGas indicates an expected call of EstimateGas.
+func (mr *MockRPCClientMockRecorder) EstimateGas(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateGas", reflect.TypeOf((*MockRPCClient)(nil).EstimateGas), ctx, msg)
+}
+
+// FetchEventLogs mocks base method.
+func (m *MockRPCClient) FetchEventLogs(ctx context.Context, contractAddress common.Address, event string, startBlock, endBlock *big.Int) ([]types.Log, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchEventLogs", ctx, contractAddress, event, startBlock, endBlock)
+	ret0, _ := ret[0].([]types.Log)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchEventLogs indicates an expected call of FetchEventLogs.
+func (mr *MockRPCClientMockRecorder) FetchEventLogs(ctx, contractAddress, event, startBlock, endBlock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchEventLogs", reflect.TypeOf((*MockRPCClient)(nil).FetchEventLogs), ctx, contractAddress, event, startBlock, endBlock)
+}
+
+// GetTransactionByHash mocks base method.
+func (m *MockRPCClient) GetTransactionByHash(h common.Hash) (*types.Transaction, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByHash", h)
+	ret0, _ := ret[0].(*types.Transaction)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTransactionByHash indicates an expected call of GetTransactionByHash.
+func (mr *MockRPCClientMockRecorder) GetTransactionByHash(h interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByHash", reflect.TypeOf((*MockRPCClient)(nil).GetTransactionByHash), h)
+}
+
+// HeaderByNumber mocks base method.
+func (m *MockRPCClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeaderByNumber", ctx, number)
+	ret0, _ := ret[0].(*types.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeaderByNumber indicates an expected call of HeaderByNumber.
+func (mr *MockRPCClientMockRecorder) HeaderByNumber(ctx, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeaderByNumber", reflect.TypeOf((*MockRPCClient)(nil).HeaderByNumber), ctx, number)
+}
+
+// LatestBlock mocks base method.
+func (m *MockRPCClient) LatestBlock() (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestBlock")
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestBlock indicates an expected call of LatestBlock.
+func (mr *MockRPCClientMockRecorder) LatestBlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestBlock", reflect.TypeOf((*MockRPCClient)(nil).LatestBlock))
+}
+
+// NonceAt mocks base method.
+func (m *MockRPCClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NonceAt", ctx, account, blockNumber)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NonceAt indicates an expected call of NonceAt.
+func (mr *MockRPCClientMockRecorder) NonceAt(ctx, account, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NonceAt", reflect.TypeOf((*MockRPCClient)(nil).NonceAt), ctx, account, blockNumber)
+}
+
+// PendingNonceAt mocks base method.
+func (m *MockRPCClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingNonceAt", ctx, account)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingNonceAt indicates an expected call of PendingNonceAt.
+func (mr *MockRPCClientMockRecorder) PendingNonceAt(ctx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingNonceAt", reflect.TypeOf((*MockRPCClient)(nil).PendingNonceAt), ctx, account)
+}
+
+// SendRawTransaction mocks base method.
+func (m *MockRPCClient) SendRawTransaction(ctx context.Context, tx []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendRawTransaction", ctx, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendRawTransaction indicates an expected call of SendRawTransaction.
+func (mr *MockRPCClientMockRecorder) SendRawTransaction(ctx, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRawTransaction", reflect.TypeOf((*MockRPCClient)(nil).SendRawTransaction), ctx, tx)
+}
+
+// SubscribeNewHead mocks base method.
+func (m *MockRPCClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeNewHead", ctx, ch)
+	ret0, _ := ret[0].(ethereum.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeNewHead indicates an expected call of SubscribeNewHead.
+func (mr *MockRPCClientMockRecorder) SubscribeNewHead(ctx, ch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeNewHead", reflect.TypeOf((*MockRPCClient)(nil).SubscribeNewHead), ctx, ch)
+}
+
+// SuggestGasPrice mocks base method.
+func (m *MockRPCClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestGasPrice", ctx)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestGasPrice indicates an expected call of SuggestGasPrice.
+func (mr *MockRPCClientMockRecorder) SuggestGasPrice(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestGasPrice", reflect.TypeOf((*MockRPCClient)(nil).SuggestGasPrice), ctx)
+}
+
+// SuggestGasTipCap mocks base method.
+func (m *MockRPCClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestGasTipCap", ctx)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestGasTipCap indicates an expected call of SuggestGasTipCap.
+func (mr *MockRPCClientMockRecorder) SuggestGasTipCap(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestGasTipCap", reflect.TypeOf((*MockRPCClient)(nil).SuggestGasTipCap), ctx)
+}
+
+// TransactionReceipt mocks base method.
+func (m *MockRPCClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransactionReceipt", ctx, txHash)
+	ret0, _ := ret[0].(*types.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransactionReceipt indicates an expected call of TransactionReceipt.
+func (mr *MockRPCClientMockRecorder) TransactionReceipt(ctx, txHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionReceipt", reflect.TypeOf((*MockRPCClient)(nil).TransactionReceipt), ctx, txHash)
+}
+
+// MockEndpointMetrics is a mock of EndpointMetrics interface.
+type MockEndpointMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockEndpointMetricsMockRecorder
+}
+
+// MockEndpointMetricsMockRecorder is the mock recorder for MockEndpointMetrics.
+type MockEndpointMetricsMockRecorder struct {
+	mock *MockEndpointMetrics
+}
+
+// NewMockEndpointMetrics creates a new mock instance.
+func NewMockEndpointMetrics(ctrl *gomock.Controller) *MockEndpointMetrics {
+	mock := &MockEndpointMetrics{ctrl: ctrl}
+	mock.recorder = &MockEndpointMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEndpointMetrics) EXPECT() *MockEndpointMetricsMockRecorder {
+	return m.recorder
+}
+
+// TrackEndpointHealth mocks base method.
+func (m *MockEndpointMetrics) TrackEndpointHealth(domainID uint8, endpoint string, healthy bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackEndpointHealth", domainID, endpoint, healthy)
+}
+
+// TrackEndpointHealth indicates an expected call of TrackEndpointHealth.
+func (mr *MockEndpointMetricsMockRecorder) TrackEndpointHealth(domainID, endpoint, healthy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackEndpointHealth", reflect.TypeOf((*MockEndpointMetrics)(nil).TrackEndpointHealth), domainID, endpoint, healthy)
+}
+
+// TrackEndpointRequest mocks base method.
+func (m *MockEndpointMetrics) TrackEndpointRequest(domainID uint8, endpoint string, success bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackEndpointRequest", domainID, endpoint, success)
+}
+
+// TrackEndpointRequest indicates an expected call of TrackEndpointRequest.
+func (mr *MockEndpointMetricsMockRecorder) TrackEndpointRequest(domainID, endpoint, success interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackEndpointRequest", reflect.TypeOf((*MockEndpointMetrics)(nil).TrackEndpointRequest), domainID, endpoint, success)
+}
@@ -0,0 +1,136 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/client/confirmation.go
+
+// Package mock_client is a generated GoMock package.
+package mock_client
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	common "github.com/ethereum/go-ethereum/common"
+	types "github.com/ethereum/go-ethereum/core/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHeadFetcher is a mock of HeadFetcher interface.
+type MockHeadFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockHeadFetcherMockRecorder
+}
+
+// MockHeadFetcherMockRecorder is the mock recorder for MockHeadFetcher.
+type MockHeadFetcherMockRecorder struct {
+	mock *MockHeadFetcher
+}
+
+// NewMockHeadFetcher creates a new mock instance.
+func NewMockHeadFetcher(ctrl *gomock.Controller) *MockHeadFetcher {
+	mock := &MockHeadFetcher{ctrl: ctrl}
+	mock.recorder = &MockHeadFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHeadFetcher) EXPECT() *MockHeadFetcherMockRecorder {
+	return m.recorder
+}
+
+// HeaderByNumber mocks base method.
+func (m *MockHeadFetcher) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeaderByNumber", ctx, number)
+	ret0, _ := ret[0].(*types.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeaderByNumber indicates an expected call of HeaderByNumber.
+func (mr *MockHeadFetcherMockRecorder) HeaderByNumber(ctx, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeaderByNumber", reflect.TypeOf((*MockHeadFetcher)(nil).HeaderByNumber), ctx, number)
+}
+
+// LatestBlock mocks base method.
+func (m *MockHeadFetcher) LatestBlock() (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestBlock")
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestBlock indicates an expected call of LatestBlock.
+func (mr *MockHeadFetcherMockRecorder) LatestBlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestBlock", reflect.TypeOf((*MockHeadFetcher)(nil).LatestBlock))
+}
+
+// MockEventClient is a mock of EventClient interface.
+type MockEventClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventClientMockRecorder
+}
+
+// MockEventClientMockRecorder is the mock recorder for MockEventClient.
+type MockEventClientMockRecorder struct {
+	mock *MockEventClient
+}
+
+// NewMockEventClient creates a new mock instance.
+func NewMockEventClient(ctrl *gomock.Controller) *MockEventClient {
+	mock := &MockEventClient{ctrl: ctrl}
+	mock.recorder = &MockEventClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventClient) EXPECT() *MockEventClientMockRecorder {
+	return m.recorder
+}
+
+// BlockByNumber mocks base method.
+func (m *MockEventClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockByNumber", ctx, number)
+	ret0, _ := ret[0].(*types.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockByNumber indicates an expected call of BlockByNumber.
+func (mr *MockEventClientMockRecorder) BlockByNumber(ctx, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockByNumber", reflect.TypeOf((*MockEventClient)(nil).BlockByNumber), ctx, number)
+}
+
+// FetchEventLogs mocks base method.
+func (m *MockEventClient) FetchEventLogs(ctx context.Context, contractAddress common.Address, event string, startBlock, endBlock *big.Int) ([]types.Log, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchEventLogs", ctx, contractAddress, event, startBlock, endBlock)
+	ret0, _ := ret[0].([]types.Log)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchEventLogs indicates an expected call of FetchEventLogs.
+func (mr *MockEventClientMockRecorder) FetchEventLogs(ctx, contractAddress, event, startBlock, endBlock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchEventLogs", reflect.TypeOf((*MockEventClient)(nil).FetchEventLogs), ctx, contractAddress, event, startBlock, endBlock)
+}
+
+// WaitAndReturnTxReceipt mocks base method.
+func (m *MockEventClient) WaitAndReturnTxReceipt(h common.Hash) (*types.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitAndReturnTxReceipt", h)
+	ret0, _ := ret[0].(*types.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitAndReturnTxReceipt indicates an expected call of WaitAndReturnTxReceipt.
+func (mr *MockEventClientMockRecorder) WaitAndReturnTxReceipt(h interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitAndReturnTxReceipt", reflect.TypeOf((*MockEventClient)(nil).WaitAndReturnTxReceipt), h)
+}
@@ -0,0 +1,80 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// QuorumClient is a MultiClient that requires event logs to be returned
+// by a quorum of endpoints before they are processed
+type QuorumClient struct {
+	*MultiClient
+	quorum int
+}
+
+// NewQuorumClient wraps the client so event logs are fetched from every endpoint and
+// accepted only if at least quorum endpoints returned the same logs.
+// Quorum of one or less falls back to failover reads.
+func NewQuorumClient(c *MultiClient, quorum int) *QuorumClient {
+	return &QuorumClient{
+		MultiClient: c,
+		quorum:      quorum,
+	}
+}
+
+func (c *QuorumClient) FetchEventLogs(ctx context.Context, contractAddress common.Address, event string, startBlock *big.Int, endBlock *big.Int) ([]types.Log, error) {
+	if c.quorum <= 1 {
+		return c.MultiClient.FetchEventLogs(ctx, contractAddress, event, startBlock, endBlock)
+	}
+
+	results := make([][]types.Log, len(c.endpoints))
+	errs := make([]error, len(c.endpoints))
+	wg := sync.WaitGroup{}
+	for i, endpoint := range c.endpoints {
+		wg.Add(1)
+		go func(i int, endpoint *Endpoint) {
+			defer wg.Done()
+			results[i], errs[i] = endpoint.Client.FetchEventLogs(ctx, contractAddress, event, startBlock, endBlock)
+			c.track(endpoint, errs[i])
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	votes := make(map[string]int)
+	var endpointErr error
+	for i, logs := range results {
+		if errs[i] != nil {
+			endpointErr = errs[i]
+			continue
+		}
+
+		digest := logsDigest(logs)
+		votes[digest]++
+		if votes[digest] >= c.quorum {
+			return logs, nil
+		}
+	}
+	if endpointErr != nil {
+		return nil, fmt.Errorf("quorum of %d endpoints not reached for %s logs in blocks %s-%s: %w", c.quorum, event, startBlock, endBlock, endpointErr)
+	}
+	return nil, fmt.Errorf("quorum of %d endpoints not reached for %s logs in blocks %s-%s", c.quorum, event, startBlock, endBlock)
+}
+
+// logsDigest identifies logs by their block hash, transaction hash and index
+// so that logs from different endpoints can be compared
+func logsDigest(logs []types.Log) string {
+	var digest strings.Builder
+	for _, log := range logs {
+		digest.WriteString(fmt.Sprintf("%s:%s:%d;", log.BlockHash, log.TxHash, log.Index))
+	}
+	return digest.String()
+}
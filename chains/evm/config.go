@@ -6,9 +6,12 @@ package evm
 import (
 	"fmt"
 	"math/big"
+	"net/url"
+	"sort"
 	"time"
 
 	"github.com/creasty/defaults"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/mitchellh/mapstructure"
 
@@ -19,10 +22,49 @@ import (
 type HandlerConfig struct {
 	Address string
 	Type    string
+	// TransferType, DepositData and ProposalData describe handlers of the abi type
+	TransferType string
+	DepositData  []abi.ArgumentMarshaling
+	ProposalData []abi.ArgumentMarshaling
+}
+
+// ConfirmationMode selects when blocks are considered confirmed
+type ConfirmationMode string
+
+const (
+	// DepthConfirmation confirms blocks that are BlockConfirmations deep
+	DepthConfirmation ConfirmationMode = "depth"
+	// SafeConfirmation confirms blocks up to the safe block of the node
+	SafeConfirmation ConfirmationMode = "safe"
+	// FinalizedConfirmation confirms blocks up to the finalized block of the node
+	FinalizedConfirmation ConfirmationMode = "finalized"
+)
+
+// EndpointConfig is an RPC endpoint of the domain.
+// Endpoints with a lower priority value are tried first.
+type EndpointConfig struct {
+	Url      string `mapstructure:"url"`
+	Name     string `mapstructure:"name"`
+	Priority int    `mapstructure:"priority"`
+}
+
+// EndpointName returns the configured name of the endpoint or the host of the url
+// so that credentials in the url path are not exposed in logs and metrics
+func (c EndpointConfig) EndpointName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	u, err := url.Parse(c.Url)
+	if err != nil || u.Host == "" {
+		return "endpoint"
+	}
+	return u.Host
 }
 
 type EVMConfig struct {
 	GeneralChainConfig    chain.GeneralChainConfig
+	Endpoints             []EndpointConfig
+	DepositQuorum         int
 	Bridge                string
 	Retry                 string
 	FrostKeygen           string
@@ -34,17 +76,29 @@ type EVMConfig struct {
 	GasIncreasePercentage *big.Int
 	StartBlock            *big.Int
 	BlockConfirmations    *big.Int
+	ConfirmationMode      ConfirmationMode
 	BlockInterval         *big.Int
 	BlockRetryInterval    time.Duration
+	LogConcurrency        int
+	HeadSubscription      bool
+	SenderKeys            []string
+	SenderStuckTimeout    time.Duration
+	MinSenderBalance      *big.Int
 }
 
 func (c *EVMConfig) String() string {
 	privateKey, _ := crypto.HexToECDSA(c.GeneralChainConfig.Key)
 	kp := secp256k1.NewKeypair(*privateKey)
-	return fmt.Sprintf(`Name: '%s', Id: '%d', Type: '%s', BlockstorePath: '%s', FreshStart: '%t', LatestBlock: '%t', Key address: '%s', Bridge: '%s', Retry: '%s', Handlers: %+v, MaxGasPrice: '%s', GasMultiplier: '%s', GasLimit: '%s', TransferGas: '%d', StartBlock: '%s', BlockConfirmations: '%s', BlockInterval: '%s', BlockRetryInterval: '%s'`,
+	endpoints := make([]string, 0)
+	for _, endpoint := range c.RPCEndpoints() {
+		endpoints = append(endpoints, endpoint.EndpointName())
+	}
+	return fmt.Sprintf(`Name: '%s', Id: '%d', Type: '%s', Endpoints: %v, DepositQuorum: '%d', BlockstorePath: '%s', FreshStart: '%t', LatestBlock: '%t', Key address: '%s', Bridge: '%s', Retry: '%s', Handlers: %+v, MaxGasPrice: '%s', GasMultiplier: '%s', GasLimit: '%s', TransferGas: '%d', StartBlock: '%s', BlockConfirmations: '%s', ConfirmationMode: '%s', BlockInterval: '%s', BlockRetryInterval: '%s', LogConcurrency: '%d', HeadSubscription: '%t', SenderKeys: '%d', SenderStuckTimeout: '%s', MinSenderBalance: '%s'`,
 		c.GeneralChainConfig.Name,
 		*c.GeneralChainConfig.Id,
 		c.GeneralChainConfig.Type,
+		endpoints,
+		c.DepositQuorum,
 		c.GeneralChainConfig.BlockstorePath,
 		c.GeneralChainConfig.FreshStart,
 		c.GeneralChainConfig.LatestBlock,
@@ -58,38 +112,89 @@ func (c *EVMConfig) String() string {
 		c.TransferGas,
 		c.StartBlock,
 		c.BlockConfirmations,
+		c.ConfirmationMode,
 		c.BlockInterval,
 		c.BlockRetryInterval,
+		c.LogConcurrency,
+		c.HeadSubscription,
+		len(c.SenderKeys),
+		c.SenderStuckTimeout,
+		c.MinSenderBalance,
 	)
 }
 
+// RPCEndpoints returns endpoints ordered by priority.
+// The single chain endpoint is used if no endpoints are configured.
+func (c *EVMConfig) RPCEndpoints() []EndpointConfig {
+	if len(c.Endpoints) == 0 {
+		return []EndpointConfig{{Url: c.GeneralChainConfig.Endpoint}}
+	}
+	return c.Endpoints
+}
+
 type RawEVMConfig struct {
 	chain.GeneralChainConfig `mapstructure:",squash"`
-	Bridge                   string          `mapstructure:"bridge"`
-	Retry                    string          `mapstructure:"retry"`
-	FrostKeygen              string          `mapstructure:"frostKeygen"`
-	Handlers                 []HandlerConfig `mapstrcture:"handlers"`
-	MaxGasPrice              int64           `mapstructure:"maxGasPrice" default:"500000000000"`
-	GasMultiplier            float64         `mapstructure:"gasMultiplier" default:"1"`
-	GasIncreasePercentage    int64           `mapstructure:"gasIncreasePercentage" default:"15"`
-	GasLimit                 int64           `mapstructure:"gasLimit" default:"15000000"`
-	TransferGas              uint64          `mapstructure:"transferGas" default:"250000"`
-	StartBlock               int64           `mapstructure:"startBlock"`
-	BlockConfirmations       int64           `mapstructure:"blockConfirmations" default:"10"`
-	BlockInterval            int64           `mapstructure:"blockInterval" default:"5"`
-	BlockRetryInterval       uint64          `mapstructure:"blockRetryInterval" default:"5"`
+	Endpoints                []EndpointConfig `mapstructure:"endpoints"`
+	DepositQuorum            int              `mapstructure:"depositQuorum"`
+	Bridge                   string           `mapstructure:"bridge"`
+	Retry                    string           `mapstructure:"retry"`
+	FrostKeygen              string           `mapstructure:"frostKeygen"`
+	Handlers                 []HandlerConfig  `mapstrcture:"handlers"`
+	MaxGasPrice              int64            `mapstructure:"maxGasPrice" default:"500000000000"`
+	GasMultiplier            float64          `mapstructure:"gasMultiplier" default:"1"`
+	GasIncreasePercentage    int64            `mapstructure:"gasIncreasePercentage" default:"15"`
+	GasLimit                 int64            `mapstructure:"gasLimit" default:"15000000"`
+	TransferGas              uint64           `mapstructure:"transferGas" default:"250000"`
+	StartBlock               int64            `mapstructure:"startBlock"`
+	BlockConfirmations       int64            `mapstructure:"blockConfirmations" default:"10"`
+	ConfirmationMode         string           `mapstructure:"confirmationMode" default:"depth"`
+	BlockInterval            int64            `mapstructure:"blockInterval" default:"5"`
+	BlockRetryInterval       uint64           `mapstructure:"blockRetryInterval" default:"5"`
+	LogConcurrency           int              `mapstructure:"logConcurrency" default:"4"`
+	HeadSubscription         bool             `mapstructure:"headSubscription"`
+	SenderKeys               []string         `mapstructure:"senderKeys"`
+	SenderStuckTimeout       uint64           `mapstructure:"senderStuckTimeout" default:"120"`
+	MinSenderBalance         int64            `mapstructure:"minSenderBalance"`
 }
 
 func (c *RawEVMConfig) Validate() error {
 	if err := c.GeneralChainConfig.Validate(); err != nil {
 		return err
 	}
+	for _, endpoint := range c.Endpoints {
+		if endpoint.Url == "" {
+			return fmt.Errorf("required field endpoints.url empty for chain %v", *c.Id)
+		}
+	}
+	endpoints := len(c.Endpoints)
+	if endpoints == 0 {
+		endpoints = 1
+	}
+	if c.DepositQuorum < 0 || c.DepositQuorum > endpoints {
+		return fmt.Errorf("depositQuorum has to be between 0 and the number of endpoints")
+	}
 	if c.Bridge == "" {
 		return fmt.Errorf("required field chain.Bridge empty for chain %v", *c.Id)
 	}
 	if c.BlockConfirmations < 1 {
 		return fmt.Errorf("blockConfirmations has to be >=1")
 	}
+	if c.LogConcurrency < 1 {
+		return fmt.Errorf("logConcurrency has to be >=1")
+	}
+	for _, key := range c.SenderKeys {
+		if _, err := crypto.HexToECDSA(key); err != nil {
+			return fmt.Errorf("senderKeys have to be hex encoded private keys")
+		}
+	}
+	if c.MinSenderBalance < 0 {
+		return fmt.Errorf("minSenderBalance has to be >=0")
+	}
+	switch ConfirmationMode(c.ConfirmationMode) {
+	case DepthConfirmation, SafeConfirmation, FinalizedConfirmation:
+	default:
+		return fmt.Errorf("confirmationMode has to be one of depth, safe or finalized")
+	}
 	return nil
 }
 
@@ -107,6 +212,11 @@ func NewEVMConfig(chainConfig map[string]interface{}) (*EVMConfig, error) {
 		return nil, err
 	}
 
+	sort.SliceStable(c.Endpoints, func(i, j int) bool { return c.Endpoints[i].Priority < c.Endpoints[j].Priority })
+	if c.Endpoint == "" && len(c.Endpoints) > 0 {
+		c.Endpoint = c.Endpoints[0].Url
+	}
+
 	err = c.Validate()
 	if err != nil {
 		return nil, err
@@ -115,6 +225,8 @@ func NewEVMConfig(chainConfig map[string]interface{}) (*EVMConfig, error) {
 	c.GeneralChainConfig.ParseFlags()
 	config := &EVMConfig{
 		GeneralChainConfig:    c.GeneralChainConfig,
+		Endpoints:             c.Endpoints,
+		DepositQuorum:         c.DepositQuorum,
 		Handlers:              c.Handlers,
 		Bridge:                c.Bridge,
 		Retry:                 c.Retry,
@@ -127,7 +239,13 @@ func NewEVMConfig(chainConfig map[string]interface{}) (*EVMConfig, error) {
 		GasMultiplier:         big.NewFloat(c.GasMultiplier),
 		StartBlock:            big.NewInt(c.StartBlock),
 		BlockConfirmations:    big.NewInt(c.BlockConfirmations),
+		ConfirmationMode:      ConfirmationMode(c.ConfirmationMode),
 		BlockInterval:         big.NewInt(c.BlockInterval),
+		LogConcurrency:        c.LogConcurrency,
+		HeadSubscription:      c.HeadSubscription,
+		SenderKeys:            c.SenderKeys,
+		SenderStuckTimeout:    time.Duration(c.SenderStuckTimeout) * time.Second,
+		MinSenderBalance:      big.NewInt(c.MinSenderBalance),
 	}
 
 	return config, nil
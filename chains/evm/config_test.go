@@ -10,6 +10,7 @@ import (
 
 	"github.com/ChainSafe/sygma-relayer/chains/evm"
 	"github.com/ChainSafe/sygma-relayer/config/chain"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -90,8 +91,12 @@ func (s *NewEVMConfigTestSuite) Test_ValidConfig() {
 		GasIncreasePercentage: big.NewInt(15),
 		StartBlock:            big.NewInt(0),
 		BlockConfirmations:    big.NewInt(10),
+		ConfirmationMode:      evm.DepthConfirmation,
 		BlockInterval:         big.NewInt(5),
 		BlockRetryInterval:    time.Duration(5) * time.Second,
+		LogConcurrency:        4,
+		SenderStuckTimeout:    time.Duration(120) * time.Second,
+		MinSenderBalance:      big.NewInt(0),
 	})
 }
 
@@ -156,7 +161,145 @@ func (s *NewEVMConfigTestSuite) Test_ValidConfigWithCustomTxParams() {
 		GasIncreasePercentage: big.NewInt(20),
 		StartBlock:            big.NewInt(1000),
 		BlockConfirmations:    big.NewInt(10),
+		ConfirmationMode:      evm.DepthConfirmation,
 		BlockInterval:         big.NewInt(2),
 		BlockRetryInterval:    time.Duration(10) * time.Second,
+		LogConcurrency:        4,
+		SenderStuckTimeout:    time.Duration(120) * time.Second,
+		MinSenderBalance:      big.NewInt(0),
 	})
 }
+
+func (s *NewEVMConfigTestSuite) Test_ValidConfigWithEndpoints() {
+	rawConfig := map[string]interface{}{
+		"id":     1,
+		"name":   "evm1",
+		"bridge": "bridgeAddress",
+		"endpoints": []map[string]interface{}{
+			{"url": "https://backup.com/key", "priority": 2},
+			{"url": "https://primary.com/key", "name": "primary", "priority": 1},
+		},
+		"depositQuorum": 2,
+	}
+
+	actualConfig, err := evm.NewEVMConfig(rawConfig)
+
+	s.Nil(err)
+	s.Equal(actualConfig.GeneralChainConfig.Endpoint, "https://primary.com/key")
+	s.Equal(actualConfig.DepositQuorum, 2)
+	s.Equal(actualConfig.RPCEndpoints(), []evm.EndpointConfig{
+		{Url: "https://primary.com/key", Name: "primary", Priority: 1},
+		{Url: "https://backup.com/key", Priority: 2},
+	})
+	s.Equal(actualConfig.RPCEndpoints()[1].EndpointName(), "backup.com")
+}
+
+func (s *NewEVMConfigTestSuite) Test_SingleEndpoint() {
+	actualConfig, err := evm.NewEVMConfig(map[string]interface{}{
+		"id":       1,
+		"endpoint": "ws://domain.com",
+		"name":     "evm1",
+		"bridge":   "bridgeAddress",
+	})
+
+	s.Nil(err)
+	s.Equal(actualConfig.RPCEndpoints(), []evm.EndpointConfig{{Url: "ws://domain.com"}})
+}
+
+func (s *NewEVMConfigTestSuite) Test_InvalidDepositQuorum() {
+	_, err := evm.NewEVMConfig(map[string]interface{}{
+		"id":            1,
+		"endpoint":      "ws://domain.com",
+		"name":          "evm1",
+		"bridge":        "bridgeAddress",
+		"depositQuorum": 2,
+	})
+
+	s.NotNil(err)
+	s.Equal(err.Error(), "depositQuorum has to be between 0 and the number of endpoints")
+}
+
+func (s *NewEVMConfigTestSuite) Test_FinalizedConfirmationMode() {
+	actualConfig, err := evm.NewEVMConfig(map[string]interface{}{
+		"id":               1,
+		"endpoint":         "ws://domain.com",
+		"name":             "evm1",
+		"bridge":           "bridgeAddress",
+		"confirmationMode": "finalized",
+	})
+
+	s.Nil(err)
+	s.Equal(actualConfig.ConfirmationMode, evm.FinalizedConfirmation)
+}
+
+func (s *NewEVMConfigTestSuite) Test_InvalidConfirmationMode() {
+	_, err := evm.NewEVMConfig(map[string]interface{}{
+		"id":               1,
+		"endpoint":         "ws://domain.com",
+		"name":             "evm1",
+		"bridge":           "bridgeAddress",
+		"confirmationMode": "latest",
+	})
+
+	s.NotNil(err)
+	s.Equal(err.Error(), "confirmationMode has to be one of depth, safe or finalized")
+}
+
+func (s *NewEVMConfigTestSuite) Test_InvalidLogConcurrency() {
+	_, err := evm.NewEVMConfig(map[string]interface{}{
+		"id":             1,
+		"endpoint":       "ws://domain.com",
+		"name":           "evm1",
+		"bridge":         "bridgeAddress",
+		"logConcurrency": -1,
+	})
+
+	s.NotNil(err)
+	s.Equal(err.Error(), "logConcurrency has to be >=1")
+}
+
+func (s *NewEVMConfigTestSuite) Test_ABIHandlerConfig() {
+	config, err := evm.NewEVMConfig(map[string]interface{}{
+		"id":       1,
+		"endpoint": "ws://domain.com",
+		"name":     "evm1",
+		"bridge":   "bridgeAddress",
+		"handlers": []interface{}{
+			map[string]interface{}{
+				"type":         "abi",
+				"address":      "address1",
+				"transferType": "custom",
+				"depositData": []interface{}{
+					map[string]interface{}{"name": "amount", "type": "uint256"},
+					map[string]interface{}{"name": "recipient", "type": "address"},
+				},
+			},
+		},
+	})
+
+	s.Nil(err)
+	s.Equal(config.Handlers, []evm.HandlerConfig{
+		{
+			Type:         "abi",
+			Address:      "address1",
+			TransferType: "custom",
+			DepositData: []abi.ArgumentMarshaling{
+				{Name: "amount", Type: "uint256"},
+				{Name: "recipient", Type: "address"},
+			},
+		},
+	})
+}
+
+func (s *NewEVMConfigTestSuite) Test_InvalidSenderKey() {
+	_, err := evm.NewEVMConfig(map[string]interface{}{
+		"id":         1,
+		"endpoint":   "ws://domain.com",
+		"name":       "evm1",
+		"bridge":     "bridgeAddress",
+		"senderKeys": []string{"invalid"},
+	})
+
+	s.NotNil(err)
+	s.Equal(err.Error(), "senderKeys have to be hex encoded private keys")
+}
@@ -5,6 +5,7 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
@@ -17,7 +18,9 @@ import (
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/rs/zerolog/log"
 
+	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/contracts/bridge"
 	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/relayer/limits"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ChainSafe/sygma-relayer/tss"
 	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/signing"
@@ -33,44 +36,66 @@ type Batch struct {
 var (
 	executionCheckPeriod = time.Minute
 	signingTimeout       = 30 * time.Minute
+	// ProposalOverheadGas is added to the estimated handler gas of each proposal
+	// to cover the signature verification and nonce bookkeeping of the bridge
+	ProposalOverheadGas uint64 = 50000
 )
 
 type BridgeContract interface {
-	IsProposalExecuted(p *transfer.TransferProposal) (bool, error)
+	AreProposalsExecuted(proposals []*transfer.TransferProposal) ([]bool, error)
 	ExecuteProposals(proposals []*transfer.TransferProposal, signature []byte, opts transactor.TransactOptions) (*ethCommon.Hash, error)
 	ProposalsHash(proposals []*transfer.TransferProposal) ([]byte, error)
+	SimulateProposal(p *transfer.TransferProposal) (uint64, error)
+}
+
+type VolumeLimiter interface {
+	Allow(source, destination uint8, depositNonce uint64, resourceID [32]byte, amount *big.Int) error
+}
+
+// FundingTracker prefers coordinators that can fund the execution transaction on the destination
+type FundingTracker interface {
+	PreferFunded(process tss.TssProcess, domainID uint8) tss.TssProcess
 }
 
 type Executor struct {
+	propStorer        PropStorer
 	coordinator       *tss.Coordinator
 	host              host.Host
 	comm              comm.Communication
 	fetcher           signing.SaveDataFetcher
 	bridge            BridgeContract
+	limiter           VolumeLimiter
 	exitLock          *sync.RWMutex
 	transactionMaxGas uint64
 	transferGasCost   uint64
+	funding           FundingTracker
 }
 
 func NewExecutor(
+	propStorer PropStorer,
 	host host.Host,
 	comm comm.Communication,
 	coordinator *tss.Coordinator,
 	bridgeContract BridgeContract,
+	limiter VolumeLimiter,
 	fetcher signing.SaveDataFetcher,
 	exitLock *sync.RWMutex,
 	transactionMaxGas uint64,
 	transferGasCost uint64,
+	funding FundingTracker,
 ) *Executor {
 	return &Executor{
+		propStorer:        propStorer,
 		host:              host,
 		comm:              comm,
 		coordinator:       coordinator,
 		bridge:            bridgeContract,
+		limiter:           limiter,
 		fetcher:           fetcher,
 		exitLock:          exitLock,
 		transactionMaxGas: transactionMaxGas,
 		transferGasCost:   transferGasCost,
+		funding:           funding,
 	}
 }
 
@@ -99,6 +124,7 @@ func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 
 			sessionID := fmt.Sprintf("%s-%d", messageID, i)
 			log.Info().Str("messageID", batch.proposals[0].MessageID).Msgf("Starting session with ID: %s", sessionID)
+			e.storeProposalsAttempt(b.proposals, messageID, sessionID)
 
 			msg := big.NewInt(0)
 			msg.SetBytes(propHash)
@@ -118,8 +144,9 @@ func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 			watchContext, cancelWatch := context.WithCancel(context.Background())
 			ep := pool.New().WithErrors()
 			ep.Go(func() error {
-				err := e.coordinator.Execute(executionContext, []tss.TssProcess{signing}, sigChn)
+				err := e.coordinator.Execute(executionContext, []tss.TssProcess{e.funding.PreferFunded(signing, b.proposals[0].Destination)}, sigChn)
 				if err != nil {
+					e.storeProposalsFailure(b.proposals, err)
 					cancelWatch()
 				}
 
@@ -145,6 +172,7 @@ func (e *Executor) watchExecution(
 	defer timeout.Stop()
 	defer cancelExecution()
 
+	txHash := ""
 	for {
 		select {
 		case sigResult := <-sigChn:
@@ -158,9 +186,11 @@ func (e *Executor) watchExecution(
 				hash, err := e.executeBatch(batch, signatureData)
 				if err != nil {
 					_ = e.comm.Broadcast(e.host.Peerstore().Peers(), []byte{}, comm.TssFailMsg, sessionID)
+					e.storeProposalsFailure(batch.proposals, err)
 					return err
 				}
 
+				txHash = hash.Hex()
 				log.Info().Str("messageID", messageID).Msgf("Sent proposals execution with hash: %s", hash)
 			}
 		case <-ticker.C:
@@ -169,12 +199,15 @@ func (e *Executor) watchExecution(
 					continue
 				}
 
+				e.storeProposalsExecution(batch.proposals, txHash)
 				log.Info().Str("messageID", messageID).Msgf("Successfully executed proposals")
 				return nil
 			}
 		case <-timeout.C:
 			{
-				return fmt.Errorf("execution timed out in %s", signingTimeout)
+				err := fmt.Errorf("execution timed out in %s", signingTimeout)
+				e.storeProposalsFailure(batch.proposals, err)
+				return err
 			}
 		case <-ctx.Done():
 			{
@@ -192,33 +225,43 @@ func (e *Executor) proposalBatches(proposals []*proposal.Proposal) ([]*Batch, er
 	}
 	batches[0] = currentBatch
 
-	for _, prop := range proposals {
-		transferProposal := &transfer.TransferProposal{
+	transferProposals := make([]*transfer.TransferProposal, len(proposals))
+	for i, prop := range proposals {
+		transferProposals[i] = &transfer.TransferProposal{
 			Source:      prop.Source,
 			Destination: prop.Destination,
 			Data:        prop.Data.(transfer.TransferProposalData),
 			Type:        prop.Type,
 			MessageID:   prop.MessageID,
 		}
+	}
+	executed, err := e.bridge.AreProposalsExecuted(transferProposals)
+	if err != nil {
+		return nil, err
+	}
 
-		isExecuted, err := e.bridge.IsProposalExecuted(transferProposal)
-		if err != nil {
-			return nil, err
-		}
-		if isExecuted {
+	for i, transferProposal := range transferProposals {
+		if executed[i] {
 			log.Info().Str("messageID", transferProposal.MessageID).Msgf("Proposal %p already executed", transferProposal)
 			continue
 		}
 
-		var propGasLimit uint64
-		l, ok := transferProposal.Data.Metadata["gasLimit"]
-		if ok {
-			propGasLimit = l.(uint64) + e.transferGasCost
-		} else {
-			propGasLimit = e.transferGasCost
+		if transferProposal.Data.Type == transfer.FungibleTransfer {
+			err = e.limiter.Allow(
+				transferProposal.Source,
+				transferProposal.Destination,
+				transferProposal.Data.DepositNonce,
+				transferProposal.Data.ResourceId,
+				limits.FungibleAmount(transferProposal.Data.Data))
+			if err != nil {
+				log.Err(err).Str("messageID", transferProposal.MessageID).Msgf("Proposal %p rejected by volume limits", transferProposal)
+				e.storeProposalsFailure([]*transfer.TransferProposal{transferProposal}, err)
+				continue
+			}
 		}
-		currentBatch.gasLimit += propGasLimit
-		if currentBatch.gasLimit >= e.transactionMaxGas {
+
+		propGasLimit := e.proposalGas(transferProposal)
+		if len(currentBatch.proposals) > 0 && currentBatch.gasLimit+propGasLimit > e.transactionMaxGas {
 			currentBatch = &Batch{
 				proposals: make([]*transfer.TransferProposal, 0),
 				gasLimit:  0,
@@ -226,12 +269,56 @@ func (e *Executor) proposalBatches(proposals []*proposal.Proposal) ([]*Batch, er
 			batches = append(batches, currentBatch)
 		}
 
+		currentBatch.gasLimit += propGasLimit
 		currentBatch.proposals = append(currentBatch.proposals, transferProposal)
 	}
 
+	for _, batch := range batches {
+		e.simulateBatch(batch)
+	}
 	return batches, nil
 }
 
+// proposalGas returns the configured gas of the proposal. Batches are composed from
+// the configured gas so every relayer derives the same batches and signing sessions.
+func (e *Executor) proposalGas(p *transfer.TransferProposal) uint64 {
+	l, ok := p.Data.Metadata["gasLimit"]
+	if ok {
+		return l.(uint64) + e.transferGasCost
+	}
+	return e.transferGasCost
+}
+
+// simulateBatch simulates batch proposals as a local pre-check and sizes the
+// execution gas limit from the estimates. Simulation depends on the RPC state of the
+// relayer, so it never changes batch contents that every relayer has to sign. Proposals
+// that revert or fail to simulate are logged and use their configured gas.
+// The gas limit is capped at the maximum transaction gas.
+func (e *Executor) simulateBatch(batch *Batch) {
+	gasLimit := uint64(0)
+	for _, prop := range batch.proposals {
+		gas, err := e.bridge.SimulateProposal(prop)
+		var revertErr *bridge.RevertError
+		switch {
+		case errors.As(err, &revertErr):
+			log.Warn().Str("messageID", prop.MessageID).Msgf("Proposal %p reverts in simulation, using configured gas: %s", prop, err)
+			gas = e.proposalGas(prop)
+		case err != nil:
+			log.Warn().Err(err).Str("messageID", prop.MessageID).Msgf("Failed simulating proposal %p, using configured gas", prop)
+			gas = e.proposalGas(prop)
+		default:
+			gas += ProposalOverheadGas
+		}
+
+		gasLimit += gas
+	}
+
+	if gasLimit > e.transactionMaxGas {
+		gasLimit = e.transactionMaxGas
+	}
+	batch.gasLimit = gasLimit
+}
+
 func (e *Executor) executeBatch(batch *Batch, signatureData *common.SignatureData) (*ethCommon.Hash, error) {
 	sig := []byte{}
 	sig = append(sig[:], ethCommon.LeftPadBytes(signatureData.R, 32)...)
@@ -250,12 +337,42 @@ func (e *Executor) executeBatch(batch *Batch, signatureData *common.SignatureDat
 }
 
 func (e *Executor) areProposalsExecuted(proposals []*transfer.TransferProposal) bool {
-	for _, prop := range proposals {
-		isExecuted, err := e.bridge.IsProposalExecuted(prop)
-		if err != nil || !isExecuted {
+	executed, err := e.bridge.AreProposalsExecuted(proposals)
+	if err != nil {
+		return false
+	}
+
+	for _, isExecuted := range executed {
+		if !isExecuted {
 			return false
 		}
 	}
-
 	return true
 }
+
+func (e *Executor) storeProposalsAttempt(proposals []*transfer.TransferProposal, messageID string, sessionID string) {
+	for _, prop := range proposals {
+		err := e.propStorer.StorePropAttempt(prop.Source, prop.Destination, prop.Data.DepositNonce, messageID, sessionID)
+		if err != nil {
+			log.Err(err).Str("messageID", messageID).Msgf("Failed storing proposal %+v attempt", prop)
+		}
+	}
+}
+
+func (e *Executor) storeProposalsExecution(proposals []*transfer.TransferProposal, txHash string) {
+	for _, prop := range proposals {
+		err := e.propStorer.StorePropExecution(prop.Source, prop.Destination, prop.Data.DepositNonce, txHash)
+		if err != nil {
+			log.Err(err).Str("messageID", prop.MessageID).Msgf("Failed storing proposal %+v execution", prop)
+		}
+	}
+}
+
+func (e *Executor) storeProposalsFailure(proposals []*transfer.TransferProposal, failure error) {
+	for _, prop := range proposals {
+		err := e.propStorer.StorePropFailure(prop.Source, prop.Destination, prop.Data.DepositNonce, failure)
+		if err != nil {
+			log.Err(err).Str("messageID", prop.MessageID).Msgf("Failed storing proposal %+v failure", prop)
+		}
+	}
+}
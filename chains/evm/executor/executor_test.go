@@ -0,0 +1,172 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package executor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/contracts/bridge"
+	mock_executor "github.com/ChainSafe/sygma-relayer/chains/evm/executor/mock"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+)
+
+type ProposalBatchesTestSuite struct {
+	suite.Suite
+	mockBridge     *mock_executor.MockBridgeContract
+	mockLimiter    *mock_executor.MockVolumeLimiter
+	mockPropStorer *mock_executor.MockPropStorer
+	executor       *Executor
+}
+
+func TestRunProposalBatchesTestSuite(t *testing.T) {
+	suite.Run(t, new(ProposalBatchesTestSuite))
+}
+
+func (s *ProposalBatchesTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockBridge = mock_executor.NewMockBridgeContract(ctrl)
+	s.mockLimiter = mock_executor.NewMockVolumeLimiter(ctrl)
+	s.mockLimiter.EXPECT().Allow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	s.mockPropStorer = mock_executor.NewMockPropStorer(ctrl)
+	s.executor = &Executor{
+		propStorer:        s.mockPropStorer,
+		bridge:            s.mockBridge,
+		limiter:           s.mockLimiter,
+		transactionMaxGas: 300000,
+		transferGasCost:   100000,
+	}
+}
+
+func (s *ProposalBatchesTestSuite) proposal(nonce uint64) *proposal.Proposal {
+	return &proposal.Proposal{
+		Source:      1,
+		Destination: 2,
+		Data: transfer.TransferProposalData{
+			DepositNonce: nonce,
+			ResourceId:   [32]byte{1},
+			Data:         []byte{},
+			Type:         transfer.FungibleTransfer,
+		},
+		Type:      transfer.TransferProposalType,
+		MessageID: "messageID",
+	}
+}
+
+func (s *ProposalBatchesTestSuite) Test_SimulationFailureUsesConfiguredGas() {
+	s.mockBridge.EXPECT().AreProposalsExecuted(gomock.Any()).Return([]bool{false}, nil)
+	s.mockBridge.EXPECT().SimulateProposal(gomock.Any()).Return(uint64(0), errors.New("connection refused"))
+
+	batches, err := s.executor.proposalBatches([]*proposal.Proposal{s.proposal(1)})
+
+	s.Nil(err)
+	s.Equal(len(batches), 1)
+	s.Equal(len(batches[0].proposals), 1)
+	s.Equal(batches[0].gasLimit, uint64(100000))
+}
+
+func (s *ProposalBatchesTestSuite) Test_RevertingProposalKept() {
+	s.mockBridge.EXPECT().AreProposalsExecuted(gomock.Any()).Return([]bool{false, false}, nil)
+	s.mockBridge.EXPECT().SimulateProposal(gomock.Any()).DoAndReturn(func(p *transfer.TransferProposal) (uint64, error) {
+		if p.Data.DepositNonce == 1 {
+			return 0, &bridge.RevertError{Reason: "call to non-contract"}
+		}
+		return uint64(100000), nil
+	}).Times(2)
+
+	batches, err := s.executor.proposalBatches([]*proposal.Proposal{s.proposal(1), s.proposal(2)})
+
+	s.Nil(err)
+	s.Equal(len(batches), 1)
+	s.Equal(len(batches[0].proposals), 2)
+	s.Equal(batches[0].gasLimit, 100000+100000+ProposalOverheadGas)
+}
+
+func (s *ProposalBatchesTestSuite) Test_EstimatedGasCappedAtMaxGas() {
+	s.mockBridge.EXPECT().AreProposalsExecuted(gomock.Any()).Return([]bool{false, false}, nil)
+	s.mockBridge.EXPECT().SimulateProposal(gomock.Any()).Return(uint64(140000), nil).Times(2)
+	s.executor.transferGasCost = 50000
+
+	batches, err := s.executor.proposalBatches([]*proposal.Proposal{s.proposal(1), s.proposal(2)})
+
+	s.Nil(err)
+	s.Equal(len(batches), 1)
+	s.Equal(len(batches[0].proposals), 2)
+	s.Equal(batches[0].gasLimit, uint64(300000))
+}
+
+func (s *ProposalBatchesTestSuite) Test_BatchesSplitByConfiguredGas() {
+	s.executor.transactionMaxGas = 250000
+	s.mockBridge.EXPECT().AreProposalsExecuted(gomock.Any()).Return([]bool{false, false, false}, nil)
+	s.mockBridge.EXPECT().SimulateProposal(gomock.Any()).Return(uint64(10000), nil).Times(3)
+
+	batches, err := s.executor.proposalBatches([]*proposal.Proposal{s.proposal(1), s.proposal(2), s.proposal(3)})
+
+	s.Nil(err)
+	s.Equal(len(batches), 2)
+	s.Equal(len(batches[0].proposals), 2)
+	s.Equal(batches[0].gasLimit, 2*(10000+ProposalOverheadGas))
+	s.Equal(len(batches[1].proposals), 1)
+	s.Equal(batches[1].gasLimit, 10000+ProposalOverheadGas)
+}
+
+func (s *ProposalBatchesTestSuite) Test_BatchesSplitByMetadataGas() {
+	s.mockBridge.EXPECT().AreProposalsExecuted(gomock.Any()).Return([]bool{false, false}, nil)
+	s.mockBridge.EXPECT().SimulateProposal(gomock.Any()).Return(uint64(10000), nil).Times(2)
+	genericProposal := s.proposal(1)
+	data := genericProposal.Data.(transfer.TransferProposalData)
+	data.Metadata = map[string]interface{}{"gasLimit": uint64(150000)}
+	genericProposal.Data = data
+
+	batches, err := s.executor.proposalBatches([]*proposal.Proposal{genericProposal, s.proposal(2)})
+
+	s.Nil(err)
+	s.Equal(len(batches), 2)
+	s.Equal(batches[0].proposals[0].Data.DepositNonce, uint64(1))
+	s.Equal(batches[1].proposals[0].Data.DepositNonce, uint64(2))
+}
+
+func (s *ProposalBatchesTestSuite) Test_ExecutedProposalsSkipped() {
+	s.mockBridge.EXPECT().AreProposalsExecuted(gomock.Any()).DoAndReturn(func(proposals []*transfer.TransferProposal) ([]bool, error) {
+		s.Equal(len(proposals), 2)
+		return []bool{true, false}, nil
+	})
+	s.mockBridge.EXPECT().SimulateProposal(gomock.Any()).Return(uint64(100000), nil)
+
+	batches, err := s.executor.proposalBatches([]*proposal.Proposal{s.proposal(1), s.proposal(2)})
+
+	s.Nil(err)
+	s.Equal(len(batches), 1)
+	s.Equal(len(batches[0].proposals), 1)
+	s.Equal(batches[0].proposals[0].Data.DepositNonce, uint64(2))
+}
+
+func (s *ProposalBatchesTestSuite) Test_OnlyFungibleProposalsLimited() {
+	s.mockLimiter = mock_executor.NewMockVolumeLimiter(gomock.NewController(s.T()))
+	s.executor.limiter = s.mockLimiter
+	s.mockLimiter.EXPECT().Allow(uint8(1), uint8(2), uint64(2), [32]byte{1}, gomock.Any()).Return(nil)
+	s.mockBridge.EXPECT().AreProposalsExecuted(gomock.Any()).Return([]bool{true, false, false}, nil)
+	s.mockBridge.EXPECT().SimulateProposal(gomock.Any()).Return(uint64(10000), nil).Times(2)
+	nonFungibleProposal := s.proposal(3)
+	data := nonFungibleProposal.Data.(transfer.TransferProposalData)
+	data.Type = transfer.NonFungibleTransfer
+	nonFungibleProposal.Data = data
+
+	batches, err := s.executor.proposalBatches([]*proposal.Proposal{s.proposal(1), s.proposal(2), nonFungibleProposal})
+
+	s.Nil(err)
+	s.Equal(len(batches), 1)
+	s.Equal(len(batches[0].proposals), 2)
+}
+
+func (s *ProposalBatchesTestSuite) Test_AreProposalsExecuted_SingleStatusRead() {
+	s.mockBridge.EXPECT().AreProposalsExecuted(gomock.Any()).Return([]bool{true, true, false}, nil)
+
+	isExecuted := s.executor.areProposalsExecuted([]*transfer.TransferProposal{{}, {}, {}})
+
+	s.False(isExecuted)
+}
@@ -9,20 +9,57 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
 
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
 
+	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/events"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/depositHandlers"
 	"github.com/ChainSafe/sygma-relayer/store"
 
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 )
 
-type TransferMessageHandler struct{}
+type Screener interface {
+	Screen(m *message.Message, format screening.Format, address string) bool
+}
+
+// TransferHandlerFunc converts a transfer message of a transfer type into a proposal
+type TransferHandlerFunc func(msg *transfer.TransferMessage) (*proposal.Proposal, error)
+
+type TransferMessageHandler struct {
+	screener Screener
+	handlers map[transfer.TransferType]TransferHandlerFunc
+}
+
+// NewTransferMessageHandler creates a TransferMessageHandler that handles built-in transfer types.
+// Handlers of other transfer types are added with RegisterTransferHandler.
+func NewTransferMessageHandler(screener Screener) *TransferMessageHandler {
+	return &TransferMessageHandler{
+		screener: screener,
+		handlers: map[transfer.TransferType]TransferHandlerFunc{
+			transfer.FungibleTransfer:              ERC20MessageHandler,
+			transfer.SemiFungibleTransfer:          ERC1155MessageHandler,
+			transfer.NonFungibleTransfer:           ERC721MessageHandler,
+			transfer.PermissionedGenericTransfer:   GenericMessageHandler,
+			transfer.PermissionlessGenericTransfer: PermissionlessGenericMessageHandler,
+		},
+	}
+}
 
+// RegisterTransferHandler registers the handler that converts messages of the transfer type into proposals
+func (h *TransferMessageHandler) RegisterTransferHandler(transferType transfer.TransferType, handler TransferHandlerFunc) {
+	log.Debug().Msgf("Registered transfer handler for transfer type %s", transferType)
+	h.handlers[transferType] = handler
+}
+
+// HandleMessage converts the transfer message into a proposal.
+// Transfers to screened recipients are quarantined and no proposal is returned.
 func (h *TransferMessageHandler) HandleMessage(msg *message.Message) (*proposal.Proposal, error) {
 	transferMessage := &transfer.TransferMessage{
 		Source:      msg.Source,
@@ -31,20 +68,48 @@ func (h *TransferMessageHandler) HandleMessage(msg *message.Message) (*proposal.
 		Type:        msg.Type,
 		ID:          msg.ID,
 	}
+	recipient, ok := transferRecipient(transferMessage.Data)
+	if ok && h.screener.Screen(msg, screening.EVMFormat, recipient) {
+		return nil, nil
+	}
+
+	handler, ok := h.handlers[transferMessage.Data.Type]
+	if !ok {
+		return nil, errors.New("wrong message type passed while handling message")
+	}
+	prop, err := handler(transferMessage)
+	if err != nil {
+		return nil, err
+	}
 
-	switch transferMessage.Data.Type {
-	case transfer.FungibleTransfer:
-		return ERC20MessageHandler(transferMessage)
+	data, ok := prop.Data.(transfer.TransferProposalData)
+	if ok {
+		data.Type = transferMessage.Data.Type
+		prop.Data = data
+	}
+	return prop, nil
+}
+
+// transferRecipient returns the recipient of token transfers. Generic transfers have no recipient.
+func transferRecipient(data transfer.TransferMessageData) (string, bool) {
+	var recipientIndex int
+	switch data.Type {
+	case transfer.FungibleTransfer, transfer.NonFungibleTransfer:
+		recipientIndex = 1
 	case transfer.SemiFungibleTransfer:
-		return ERC1155MessageHandler(transferMessage)
-	case transfer.NonFungibleTransfer:
-		return ERC721MessageHandler(transferMessage)
-	case transfer.PermissionedGenericTransfer:
-		return GenericMessageHandler(transferMessage)
-	case transfer.PermissionlessGenericTransfer:
-		return PermissionlessGenericMessageHandler(transferMessage)
-	}
-	return nil, errors.New("wrong message type passed while handling message")
+		recipientIndex = 2
+	default:
+		return "", false
+	}
+	if len(data.Payload) <= recipientIndex {
+		return "", false
+	}
+
+	recipient, ok := data.Payload[recipientIndex].([]byte)
+	if !ok || len(recipient) != common.AddressLength {
+		return "", false
+	}
+	return common.BytesToAddress(recipient).Hex(), true
 }
 
 func PermissionlessGenericMessageHandler(msg *transfer.TransferMessage) (*proposal.Proposal, error) {
@@ -225,37 +290,58 @@ type BlockFetcher interface {
 type PropStorer interface {
 	StorePropStatus(source, destination uint8, depositNonce uint64, status store.PropStatus) error
 	PropStatus(source, destination uint8, depositNonce uint64) (store.PropStatus, error)
+	StorePropAttempt(source, destination uint8, depositNonce uint64, messageID string, sessionID string) error
+	StorePropExecution(source, destination uint8, depositNonce uint64, txHash string) error
+	StorePropFailure(source, destination uint8, depositNonce uint64, failure error) error
 }
 
 type DepositProcessor interface {
 	ProcessDeposits(startBlock *big.Int, endBlock *big.Int) (map[uint8][]*message.Message, error)
 }
 
+type MessageQueue interface {
+	Enqueue(block *big.Int, domainMessages map[uint8][]*message.Message) error
+}
+
+type RetryDepositFetcher interface {
+	FetchRetryDepositEvents(event events.RetryV1Event, bridgeAddress common.Address, blockConfirmations *big.Int) ([]events.Deposit, error)
+}
+
 type RetryMessageHandler struct {
 	depositProcessor   DepositProcessor
+	depositFetcher     RetryDepositFetcher
+	bridgeAddress      common.Address
 	blockConfirmations *big.Int
 	blockFetcher       BlockFetcher
 	propStorer         PropStorer
-	msgChan            chan []*message.Message
+	msgQueue           MessageQueue
 }
 
 func NewRetryMessageHandler(
 	depositProcessor DepositProcessor,
+	depositFetcher RetryDepositFetcher,
+	bridgeAddress common.Address,
 	blockFetcher BlockFetcher,
 	propStorer PropStorer,
 	blockConfirmations *big.Int,
-	msgChan chan []*message.Message) *RetryMessageHandler {
+	msgQueue MessageQueue) *RetryMessageHandler {
 	return &RetryMessageHandler{
 		depositProcessor:   depositProcessor,
+		depositFetcher:     depositFetcher,
+		bridgeAddress:      bridgeAddress,
 		blockFetcher:       blockFetcher,
 		propStorer:         propStorer,
 		blockConfirmations: blockConfirmations,
-		msgChan:            msgChan,
+		msgQueue:           msgQueue,
 	}
 }
 
 func (h *RetryMessageHandler) HandleMessage(msg *message.Message) (*proposal.Proposal, error) {
 	retryData := msg.Data.(retry.RetryMessageData)
+	if retryData.TxHash != "" {
+		return nil, h.retryTransaction(msg, retryData.TxHash)
+	}
+
 	latestBlock, err := h.blockFetcher.LatestBlock()
 	if err != nil {
 		return nil, err
@@ -280,6 +366,90 @@ func (h *RetryMessageHandler) HandleMessage(msg *message.Message) (*proposal.Pro
 		return nil, nil
 	}
 
-	h.msgChan <- filteredDeposits
-	return nil, nil
+	retry.MarkRetried(filteredDeposits, msg.ID)
+	return nil, h.msgQueue.Enqueue(retryData.BlockHeight, map[uint8][]*message.Message{
+		retryData.DestinationDomainID: filteredDeposits,
+	})
+}
+
+// retryTransaction retries deposits made in the transaction that are confirmed and not yet executed
+func (h *RetryMessageHandler) retryTransaction(msg *message.Message, txHash string) error {
+	txDeposits, err := h.depositFetcher.FetchRetryDepositEvents(events.RetryV1Event{TxHash: txHash}, h.bridgeAddress, h.blockConfirmations)
+	if err != nil {
+		return err
+	}
+	if len(txDeposits) == 0 {
+		return fmt.Errorf("no deposits found in transaction %s", txHash)
+	}
+
+	blockHeight := new(big.Int).SetUint64(txDeposits[0].BlockNumber)
+	domainDeposits, err := h.depositProcessor.ProcessDeposits(blockHeight, blockHeight)
+	if err != nil {
+		return err
+	}
+
+	retriedDeposits := make(map[uint8][]*message.Message)
+	for _, txDeposit := range txDeposits {
+		deposits := make([]*message.Message, 0)
+		for _, deposit := range domainDeposits[txDeposit.DestinationDomainID] {
+			if deposit.Data.(transfer.TransferMessageData).DepositNonce == txDeposit.DepositNonce {
+				deposits = append(deposits, deposit)
+			}
+		}
+
+		filteredDeposits, err := retry.FilterDeposits(
+			h.propStorer,
+			map[uint8][]*message.Message{txDeposit.DestinationDomainID: deposits},
+			txDeposit.ResourceID,
+			txDeposit.DestinationDomainID)
+		if err != nil {
+			return err
+		}
+		retriedDeposits[txDeposit.DestinationDomainID] = append(retriedDeposits[txDeposit.DestinationDomainID], filteredDeposits...)
+	}
+
+	for _, deposits := range retriedDeposits {
+		retry.MarkRetried(deposits, msg.ID)
+	}
+	return h.msgQueue.Enqueue(blockHeight, retriedDeposits)
+}
+
+// ABIMessageHandler returns a handler that encodes deposit fields of declarative transfers
+// as proposal data. Deposit calldata is decoded with the deposit arguments and each proposal
+// argument is filled with the deposit field of the same name.
+func ABIMessageHandler(depositData abi.Arguments, proposalData abi.Arguments) TransferHandlerFunc {
+	return func(msg *transfer.TransferMessage) (*proposal.Proposal, error) {
+		if len(msg.Data.Payload) != 1 {
+			return nil, errors.New("malformed payload. Len  of payload should be 1")
+		}
+		calldata, ok := msg.Data.Payload[0].([]byte)
+		if !ok {
+			return nil, errors.New("wrong payload calldata format")
+		}
+		fields := make(map[string]interface{})
+		err := depositData.UnpackIntoMap(fields, calldata)
+		if err != nil {
+			return nil, fmt.Errorf("invalid calldata: %w", err)
+		}
+
+		values := make([]interface{}, len(proposalData))
+		for i, arg := range proposalData {
+			value, ok := fields[arg.Name]
+			if !ok {
+				return nil, fmt.Errorf("deposit field %s missing", arg.Name)
+			}
+			values[i] = value
+		}
+		data, err := proposalData.Pack(values...)
+		if err != nil {
+			return nil, err
+		}
+
+		return proposal.NewProposal(msg.Source, msg.Destination, transfer.TransferProposalData{
+			DepositNonce: msg.Data.DepositNonce,
+			ResourceId:   msg.Data.ResourceId,
+			Metadata:     msg.Data.Metadata,
+			Data:         data,
+		}, msg.ID, transfer.TransferProposalType), nil
+	}
 }
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"math/big"
+	"sort"
 	"testing"
 
 	mock_executor "github.com/ChainSafe/sygma-relayer/chains/evm/executor/mock"
@@ -17,13 +18,18 @@ import (
 
 	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/events"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/executor"
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
 	"github.com/ChainSafe/sygma-relayer/e2e/evm"
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/suite"
 )
 
+// unscreened is a screener without a screening list that flags no address
+var unscreened, _ = screening.NewScreener(relayer.ScreeningConfig{}, nil, nil)
+
 var errIncorrectERC20PayloadLen = errors.New("wrong payload length 1")
 var errIncorrectERC721PayloadLen = errors.New("malformed payload. Len  of payload should be 3")
 var errIncorrectGenericPayloadLen = errors.New("malformed payload. Len  of payload should be 1")
@@ -66,13 +72,39 @@ func (s *ERC20HandlerTestSuite) TestERC20HandleMessage() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(err)
 	s.NotNil(prop)
 }
 
+func (s *ERC20HandlerTestSuite) TestERC20HandleMessage_ScreenedRecipient() {
+	recipient := []byte{241, 229, 143, 177, 119, 4, 194, 218, 132, 121, 165, 51, 249, 250, 212, 173, 9, 147, 202, 107}
+	message := &message.Message{
+		Source:      1,
+		Destination: 0,
+		Data: transfer.TransferMessageData{
+			DepositNonce: 1,
+			ResourceId:   [32]byte{0},
+			Payload: []interface{}{
+				[]byte{2}, // amount
+				recipient,
+			},
+			Type: transfer.FungibleTransfer,
+		},
+		Type: transfer.TransferMessageType,
+	}
+	screener := mock_executor.NewMockScreener(gomock.NewController(s.T()))
+	screener.EXPECT().Screen(message, screening.EVMFormat, common.BytesToAddress(recipient).Hex()).Return(true)
+
+	mh := executor.NewTransferMessageHandler(screener)
+	prop, err := mh.HandleMessage(message)
+
+	s.Nil(err)
+	s.Nil(prop)
+}
+
 func (s *ERC20HandlerTestSuite) TestERC20HandleMessage_WithOptionalMessage() {
 	amount := []byte{2}
 	recipient := []byte{241, 229, 143, 177, 119, 4, 194, 218, 132, 121, 165, 51, 249, 250, 212, 173, 9, 147, 202, 107}
@@ -99,7 +131,7 @@ func (s *ERC20HandlerTestSuite) TestERC20HandleMessage_WithOptionalMessage() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(err)
@@ -122,7 +154,7 @@ func (s *ERC20HandlerTestSuite) TestERC20HandleMessageIncorrectDataLen() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -147,7 +179,7 @@ func (s *ERC20HandlerTestSuite) TestERC20HandleMessageIncorrectAmount() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -171,7 +203,7 @@ func (s *ERC20HandlerTestSuite) TestERC20HandleMessageIncorrectRecipient() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -211,7 +243,7 @@ func (s *ERC721HandlerTestSuite) TestERC721MessageHandlerEmptyMetadata() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(err)
@@ -233,7 +265,7 @@ func (s *ERC721HandlerTestSuite) TestERC721MessageHandlerIncorrectDataLen() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -259,7 +291,7 @@ func (s *ERC721HandlerTestSuite) TestERC721MessageHandlerIncorrectAmount() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -285,7 +317,7 @@ func (s *ERC721HandlerTestSuite) TestERC721MessageHandlerIncorrectRecipient() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -311,7 +343,7 @@ func (s *ERC721HandlerTestSuite) TestERC721MessageHandlerIncorrectMetadata() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -347,7 +379,7 @@ func (s *GenericHandlerTestSuite) TestGenericHandleEvent() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(err)
@@ -367,7 +399,7 @@ func (s *GenericHandlerTestSuite) TestGenericHandleEventIncorrectDataLen() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -389,7 +421,7 @@ func (s *GenericHandlerTestSuite) TestGenericHandleEventIncorrectMetadata() {
 		},
 		Type: transfer.TransferMessageType,
 	}
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -449,7 +481,7 @@ func (s *PermissionlessGenericHandlerTestSuite) Test_HandleMessage() {
 		ID:   "messageID",
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	expectedData, _ := hex.DecodeString("0000000000000000000000000000000000000000000000000000000000030d4000001402091eeff969b33a5ce8a729dae325879bf76f90145c1f5961696bad2e73f73417f07ef55c62a2dc5b307868617368")
@@ -461,6 +493,7 @@ func (s *PermissionlessGenericHandlerTestSuite) Test_HandleMessage() {
 			ResourceId:   message.Data.(transfer.TransferMessageData).ResourceId,
 			Metadata:     message.Data.(transfer.TransferMessageData).Metadata,
 			Data:         expectedData,
+			Type:         transfer.PermissionlessGenericTransfer,
 		},
 		"messageID",
 		transfer.TransferProposalType,
@@ -505,7 +538,7 @@ func (s *Erc1155HandlerTestSuite) Test_HandleErc1155Message() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(err)
@@ -533,7 +566,7 @@ func (s *Erc1155HandlerTestSuite) Test_HandleErc1155Message_InvalidPayloadLen()
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -560,7 +593,7 @@ func (s *Erc1155HandlerTestSuite) Test_HandleErc1155Message_InvalidTokenIDs() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -587,7 +620,7 @@ func (s *Erc1155HandlerTestSuite) Test_HandleErc1155Message_InvalidAmounts() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -616,7 +649,7 @@ func (s *Erc1155HandlerTestSuite) Test_HandleErc1155Message_InvalidRecipient() {
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -645,7 +678,7 @@ func (s *Erc1155HandlerTestSuite) Test_HandleErc1155Message_InvalidRecipientLen(
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -674,7 +707,7 @@ func (s *Erc1155HandlerTestSuite) Test_HandleErc1155Message_InvalidTransferData(
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.TransferMessageHandler{}
+	mh := executor.NewTransferMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -687,8 +720,10 @@ type RetryMessageHandlerTestSuite struct {
 	messageHandler       *executor.RetryMessageHandler
 	mockBlockFetcher     *mock_executor.MockBlockFetcher
 	mockDepositProcessor *mock_executor.MockDepositProcessor
+	mockDepositFetcher   *mock_executor.MockRetryDepositFetcher
 	mockPropStorer       *mock_executor.MockPropStorer
 	msgChan              chan []*message.Message
+	mockMessageQueue     *mock_executor.MockMessageQueue
 }
 
 func TestRunRetryMessageHandlerTestSuite(t *testing.T) {
@@ -699,14 +734,29 @@ func (s *RetryMessageHandlerTestSuite) SetupTest() {
 	ctrl := gomock.NewController(s.T())
 	s.mockBlockFetcher = mock_executor.NewMockBlockFetcher(ctrl)
 	s.mockDepositProcessor = mock_executor.NewMockDepositProcessor(ctrl)
+	s.mockDepositFetcher = mock_executor.NewMockRetryDepositFetcher(ctrl)
 	s.mockPropStorer = mock_executor.NewMockPropStorer(ctrl)
 	s.msgChan = make(chan []*message.Message, 1)
+	s.mockMessageQueue = mock_executor.NewMockMessageQueue(ctrl)
+	s.mockMessageQueue.EXPECT().Enqueue(gomock.Any(), gomock.Any()).DoAndReturn(func(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+		domains := make([]int, 0)
+		for domain := range domainMessages {
+			domains = append(domains, int(domain))
+		}
+		sort.Ints(domains)
+		for _, domain := range domains {
+			s.msgChan <- domainMessages[uint8(domain)]
+		}
+		return nil
+	}).AnyTimes()
 	s.messageHandler = executor.NewRetryMessageHandler(
 		s.mockDepositProcessor,
+		s.mockDepositFetcher,
+		common.HexToAddress("0x1"),
 		s.mockBlockFetcher,
 		s.mockPropStorer,
 		big.NewInt(5),
-		s.msgChan)
+		s.mockMessageQueue)
 }
 
 func (s *RetryMessageHandlerTestSuite) Test_HandleMessage_RetryTooNew() {
@@ -814,6 +864,7 @@ func (s *RetryMessageHandlerTestSuite) Test_HandleMessage_ValidDeposits() {
 			ResourceID:          validResource,
 		},
 		Type: transfer.TransferMessageType,
+		ID:   "retry-2-3",
 	}
 
 	prop, err := s.messageHandler.HandleMessage(message)
@@ -823,4 +874,60 @@ func (s *RetryMessageHandlerTestSuite) Test_HandleMessage_ValidDeposits() {
 	msgs := <-s.msgChan
 	s.Equal(msgs[0].Data.(transfer.TransferMessageData).DepositNonce, failedNonce)
 	s.Equal(msgs[0].Destination, validDomain)
+	s.Equal(msgs[0].ID, "retry-2-3")
+	s.True(retry.IsRetry(msgs[0]))
+}
+
+func (s *RetryMessageHandlerTestSuite) Test_HandleMessage_TxRetryFetchingFails() {
+	s.mockDepositFetcher.EXPECT().FetchRetryDepositEvents(events.RetryV1Event{TxHash: "0xab"}, common.HexToAddress("0x1"), big.NewInt(5)).Return(nil, errors.New("too new"))
+
+	message := &message.Message{
+		Source:      1,
+		Destination: 1,
+		Data: retry.RetryMessageData{
+			SourceDomainID: 1,
+			TxHash:         "0xab",
+		},
+		Type: retry.RetryMessageType,
+	}
+
+	prop, err := s.messageHandler.HandleMessage(message)
+
+	s.Nil(prop)
+	s.NotNil(err)
+	s.Equal(len(s.msgChan), 0)
+}
+
+func (s *RetryMessageHandlerTestSuite) Test_HandleMessage_TxRetryValidDeposits() {
+	resourceID := evm.SliceTo32Bytes(common.LeftPadBytes([]byte{3}, 31))
+	s.mockDepositFetcher.EXPECT().FetchRetryDepositEvents(events.RetryV1Event{TxHash: "0xab"}, common.HexToAddress("0x1"), big.NewInt(5)).Return([]events.Deposit{
+		{DestinationDomainID: 2, ResourceID: resourceID, DepositNonce: 2, BlockNumber: 100},
+	}, nil)
+	s.mockDepositProcessor.EXPECT().ProcessDeposits(big.NewInt(100), big.NewInt(100)).Return(map[uint8][]*message.Message{
+		2: {
+			{Source: 1, Destination: 2, Data: transfer.TransferMessageData{DepositNonce: 1, ResourceId: resourceID}},
+			{Source: 1, Destination: 2, Data: transfer.TransferMessageData{DepositNonce: 2, ResourceId: resourceID}},
+		},
+	}, nil)
+	s.mockPropStorer.EXPECT().PropStatus(uint8(1), uint8(2), uint64(2)).Return(store.FailedProp, nil)
+
+	message := &message.Message{
+		Source:      1,
+		Destination: 1,
+		Data: retry.RetryMessageData{
+			SourceDomainID: 1,
+			TxHash:         "0xab",
+		},
+		Type: retry.RetryMessageType,
+		ID:   "retry-admin-1-ab",
+	}
+
+	prop, err := s.messageHandler.HandleMessage(message)
+
+	s.Nil(prop)
+	s.Nil(err)
+	msgs := <-s.msgChan
+	s.Equal(len(msgs), 1)
+	s.Equal(msgs[0].Data.(transfer.TransferMessageData).DepositNonce, uint64(2))
+	s.Equal(msgs[0].ID, "retry-admin-1-ab")
 }
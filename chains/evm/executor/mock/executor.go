@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/executor/executor.go
+
+// Package mock_executor is a generated GoMock package.
+package mock_executor
+
+import (
+	big "math/big"
+	reflect "reflect"
+
+	transfer "github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	tss "github.com/ChainSafe/sygma-relayer/tss"
+	common "github.com/ethereum/go-ethereum/common"
+	gomock "github.com/golang/mock/gomock"
+	transactor "github.com/sygmaprotocol/sygma-core/chains/evm/transactor"
+)
+
+// MockBridgeContract is a mock of BridgeContract interface.
+type MockBridgeContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockBridgeContractMockRecorder
+}
+
+// MockBridgeContractMockRecorder is the mock recorder for MockBridgeContract.
+type MockBridgeContractMockRecorder struct {
+	mock *MockBridgeContract
+}
+
+// NewMockBridgeContract creates a new mock instance.
+func NewMockBridgeContract(ctrl *gomock.Controller) *MockBridgeContract {
+	mock := &MockBridgeContract{ctrl: ctrl}
+	mock.recorder = &MockBridgeContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBridgeContract) EXPECT() *MockBridgeContractMockRecorder {
+	return m.recorder
+}
+
+// AreProposalsExecuted mocks base method.
+func (m *MockBridgeContract) AreProposalsExecuted(proposals []*transfer.TransferProposal) ([]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AreProposalsExecuted", proposals)
+	ret0, _ := ret[0].([]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AreProposalsExecuted indicates an expected call of AreProposalsExecuted.
+func (mr *MockBridgeContractMockRecorder) AreProposalsExecuted(proposals interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AreProposalsExecuted", reflect.TypeOf((*MockBridgeContract)(nil).AreProposalsExecuted), proposals)
+}
+
+// ExecuteProposals mocks base method.
+func (m *MockBridgeContract) ExecuteProposals(proposals []*transfer.TransferProposal, signature []byte, opts transactor.TransactOptions) (*common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteProposals", proposals, signature, opts)
+	ret0, _ := ret[0].(*common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteProposals indicates an expected call of ExecuteProposals.
+func (mr *MockBridgeContractMockRecorder) ExecuteProposals(proposals, signature, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteProposals", reflect.TypeOf((*MockBridgeContract)(nil).ExecuteProposals), proposals, signature, opts)
+}
+
+// ProposalsHash mocks base method.
+func (m *MockBridgeContract) ProposalsHash(proposals []*transfer.TransferProposal) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProposalsHash", proposals)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProposalsHash indicates an expected call of ProposalsHash.
+func (mr *MockBridgeContractMockRecorder) ProposalsHash(proposals interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposalsHash", reflect.TypeOf((*MockBridgeContract)(nil).ProposalsHash), proposals)
+}
+
+// SimulateProposal mocks base method.
+func (m *MockBridgeContract) SimulateProposal(p *transfer.TransferProposal) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulateProposal", p)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulateProposal indicates an expected call of SimulateProposal.
+func (mr *MockBridgeContractMockRecorder) SimulateProposal(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateProposal", reflect.TypeOf((*MockBridgeContract)(nil).SimulateProposal), p)
+}
+
+// MockVolumeLimiter is a mock of VolumeLimiter interface.
+type MockVolumeLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockVolumeLimiterMockRecorder
+}
+
+// MockVolumeLimiterMockRecorder is the mock recorder for MockVolumeLimiter.
+type MockVolumeLimiterMockRecorder struct {
+	mock *MockVolumeLimiter
+}
+
+// NewMockVolumeLimiter creates a new mock instance.
+func NewMockVolumeLimiter(ctrl *gomock.Controller) *MockVolumeLimiter {
+	mock := &MockVolumeLimiter{ctrl: ctrl}
+	mock.recorder = &MockVolumeLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVolumeLimiter) EXPECT() *MockVolumeLimiterMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockVolumeLimiter) Allow(source, destination uint8, depositNonce uint64, resourceID [32]byte, amount *big.Int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", source, destination, depositNonce, resourceID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockVolumeLimiterMockRecorder) Allow(source, destination, depositNonce, resourceID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockVolumeLimiter)(nil).Allow), source, destination, depositNonce, resourceID, amount)
+}
+
+// MockFundingTracker is a mock of FundingTracker interface.
+type MockFundingTracker struct {
+	ctrl     *gomock.Controller
+	recorder *MockFundingTrackerMockRecorder
+}
+
+// MockFundingTrackerMockRecorder is the mock recorder for MockFundingTracker.
+type MockFundingTrackerMockRecorder struct {
+	mock *MockFundingTracker
+}
+
+// NewMockFundingTracker creates a new mock instance.
+func NewMockFundingTracker(ctrl *gomock.Controller) *MockFundingTracker {
+	mock := &MockFundingTracker{ctrl: ctrl}
+	mock.recorder = &MockFundingTrackerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFundingTracker) EXPECT() *MockFundingTrackerMockRecorder {
+	return m.recorder
+}
+
+// PreferFunded mocks base method.
+func (m *MockFundingTracker) PreferFunded(process tss.TssProcess, domainID uint8) tss.TssProcess {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreferFunded", process, domainID)
+	ret0, _ := ret[0].(tss.TssProcess)
+	return ret0
+}
+
+// PreferFunded indicates an expected call of PreferFunded.
+func (mr *MockFundingTrackerMockRecorder) PreferFunded(process, domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreferFunded", reflect.TypeOf((*MockFundingTracker)(nil).PreferFunded), process, domainID)
+}
@@ -0,0 +1,182 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/executor"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/depositHandlers"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/eventHandlers"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// ABIHandlerType is the handler type whose deposit calldata and proposal data are described with ABI fragments
+const ABIHandlerType = "abi"
+
+// Handlers pair the deposit handler of a handler contract with the transfer handler
+// that converts its transfers into proposals on the destination
+type Handlers struct {
+	TransferType   transfer.TransferType
+	DepositHandler eventHandlers.DepositHandler
+	MessageHandler executor.TransferHandlerFunc
+}
+
+// HandlerFactory creates handlers of a handler type from the handler configuration
+type HandlerFactory func(config evm.HandlerConfig) (*Handlers, error)
+
+type DepositHandlerRegistrar interface {
+	RegisterDepositHandler(handlerAddress string, handler eventHandlers.DepositHandler)
+}
+
+type TransferHandlerRegistrar interface {
+	RegisterTransferHandler(transferType transfer.TransferType, handler executor.TransferHandlerFunc)
+}
+
+// Registry maps handler types from the domain configuration to their handlers
+type Registry struct {
+	factories map[string]HandlerFactory
+}
+
+// NewRegistry creates a registry with the built-in handler types
+func NewRegistry() *Registry {
+	r := &Registry{
+		factories: make(map[string]HandlerFactory),
+	}
+	r.Register("erc20", builtinFactory(transfer.FungibleTransfer, &depositHandlers.Erc20DepositHandler{}, executor.ERC20MessageHandler))
+	r.Register("native", builtinFactory(transfer.FungibleTransfer, &depositHandlers.Erc20DepositHandler{}, executor.ERC20MessageHandler))
+	r.Register("permissionlessGeneric", builtinFactory(transfer.PermissionlessGenericTransfer, &depositHandlers.PermissionlessGenericDepositHandler{}, executor.PermissionlessGenericMessageHandler))
+	r.Register("erc721", builtinFactory(transfer.NonFungibleTransfer, &depositHandlers.Erc721DepositHandler{}, executor.ERC721MessageHandler))
+	r.Register("erc1155", builtinFactory(transfer.SemiFungibleTransfer, &depositHandlers.Erc1155DepositHandler{}, executor.ERC1155MessageHandler))
+	r.Register(ABIHandlerType, NewABIHandlers)
+	return r
+}
+
+// Register adds a handler type to the registry. Registering an existing type replaces it.
+func (r *Registry) Register(handlerType string, factory HandlerFactory) {
+	r.factories[handlerType] = factory
+}
+
+// Setup registers deposit and transfer handlers of every configured handler contract.
+// Unknown handler types and invalid handler configurations return an error.
+func (r *Registry) Setup(
+	configs []evm.HandlerConfig,
+	depositRegistrar DepositHandlerRegistrar,
+	transferRegistrar TransferHandlerRegistrar) error {
+	for _, config := range configs {
+		factory, ok := r.factories[config.Type]
+		if !ok {
+			return fmt.Errorf("unknown type %s of handler %s", config.Type, config.Address)
+		}
+
+		handlers, err := factory(config)
+		if err != nil {
+			return fmt.Errorf("invalid %s handler %s: %w", config.Type, config.Address, err)
+		}
+		depositRegistrar.RegisterDepositHandler(config.Address, handlers.DepositHandler)
+		transferRegistrar.RegisterTransferHandler(handlers.TransferType, handlers.MessageHandler)
+	}
+	return nil
+}
+
+func builtinFactory(transferType transfer.TransferType, depositHandler eventHandlers.DepositHandler, messageHandler executor.TransferHandlerFunc) HandlerFactory {
+	return func(config evm.HandlerConfig) (*Handlers, error) {
+		return &Handlers{
+			TransferType:   transferType,
+			DepositHandler: depositHandler,
+			MessageHandler: messageHandler,
+		}, nil
+	}
+}
+
+// NewABIHandlers creates handlers of a handler contract described with ABI fragments.
+// Deposit calldata is decoded with the deposit arguments and proposal data is encoded
+// with the proposal arguments. The deposit arguments are used if no proposal arguments are configured.
+func NewABIHandlers(config evm.HandlerConfig) (*Handlers, error) {
+	if config.TransferType == "" {
+		return nil, fmt.Errorf("transferType is required")
+	}
+	if isBuiltinTransferType(transfer.TransferType(config.TransferType)) {
+		return nil, fmt.Errorf("transferType %s is reserved", config.TransferType)
+	}
+
+	depositData, err := parseArguments(config.DepositData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid depositData: %w", err)
+	}
+	if len(depositData) == 0 {
+		return nil, fmt.Errorf("depositData is required")
+	}
+	proposalData := depositData
+	if len(config.ProposalData) > 0 {
+		proposalData, err = parseArguments(config.ProposalData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proposalData: %w", err)
+		}
+	}
+
+	for _, arg := range proposalData {
+		depositArg, ok := findArgument(depositData, arg.Name)
+		if !ok {
+			return nil, fmt.Errorf("proposalData argument %s missing from depositData", arg.Name)
+		}
+		if depositArg.Type.String() != arg.Type.String() {
+			return nil, fmt.Errorf("proposalData argument %s has type %s, depositData type is %s", arg.Name, arg.Type, depositArg.Type)
+		}
+	}
+
+	transferType := transfer.TransferType(config.TransferType)
+	return &Handlers{
+		TransferType: transferType,
+		DepositHandler: &depositHandlers.ABIDepositHandler{
+			TransferType: transferType,
+			DepositData:  depositData,
+		},
+		MessageHandler: executor.ABIMessageHandler(depositData, proposalData),
+	}, nil
+}
+
+// parseArguments converts ABI fragments into arguments. Every argument has to be named
+// because values are matched by name.
+func parseArguments(fragments []abi.ArgumentMarshaling) (abi.Arguments, error) {
+	args := make(abi.Arguments, len(fragments))
+	for i, fragment := range fragments {
+		if fragment.Name == "" {
+			return nil, fmt.Errorf("argument %d has no name", i)
+		}
+		if _, ok := findArgument(args[:i], fragment.Name); ok {
+			return nil, fmt.Errorf("duplicate argument %s", fragment.Name)
+		}
+
+		argType, err := abi.NewType(fragment.Type, fragment.InternalType, fragment.Components)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %w", fragment.Name, err)
+		}
+		args[i] = abi.Argument{Name: fragment.Name, Type: argType}
+	}
+	return args, nil
+}
+
+func findArgument(args abi.Arguments, name string) (abi.Argument, bool) {
+	for _, arg := range args {
+		if arg.Name == name {
+			return arg, true
+		}
+	}
+	return abi.Argument{}, false
+}
+
+func isBuiltinTransferType(transferType transfer.TransferType) bool {
+	switch transferType {
+	case transfer.FungibleTransfer,
+		transfer.SemiFungibleTransfer,
+		transfer.NonFungibleTransfer,
+		transfer.PermissionedGenericTransfer,
+		transfer.PermissionlessGenericTransfer:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package handlers_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/executor"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/handlers"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/depositHandlers"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/eventHandlers"
+	"github.com/ChainSafe/sygma-relayer/relayer/queue"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ChainSafe/sygma-relayer/store/lvldb"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+type unscreenedScreener struct{}
+
+func (s unscreenedScreener) Screen(m *message.Message, format screening.Format, address string) bool {
+	return false
+}
+
+type depositRegistrar struct {
+	handlers map[string]eventHandlers.DepositHandler
+}
+
+func (r *depositRegistrar) RegisterDepositHandler(handlerAddress string, handler eventHandlers.DepositHandler) {
+	r.handlers[handlerAddress] = handler
+}
+
+type RegistryTestSuite struct {
+	suite.Suite
+	registry         *handlers.Registry
+	depositRegistrar *depositRegistrar
+	transferHandler  *executor.TransferMessageHandler
+}
+
+func TestRunRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(RegistryTestSuite))
+}
+
+func (s *RegistryTestSuite) SetupTest() {
+	s.registry = handlers.NewRegistry()
+	s.depositRegistrar = &depositRegistrar{handlers: make(map[string]eventHandlers.DepositHandler)}
+	s.transferHandler = executor.NewTransferMessageHandler(unscreenedScreener{})
+}
+
+func (s *RegistryTestSuite) abiHandlerConfig() evm.HandlerConfig {
+	return evm.HandlerConfig{
+		Type:         handlers.ABIHandlerType,
+		Address:      "0x1",
+		TransferType: "custom",
+		DepositData: []abi.ArgumentMarshaling{
+			{Name: "amount", Type: "uint256"},
+			{Name: "recipient", Type: "address"},
+			{Name: "gasLimit", Type: "uint256"},
+		},
+		ProposalData: []abi.ArgumentMarshaling{
+			{Name: "recipient", Type: "address"},
+			{Name: "amount", Type: "uint256"},
+		},
+	}
+}
+
+func (s *RegistryTestSuite) Test_Setup_BuiltinTypes() {
+	err := s.registry.Setup([]evm.HandlerConfig{
+		{Type: "erc20", Address: "0x1"},
+		{Type: "native", Address: "0x2"},
+		{Type: "permissionlessGeneric", Address: "0x3"},
+		{Type: "erc721", Address: "0x4"},
+		{Type: "erc1155", Address: "0x5"},
+	}, s.depositRegistrar, s.transferHandler)
+
+	s.Nil(err)
+	s.Equal(len(s.depositRegistrar.handlers), 5)
+	s.IsType(&depositHandlers.Erc20DepositHandler{}, s.depositRegistrar.handlers["0x2"])
+	s.IsType(&depositHandlers.Erc1155DepositHandler{}, s.depositRegistrar.handlers["0x5"])
+}
+
+func (s *RegistryTestSuite) Test_Setup_UnknownType() {
+	err := s.registry.Setup([]evm.HandlerConfig{
+		{Type: "erc20", Address: "0x1"},
+		{Type: "unknown", Address: "0x2"},
+	}, s.depositRegistrar, s.transferHandler)
+
+	s.NotNil(err)
+	s.Equal(err.Error(), "unknown type unknown of handler 0x2")
+}
+
+func (s *RegistryTestSuite) Test_Setup_CustomType() {
+	s.registry.Register("custom", func(config evm.HandlerConfig) (*handlers.Handlers, error) {
+		return &handlers.Handlers{
+			TransferType:   transfer.FungibleTransfer,
+			DepositHandler: &depositHandlers.Erc20DepositHandler{},
+			MessageHandler: executor.ERC20MessageHandler,
+		}, nil
+	})
+
+	err := s.registry.Setup([]evm.HandlerConfig{{Type: "custom", Address: "0x1"}}, s.depositRegistrar, s.transferHandler)
+
+	s.Nil(err)
+	s.IsType(&depositHandlers.Erc20DepositHandler{}, s.depositRegistrar.handlers["0x1"])
+}
+
+func (s *RegistryTestSuite) Test_Setup_InvalidABIHandler() {
+	config := s.abiHandlerConfig()
+	config.DepositData[0].Type = "uint257"
+
+	err := s.registry.Setup([]evm.HandlerConfig{config}, s.depositRegistrar, s.transferHandler)
+
+	s.NotNil(err)
+}
+
+func (s *RegistryTestSuite) Test_NewABIHandlers_ReservedTransferType() {
+	config := s.abiHandlerConfig()
+	config.TransferType = string(transfer.FungibleTransfer)
+
+	_, err := handlers.NewABIHandlers(config)
+
+	s.NotNil(err)
+	s.Equal(err.Error(), "transferType fungible is reserved")
+}
+
+func (s *RegistryTestSuite) Test_NewABIHandlers_UnnamedArgument() {
+	config := s.abiHandlerConfig()
+	config.DepositData[1].Name = ""
+
+	_, err := handlers.NewABIHandlers(config)
+
+	s.NotNil(err)
+}
+
+func (s *RegistryTestSuite) Test_NewABIHandlers_ProposalArgumentMissing() {
+	config := s.abiHandlerConfig()
+	config.ProposalData = append(config.ProposalData, abi.ArgumentMarshaling{Name: "data", Type: "bytes"})
+
+	_, err := handlers.NewABIHandlers(config)
+
+	s.NotNil(err)
+	s.Equal(err.Error(), "proposalData argument data missing from depositData")
+}
+
+func (s *RegistryTestSuite) Test_NewABIHandlers_ProposalArgumentTypeMismatch() {
+	config := s.abiHandlerConfig()
+	config.ProposalData[1].Type = "uint128"
+
+	_, err := handlers.NewABIHandlers(config)
+
+	s.NotNil(err)
+}
+
+func (s *RegistryTestSuite) Test_ABIHandler_DepositToProposal() {
+	err := s.registry.Setup([]evm.HandlerConfig{s.abiHandlerConfig()}, s.depositRegistrar, s.transferHandler)
+	s.Nil(err)
+
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	addressType, _ := abi.NewType("address", "", nil)
+	recipient := common.HexToAddress("0xf1a4d5a7a1e8ad7ab5b0c7d9f4b9e6c2d3a4b5c6")
+	calldata, _ := abi.Arguments{{Type: uint256Type}, {Type: addressType}, {Type: uint256Type}}.Pack(big.NewInt(100), recipient, big.NewInt(200000))
+
+	msg, err := s.depositRegistrar.handlers["0x1"].HandleDeposit(1, 2, 3, [32]byte{1}, calldata, []byte{}, "messageID", time.Unix(0, 0))
+	s.Nil(err)
+	s.Equal(msg.Data.(transfer.TransferMessageData).Type, transfer.TransferType("custom"))
+	s.Equal(msg.Data.(transfer.TransferMessageData).Metadata["gasLimit"], uint64(200000))
+
+	prop, err := s.transferHandler.HandleMessage(msg)
+	s.Nil(err)
+
+	expectedData, _ := abi.Arguments{{Type: addressType}, {Type: uint256Type}}.Pack(recipient, big.NewInt(100))
+	s.Equal(prop.Data.(transfer.TransferProposalData).Data, expectedData)
+	s.Equal(prop.Data.(transfer.TransferProposalData).DepositNonce, uint64(3))
+}
+
+func (s *RegistryTestSuite) Test_ABIHandler_QueueRoundTrip() {
+	err := s.registry.Setup([]evm.HandlerConfig{s.abiHandlerConfig()}, s.depositRegistrar, s.transferHandler)
+	s.Nil(err)
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	defer db.Close()
+
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	addressType, _ := abi.NewType("address", "", nil)
+	recipient := common.HexToAddress("0xf1a4d5a7a1e8ad7ab5b0c7d9f4b9e6c2d3a4b5c6")
+	calldata, _ := abi.Arguments{{Type: uint256Type}, {Type: addressType}, {Type: uint256Type}}.Pack(big.NewInt(100), recipient, big.NewInt(200000))
+	msg, err := s.depositRegistrar.handlers["0x1"].HandleDeposit(1, 2, 3, [32]byte{1}, calldata, []byte{}, "messageID", time.Unix(0, 0))
+	s.Nil(err)
+
+	err = queue.NewMessageQueue(db, make(chan []*message.Message, 1)).Enqueue(big.NewInt(5), map[uint8][]*message.Message{2: {msg}})
+	s.Nil(err)
+	msgChan := make(chan []*message.Message, 1)
+	err = queue.NewMessageQueue(db, msgChan).Replay()
+	s.Nil(err)
+	replayed := <-msgChan
+
+	prop, err := s.transferHandler.HandleMessage(replayed[0])
+	s.Nil(err)
+	expectedData, _ := abi.Arguments{{Type: addressType}, {Type: uint256Type}}.Pack(recipient, big.NewInt(100))
+	s.Equal(prop.Data.(transfer.TransferProposalData).Data, expectedData)
+	s.Equal(prop.Data.(transfer.TransferProposalData).Metadata["gasLimit"], uint64(200000))
+}
+
+func (s *RegistryTestSuite) Test_ABIHandler_InvalidCalldata() {
+	err := s.registry.Setup([]evm.HandlerConfig{s.abiHandlerConfig()}, s.depositRegistrar, s.transferHandler)
+	s.Nil(err)
+
+	_, err = s.depositRegistrar.handlers["0x1"].HandleDeposit(1, 2, 3, [32]byte{1}, []byte{1, 2}, []byte{}, "messageID", time.Unix(0, 0))
+
+	s.NotNil(err)
+}
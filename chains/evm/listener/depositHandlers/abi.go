@@ -0,0 +1,61 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package depositHandlers
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+// GasLimitField is the deposit field that is used as the execution gas limit of declarative transfers
+const GasLimitField = "gasLimit"
+
+// ABIDepositHandler converts deposits of handlers configured with ABI fragments into messages.
+// Calldata is validated by decoding it with the deposit arguments and is passed to the
+// destination as it is, so messages only carry types the message queue can encode.
+type ABIDepositHandler struct {
+	TransferType transfer.TransferType
+	DepositData  abi.Arguments
+}
+
+func (dh *ABIDepositHandler) HandleDeposit(
+	sourceID,
+	destID uint8,
+	nonce uint64,
+	resourceID [32]byte,
+	calldata,
+	handlerResponse []byte,
+	messageID string,
+	timestamp time.Time) (*message.Message, error) {
+	fields := make(map[string]interface{})
+	err := dh.DepositData.UnpackIntoMap(fields, calldata)
+	if err != nil {
+		return nil, fmt.Errorf("invalid calldata: %w", err)
+	}
+
+	metadata := make(map[string]interface{})
+	if gasLimit, ok := fields[GasLimitField].(*big.Int); ok {
+		metadata["gasLimit"] = gasLimit.Uint64()
+	}
+
+	return message.NewMessage(
+		sourceID,
+		destID,
+		transfer.TransferMessageData{
+			DepositNonce: nonce,
+			ResourceId:   resourceID,
+			Metadata:     metadata,
+			Payload:      []interface{}{calldata},
+			Type:         dh.TransferType,
+		},
+		messageID,
+		transfer.TransferMessageType,
+		timestamp,
+	), nil
+}
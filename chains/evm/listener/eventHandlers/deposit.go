@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/events"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rs/zerolog/log"
@@ -23,25 +25,35 @@ type EventListener interface {
 	FetchRetryDepositEvents(event events.RetryV1Event, bridgeAddress common.Address, blockConfirmations *big.Int) ([]events.Deposit, error)
 }
 
+type MessageQueue interface {
+	Enqueue(block *big.Int, domainMessages map[uint8][]*message.Message) error
+}
+
 type DepositHandler interface {
 	HandleDeposit(sourceID, destID uint8, nonce uint64, resourceID [32]byte, calldata, handlerResponse []byte, messageID string, timestamp time.Time) (*message.Message, error)
 }
 
+type Screener interface {
+	Screen(m *message.Message, format screening.Format, address string) bool
+}
+
 type DepositEventHandler struct {
 	eventListener  EventListener
 	depositHandler DepositHandler
 	bridgeAddress  common.Address
 	domainID       uint8
-	msgChan        chan []*message.Message
+	msgQueue       MessageQueue
+	screener       Screener
 }
 
-func NewDepositEventHandler(eventListener EventListener, depositHandler DepositHandler, bridgeAddress common.Address, domainID uint8, msgChan chan []*message.Message) *DepositEventHandler {
+func NewDepositEventHandler(eventListener EventListener, depositHandler DepositHandler, bridgeAddress common.Address, domainID uint8, msgQueue MessageQueue, screener Screener) *DepositEventHandler {
 	return &DepositEventHandler{
 		eventListener:  eventListener,
 		depositHandler: depositHandler,
 		bridgeAddress:  bridgeAddress,
 		domainID:       domainID,
-		msgChan:        msgChan,
+		msgQueue:       msgQueue,
+		screener:       screener,
 	}
 }
 
@@ -51,13 +63,7 @@ func (eh *DepositEventHandler) HandleEvents(startBlock *big.Int, endBlock *big.I
 		return err
 	}
 
-	for _, deposits := range domainDeposits {
-		go func(d []*message.Message) {
-			eh.msgChan <- d
-		}(deposits)
-	}
-
-	return nil
+	return eh.msgQueue.Enqueue(endBlock, domainDeposits)
 }
 
 func (eh *DepositEventHandler) ProcessDeposits(startBlock *big.Int, endBlock *big.Int) (map[uint8][]*message.Message, error) {
@@ -81,6 +87,11 @@ func (eh *DepositEventHandler) ProcessDeposits(startBlock *big.Int, endBlock *bi
 				log.Error().Err(err).Str("start block", startBlock.String()).Str("end block", endBlock.String()).Uint8("domainID", eh.domainID).Msgf("%v", err)
 				return
 			}
+			transfer.SetDepositBlock(m, d.BlockNumber)
+			transfer.SetDepositor(m, d.SenderAddress.Hex())
+			if eh.screener.Screen(m, screening.EVMFormat, d.SenderAddress.Hex()) {
+				return
+			}
 
 			log.Info().Str("messageID", m.ID).Msgf("Resolved message %+v in block range: %s-%s", m, startBlock.String(), endBlock.String())
 			domainDeposits[m.Destination] = append(domainDeposits[m.Destination], m)
@@ -6,6 +6,7 @@ package eventHandlers_test
 import (
 	"fmt"
 	"math/big"
+	"sort"
 	"testing"
 	"time"
 
@@ -16,10 +17,16 @@ import (
 	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/events"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/eventHandlers"
 	mock_listener "github.com/ChainSafe/sygma-relayer/chains/evm/listener/eventHandlers/mock"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 )
 
+var (
+	depositorMetadata = map[string]interface{}{transfer.DepositorMetadataKey: common.Address{}.Hex()}
+	screenedDepositor = common.HexToAddress("0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b")
+)
+
 type DepositHandlerTestSuite struct {
 	suite.Suite
 	depositEventHandler *eventHandlers.DepositEventHandler
@@ -27,6 +34,8 @@ type DepositHandlerTestSuite struct {
 	mockEventListener   *mock_listener.MockEventListener
 	domainID            uint8
 	msgChan             chan []*message.Message
+	mockMessageQueue    *mock_listener.MockMessageQueue
+	mockScreener        *mock_listener.MockScreener
 }
 
 func TestRunDepositHandlerTestSuite(t *testing.T) {
@@ -39,7 +48,21 @@ func (s *DepositHandlerTestSuite) SetupTest() {
 	s.mockEventListener = mock_listener.NewMockEventListener(ctrl)
 	s.mockDepositHandler = mock_listener.NewMockDepositHandler(ctrl)
 	s.msgChan = make(chan []*message.Message, 2)
-	s.depositEventHandler = eventHandlers.NewDepositEventHandler(s.mockEventListener, s.mockDepositHandler, common.Address{}, s.domainID, s.msgChan)
+	s.mockMessageQueue = mock_listener.NewMockMessageQueue(ctrl)
+	s.mockMessageQueue.EXPECT().Enqueue(gomock.Any(), gomock.Any()).DoAndReturn(func(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+		domains := make([]int, 0)
+		for domain := range domainMessages {
+			domains = append(domains, int(domain))
+		}
+		sort.Ints(domains)
+		for _, domain := range domains {
+			s.msgChan <- domainMessages[uint8(domain)]
+		}
+		return nil
+	}).AnyTimes()
+	s.mockScreener = mock_listener.NewMockScreener(ctrl)
+	s.mockScreener.EXPECT().Screen(gomock.Any(), screening.EVMFormat, gomock.Not(screenedDepositor.Hex())).Return(false).AnyTimes()
+	s.depositEventHandler = eventHandlers.NewDepositEventHandler(s.mockEventListener, s.mockDepositHandler, common.Address{}, s.domainID, s.mockMessageQueue, s.mockScreener)
 }
 
 func (s *DepositHandlerTestSuite) Test_FetchDepositFails() {
@@ -104,7 +127,7 @@ func (s *DepositHandlerTestSuite) Test_HandleDepositFails_ExecutionContinue() {
 	msgs := <-s.msgChan
 
 	s.Nil(err)
-	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 2}}})
+	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 2, Metadata: depositorMetadata}}})
 }
 
 func (s *DepositHandlerTestSuite) Test_HandleDepositPanis_ExecutionContinues() {
@@ -155,7 +178,7 @@ func (s *DepositHandlerTestSuite) Test_HandleDepositPanis_ExecutionContinues() {
 	msgs := <-s.msgChan
 
 	s.Nil(err)
-	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 2}}})
+	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 2, Metadata: depositorMetadata}}})
 }
 
 func (s *DepositHandlerTestSuite) Test_SuccessfulHandleDeposit() {
@@ -165,6 +188,7 @@ func (s *DepositHandlerTestSuite) Test_SuccessfulHandleDeposit() {
 		ResourceID:          [32]byte{},
 		HandlerResponse:     []byte{},
 		Data:                []byte{},
+		BlockNumber:         3,
 	}
 	d2 := &events.Deposit{
 		DepositNonce:        2,
@@ -172,6 +196,7 @@ func (s *DepositHandlerTestSuite) Test_SuccessfulHandleDeposit() {
 		ResourceID:          [32]byte{},
 		HandlerResponse:     []byte{},
 		Data:                []byte{},
+		BlockNumber:         4,
 	}
 	deposits := []*events.Deposit{d1, d2}
 	s.mockEventListener.EXPECT().FetchDeposits(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(deposits, nil)
@@ -207,5 +232,52 @@ func (s *DepositHandlerTestSuite) Test_SuccessfulHandleDeposit() {
 	msgs := <-s.msgChan
 
 	s.Nil(err)
-	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 1}}, {Data: transfer.TransferMessageData{DepositNonce: 2}}})
+	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 1, DepositBlock: 3, Metadata: depositorMetadata}}, {Data: transfer.TransferMessageData{DepositNonce: 2, DepositBlock: 4, Metadata: depositorMetadata}}})
+}
+
+func (s *DepositHandlerTestSuite) Test_ScreenedDepositor_DepositQuarantined() {
+	d1 := &events.Deposit{
+		DepositNonce:        1,
+		DestinationDomainID: 2,
+		ResourceID:          [32]byte{},
+		HandlerResponse:     []byte{},
+		Data:                []byte{},
+		SenderAddress:       screenedDepositor,
+	}
+	d2 := &events.Deposit{
+		DepositNonce:        2,
+		DestinationDomainID: 2,
+		ResourceID:          [32]byte{},
+		HandlerResponse:     []byte{},
+		Data:                []byte{},
+	}
+	deposits := []*events.Deposit{d1, d2}
+	s.mockEventListener.EXPECT().FetchDeposits(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(deposits, nil)
+	s.mockDepositHandler.EXPECT().HandleDeposit(
+		s.domainID,
+		d1.DestinationDomainID,
+		d1.DepositNonce,
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&message.Message{Data: transfer.TransferMessageData{DepositNonce: 1}}, nil)
+	s.mockDepositHandler.EXPECT().HandleDeposit(
+		s.domainID,
+		d2.DestinationDomainID,
+		d2.DepositNonce,
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&message.Message{Data: transfer.TransferMessageData{DepositNonce: 2}}, nil)
+	s.mockScreener.EXPECT().Screen(gomock.Any(), screening.EVMFormat, screenedDepositor.Hex()).Return(true)
+
+	err := s.depositEventHandler.HandleEvents(big.NewInt(0), big.NewInt(5))
+	msgs := <-s.msgChan
+
+	s.Nil(err)
+	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 2, Metadata: depositorMetadata}}})
 }
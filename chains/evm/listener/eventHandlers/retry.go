@@ -10,6 +10,7 @@ import (
 	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/consts"
 	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/events"
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ChainSafe/sygma-relayer/store"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -23,7 +24,7 @@ type RetryV2EventHandler struct {
 	eventListener EventListener
 	retryAddress  common.Address
 	domainID      uint8
-	msgChan       chan []*message.Message
+	msgQueue      MessageQueue
 }
 
 func NewRetryV2EventHandler(
@@ -31,14 +32,14 @@ func NewRetryV2EventHandler(
 	eventListener EventListener,
 	retryAddress common.Address,
 	domainID uint8,
-	msgChan chan []*message.Message,
+	msgQueue MessageQueue,
 ) *RetryV2EventHandler {
 	return &RetryV2EventHandler{
 		log:           logC.Logger(),
 		eventListener: eventListener,
 		retryAddress:  retryAddress,
 		domainID:      domainID,
-		msgChan:       msgChan,
+		msgQueue:      msgQueue,
 	}
 }
 
@@ -51,6 +52,7 @@ func (eh *RetryV2EventHandler) HandleEvents(
 		return fmt.Errorf("unable to fetch retry v2 events because of: %+v", err)
 	}
 
+	retriesByDomain := make(map[uint8][]*message.Message)
 	for _, e := range retryEvents {
 		messageID := fmt.Sprintf("retry-%d-%d", e.SourceDomainID, e.DestinationDomainID)
 		msg := message.NewMessage(
@@ -70,9 +72,9 @@ func (eh *RetryV2EventHandler) HandleEvents(
 		eh.log.Info().Str("messageID", messageID).Msgf(
 			"Resolved retry message %+v in block range: %s-%s", msg, startBlock.String(), endBlock.String(),
 		)
-		go func() { eh.msgChan <- []*message.Message{msg} }()
+		retriesByDomain[msg.Destination] = append(retriesByDomain[msg.Destination], msg)
 	}
-	return nil
+	return eh.msgQueue.Enqueue(endBlock, retriesByDomain)
 }
 
 type PropStorer interface {
@@ -89,7 +91,8 @@ type RetryV1EventHandler struct {
 	bridgeABI          abi.ABI
 	domainID           uint8
 	blockConfirmations *big.Int
-	msgChan            chan []*message.Message
+	msgQueue           MessageQueue
+	screener           Screener
 }
 
 func NewRetryV1EventHandler(
@@ -100,7 +103,8 @@ func NewRetryV1EventHandler(
 	bridgeAddress common.Address,
 	domainID uint8,
 	blockConfirmations *big.Int,
-	msgChan chan []*message.Message,
+	msgQueue MessageQueue,
+	screener Screener,
 ) *RetryV1EventHandler {
 	bridgeABI, _ := abi.JSON(strings.NewReader(consts.BridgeABI))
 	return &RetryV1EventHandler{
@@ -112,7 +116,8 @@ func NewRetryV1EventHandler(
 		bridgeABI:          bridgeABI,
 		domainID:           domainID,
 		blockConfirmations: blockConfirmations,
-		msgChan:            msgChan,
+		msgQueue:           msgQueue,
+		screener:           screener,
 	}
 }
 
@@ -150,6 +155,11 @@ func (eh *RetryV1EventHandler) HandleEvents(
 					eh.log.Err(err).Str("messageID", msg.ID).Msgf("Failed handling deposit %+v", d)
 					continue
 				}
+				transfer.SetDepositBlock(msg, d.BlockNumber)
+				transfer.SetDepositor(msg, d.SenderAddress.Hex())
+				if eh.screener.Screen(msg, screening.EVMFormat, d.SenderAddress.Hex()) {
+					continue
+				}
 				isExecuted, err := eh.isExecuted(msg)
 				if err != nil {
 					eh.log.Err(err).Str("messageID", msg.ID).Msgf("Failed checking if deposit executed %+v", d)
@@ -168,11 +178,7 @@ func (eh *RetryV1EventHandler) HandleEvents(
 		}(event)
 	}
 
-	for _, retries := range retriesByDomain {
-		eh.msgChan <- retries
-	}
-
-	return nil
+	return eh.msgQueue.Enqueue(endBlock, retriesByDomain)
 }
 
 func (eh *RetryV1EventHandler) isExecuted(msg *message.Message) (bool, error) {
@@ -6,6 +6,7 @@ package eventHandlers_test
 import (
 	"fmt"
 	"math/big"
+	"sort"
 	"testing"
 
 	"github.com/rs/zerolog/log"
@@ -18,6 +19,7 @@ import (
 	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/eventHandlers"
 	mock_listener "github.com/ChainSafe/sygma-relayer/chains/evm/listener/eventHandlers/mock"
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ChainSafe/sygma-relayer/store"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
@@ -29,6 +31,7 @@ type RetryV2EventHandlerTestSuite struct {
 	mockEventListener *mock_listener.MockEventListener
 	domainID          uint8
 	msgChan           chan []*message.Message
+	mockMessageQueue  *mock_listener.MockMessageQueue
 }
 
 func TestRunRetryEventHandlerTestSuite(t *testing.T) {
@@ -39,13 +42,25 @@ func (s *RetryV2EventHandlerTestSuite) SetupTest() {
 	ctrl := gomock.NewController(s.T())
 	s.domainID = 1
 	s.mockEventListener = mock_listener.NewMockEventListener(ctrl)
-	s.msgChan = make(chan []*message.Message, 1)
+	s.msgChan = make(chan []*message.Message, 2)
+	s.mockMessageQueue = mock_listener.NewMockMessageQueue(ctrl)
+	s.mockMessageQueue.EXPECT().Enqueue(gomock.Any(), gomock.Any()).DoAndReturn(func(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+		domains := make([]int, 0)
+		for domain := range domainMessages {
+			domains = append(domains, int(domain))
+		}
+		sort.Ints(domains)
+		for _, domain := range domains {
+			s.msgChan <- domainMessages[uint8(domain)]
+		}
+		return nil
+	}).AnyTimes()
 	s.retryEventHandler = eventHandlers.NewRetryV2EventHandler(
 		log.With(),
 		s.mockEventListener,
 		common.Address{},
 		s.domainID,
-		s.msgChan)
+		s.mockMessageQueue)
 }
 
 func (s *RetryV2EventHandlerTestSuite) Test_FetchRetryEventsFails() {
@@ -66,8 +81,8 @@ func (s *RetryV2EventHandlerTestSuite) Test_FetchRetryEvents_ValidRetry() {
 	}, nil)
 
 	err := s.retryEventHandler.HandleEvents(big.NewInt(0), big.NewInt(5))
-	msgs2 := <-s.msgChan
 	msgs1 := <-s.msgChan
+	msgs2 := <-s.msgChan
 
 	s.Nil(err)
 	s.Equal(msgs1[0].Data, retry.RetryMessageData{
@@ -91,6 +106,8 @@ type RetryV1EventHandlerTestSuite struct {
 	mockEventListener  *mock_listener.MockEventListener
 	domainID           uint8
 	msgChan            chan []*message.Message
+	mockMessageQueue   *mock_listener.MockMessageQueue
+	mockScreener       *mock_listener.MockScreener
 }
 
 func TestRunRetryV1EventHandlerTestSuite(t *testing.T) {
@@ -104,6 +121,20 @@ func (s *RetryV1EventHandlerTestSuite) SetupTest() {
 	s.mockDepositHandler = mock_listener.NewMockDepositHandler(ctrl)
 	s.mockPropStorer = mock_listener.NewMockPropStorer(ctrl)
 	s.msgChan = make(chan []*message.Message, 1)
+	s.mockMessageQueue = mock_listener.NewMockMessageQueue(ctrl)
+	s.mockMessageQueue.EXPECT().Enqueue(gomock.Any(), gomock.Any()).DoAndReturn(func(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+		domains := make([]int, 0)
+		for domain := range domainMessages {
+			domains = append(domains, int(domain))
+		}
+		sort.Ints(domains)
+		for _, domain := range domains {
+			s.msgChan <- domainMessages[uint8(domain)]
+		}
+		return nil
+	}).AnyTimes()
+	s.mockScreener = mock_listener.NewMockScreener(ctrl)
+	s.mockScreener.EXPECT().Screen(gomock.Any(), screening.EVMFormat, gomock.Any()).Return(false).AnyTimes()
 	s.retryEventHandler = eventHandlers.NewRetryV1EventHandler(
 		log.With(),
 		s.mockEventListener,
@@ -112,7 +143,8 @@ func (s *RetryV1EventHandlerTestSuite) SetupTest() {
 		common.Address{},
 		s.domainID,
 		big.NewInt(5),
-		s.msgChan)
+		s.mockMessageQueue,
+		s.mockScreener)
 }
 
 func (s *RetryV1EventHandlerTestSuite) Test_FetchDepositFails() {
@@ -160,6 +192,7 @@ func (s *RetryV1EventHandlerTestSuite) Test_FetchDepositFails_ExecutionContinues
 	s.Nil(err)
 	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{
 		DepositNonce: 2,
+		Metadata:     depositorMetadata,
 	}}})
 }
 
@@ -214,7 +247,7 @@ func (s *RetryV1EventHandlerTestSuite) Test_HandleDepositFails_ExecutionContinue
 	msgs := <-s.msgChan
 
 	s.Nil(err)
-	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 2}}})
+	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 2, Metadata: depositorMetadata}}})
 }
 
 func (s *RetryV1EventHandlerTestSuite) Test_HandlingRetryPanics_ExecutionContinue() {
@@ -270,6 +303,7 @@ func (s *RetryV1EventHandlerTestSuite) Test_HandlingRetryPanics_ExecutionContinu
 	s.Nil(err)
 	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{
 		DepositNonce: 2,
+		Metadata:     depositorMetadata,
 	}}})
 }
 
@@ -325,8 +359,10 @@ func (s *RetryV1EventHandlerTestSuite) Test_MultipleDeposits() {
 	s.Nil(err)
 	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{
 		DepositNonce: 1,
+		Metadata:     depositorMetadata,
 	}}, {Data: transfer.TransferMessageData{
 		DepositNonce: 2,
+		Metadata:     depositorMetadata,
 	}}})
 }
 
@@ -386,6 +422,7 @@ func (s *RetryV1EventHandlerTestSuite) Test_MultipleDeposits_ExecutedIgnored() {
 		{
 			Data: transfer.TransferMessageData{
 				DepositNonce: 2,
+				Metadata:     depositorMetadata,
 			},
 		},
 	})
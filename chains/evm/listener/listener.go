@@ -0,0 +1,219 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package listener
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// HeadTimeout is the longest time the listener waits for a new head while subscribed
+// before checking the latest block in case the subscription stalled without an error
+var HeadTimeout = time.Minute
+
+type EventHandler interface {
+	HandleEvents(startBlock *big.Int, endBlock *big.Int) error
+}
+
+type ChainClient interface {
+	LatestBlock() (*big.Int, error)
+}
+
+type HeadSubscriber interface {
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+type BlockDeltaMeter interface {
+	TrackBlockDelta(domainID uint8, head *big.Int, current *big.Int)
+}
+
+type BlockStorer interface {
+	StoreBlock(block *big.Int, domainID uint8) error
+}
+
+// EVMListener processes block ranges of a domain once they are confirmed.
+// Without a head subscriber the latest block is polled every block retry interval.
+// With a head subscriber new heads received over the subscription trigger processing,
+// and polling is used while the subscription is down.
+type EVMListener struct {
+	client        ChainClient
+	subscriber    HeadSubscriber
+	eventHandlers []EventHandler
+	metrics       BlockDeltaMeter
+	blockstore    BlockStorer
+
+	domainID           uint8
+	blockRetryInterval time.Duration
+	blockConfirmations *big.Int
+	blockInterval      *big.Int
+
+	subscribed atomic.Bool
+	heads      chan struct{}
+
+	log zerolog.Logger
+}
+
+// NewEVMListener creates an EVMListener that listens to events on chain and calls event handlers
+// for every confirmed block range. The head subscriber is optional.
+func NewEVMListener(
+	client ChainClient,
+	subscriber HeadSubscriber,
+	eventHandlers []EventHandler,
+	blockstore BlockStorer,
+	metrics BlockDeltaMeter,
+	domainID uint8,
+	blockRetryInterval time.Duration,
+	blockConfirmations *big.Int,
+	blockInterval *big.Int) *EVMListener {
+	return &EVMListener{
+		log:                log.With().Uint8("domainID", domainID).Logger(),
+		client:             client,
+		subscriber:         subscriber,
+		metrics:            metrics,
+		eventHandlers:      eventHandlers,
+		blockstore:         blockstore,
+		domainID:           domainID,
+		blockRetryInterval: blockRetryInterval,
+		blockConfirmations: blockConfirmations,
+		blockInterval:      blockInterval,
+		heads:              make(chan struct{}, 1),
+	}
+}
+
+// ListenToEvents goes through block ranges of the network in order and executes
+// event handlers that are configured for the listener.
+// A range is processed again until all handlers succeed, so no range is skipped
+// even if the head subscription drops.
+func (l *EVMListener) ListenToEvents(ctx context.Context, startBlock *big.Int) {
+	if l.subscriber != nil {
+		go l.watchHeads(ctx)
+	}
+
+	endBlock := big.NewInt(0)
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			head, err := l.client.LatestBlock()
+			if err != nil {
+				l.log.Warn().Err(err).Msg("Unable to get latest block")
+				l.waitForHead(ctx)
+				continue
+			}
+			if startBlock == nil {
+				startBlock = big.NewInt(head.Int64())
+			}
+			endBlock.Add(startBlock, l.blockInterval)
+
+			// Wait if the difference is less than needed block confirmations; (latest - current) < BlockDelay
+			if new(big.Int).Sub(head, endBlock).Cmp(l.blockConfirmations) == -1 {
+				l.waitForHead(ctx)
+				continue
+			}
+
+			l.metrics.TrackBlockDelta(l.domainID, head, endBlock)
+			l.log.Debug().Msgf("Fetching evm events for block range %s-%s", startBlock, endBlock)
+
+			for _, handler := range l.eventHandlers {
+				err := handler.HandleEvents(startBlock, new(big.Int).Sub(endBlock, big.NewInt(1)))
+				if err != nil {
+					l.log.Warn().Err(err).Msgf("Unable to handle events")
+					continue loop
+				}
+			}
+
+			//Write to block store. Not a critical operation, no need to retry
+			err = l.blockstore.StoreBlock(endBlock, l.domainID)
+			if err != nil {
+				l.log.Error().Str("block", endBlock.String()).Err(err).Msg("Failed to write latest block to blockstore")
+			}
+
+			startBlock.Add(startBlock, l.blockInterval)
+		}
+	}
+}
+
+// waitForHead waits for the next head received over the subscription or
+// for the block retry interval if the listener is not subscribed
+func (l *EVMListener) waitForHead(ctx context.Context) {
+	timeout := l.blockRetryInterval
+	if l.subscribed.Load() {
+		timeout = HeadTimeout
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-l.heads:
+	case <-timer.C:
+	}
+}
+
+// watchHeads keeps the head subscription open and notifies the listener about new heads.
+// The listener polls while the subscription is down and the subscription is retried
+// every block retry interval.
+func (l *EVMListener) watchHeads(ctx context.Context) {
+	for {
+		headers := make(chan *types.Header)
+		sub, err := l.subscriber.SubscribeNewHead(ctx, headers)
+		if err != nil {
+			l.log.Warn().Err(err).Msg("Unable to subscribe to new heads, polling latest block")
+		} else {
+			l.log.Info().Msg("Subscribed to new heads")
+			l.subscribed.Store(true)
+			err = l.forwardHeads(ctx, sub, headers)
+			l.subscribed.Store(false)
+			sub.Unsubscribe()
+			if ctx.Err() != nil {
+				return
+			}
+			// wakes up the listener so it falls back to polling
+			l.notifyHead()
+
+			l.log.Warn().Err(err).Msg("New heads subscription dropped, polling latest block")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(l.blockRetryInterval):
+		}
+	}
+}
+
+// forwardHeads notifies the listener about heads until the subscription fails
+// or the context is canceled
+func (l *EVMListener) forwardHeads(ctx context.Context, sub ethereum.Subscription, headers chan *types.Header) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			if err == nil {
+				err = fmt.Errorf("subscription closed")
+			}
+			return err
+		case <-headers:
+			l.notifyHead()
+		}
+	}
+}
+
+func (l *EVMListener) notifyHead() {
+	select {
+	case l.heads <- struct{}{}:
+	default:
+	}
+}
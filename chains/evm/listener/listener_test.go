@@ -0,0 +1,158 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package listener_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener"
+	mock_listener "github.com/ChainSafe/sygma-relayer/chains/evm/listener/mock"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type subscription struct {
+	err chan error
+}
+
+func (s *subscription) Unsubscribe()      {}
+func (s *subscription) Err() <-chan error { return s.err }
+
+type ListenerTestSuite struct {
+	suite.Suite
+	mockClient       *mock_listener.MockChainClient
+	mockSubscriber   *mock_listener.MockHeadSubscriber
+	mockEventHandler *mock_listener.MockEventHandler
+	mockBlockStorer  *mock_listener.MockBlockStorer
+	mockMetrics      *mock_listener.MockBlockDeltaMeter
+	domainID         uint8
+}
+
+func TestRunListenerTestSuite(t *testing.T) {
+	suite.Run(t, new(ListenerTestSuite))
+}
+
+func (s *ListenerTestSuite) SetupTest() {
+	s.domainID = 1
+	ctrl := gomock.NewController(s.T())
+	s.mockClient = mock_listener.NewMockChainClient(ctrl)
+	s.mockSubscriber = mock_listener.NewMockHeadSubscriber(ctrl)
+	s.mockEventHandler = mock_listener.NewMockEventHandler(ctrl)
+	s.mockBlockStorer = mock_listener.NewMockBlockStorer(ctrl)
+	s.mockMetrics = mock_listener.NewMockBlockDeltaMeter(ctrl)
+	s.mockMetrics.EXPECT().TrackBlockDelta(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	listener.HeadTimeout = time.Hour
+}
+
+func (s *ListenerTestSuite) listener(subscriber listener.HeadSubscriber, blockRetryInterval time.Duration) *listener.EVMListener {
+	return listener.NewEVMListener(
+		s.mockClient,
+		subscriber,
+		[]listener.EventHandler{s.mockEventHandler},
+		s.mockBlockStorer,
+		s.mockMetrics,
+		s.domainID,
+		blockRetryInterval,
+		big.NewInt(0),
+		big.NewInt(5))
+}
+
+func (s *ListenerTestSuite) Test_ListenToEvents_PollsWithoutSubscriber() {
+	s.mockClient.EXPECT().LatestBlock().Return(big.NewInt(100), nil).Times(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.listener(nil, time.Second).ListenToEvents(ctx, big.NewInt(100))
+
+	time.Sleep(time.Millisecond * 50)
+	cancel()
+}
+
+func (s *ListenerTestSuite) Test_ListenToEvents_RetriesInCaseOfHandlerFailure() {
+	s.mockClient.EXPECT().LatestBlock().Return(big.NewInt(110), nil).Times(2)
+	s.mockClient.EXPECT().LatestBlock().Return(big.NewInt(100), nil).AnyTimes()
+	s.mockEventHandler.EXPECT().HandleEvents(big.NewInt(100), big.NewInt(104)).Return(fmt.Errorf("error"))
+	s.mockEventHandler.EXPECT().HandleEvents(big.NewInt(100), big.NewInt(104)).Return(nil)
+	s.mockBlockStorer.EXPECT().StoreBlock(big.NewInt(105), s.domainID).Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.listener(nil, time.Second).ListenToEvents(ctx, big.NewInt(100))
+
+	time.Sleep(time.Millisecond * 50)
+	cancel()
+}
+
+func (s *ListenerTestSuite) Test_ListenToEvents_NewHeadTriggersProcessing() {
+	processed := make(chan struct{})
+	s.mockSubscriber.EXPECT().SubscribeNewHead(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+		go func() {
+			time.Sleep(time.Millisecond * 10)
+			ch <- &types.Header{Number: big.NewInt(106)}
+		}()
+		return &subscription{err: make(chan error)}, nil
+	})
+	s.mockClient.EXPECT().LatestBlock().Return(big.NewInt(100), nil).Times(1)
+	s.mockClient.EXPECT().LatestBlock().Return(big.NewInt(106), nil).AnyTimes()
+	s.mockEventHandler.EXPECT().HandleEvents(big.NewInt(100), big.NewInt(104)).Return(nil)
+	s.mockBlockStorer.EXPECT().StoreBlock(big.NewInt(105), s.domainID).DoAndReturn(func(block *big.Int, domainID uint8) error {
+		close(processed)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.listener(s.mockSubscriber, time.Hour).ListenToEvents(ctx, big.NewInt(100))
+
+	select {
+	case <-processed:
+	case <-time.After(time.Second):
+		s.Fail("new head did not trigger processing")
+	}
+}
+
+func (s *ListenerTestSuite) Test_ListenToEvents_FallsBackToPollingWhenSubscriptionDrops() {
+	processed := make(chan struct{})
+	sub := &subscription{err: make(chan error, 1)}
+	s.mockSubscriber.EXPECT().SubscribeNewHead(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+		sub.err <- fmt.Errorf("connection closed")
+		return sub, nil
+	})
+	s.mockSubscriber.EXPECT().SubscribeNewHead(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("connection refused")).AnyTimes()
+	s.mockClient.EXPECT().LatestBlock().Return(big.NewInt(100), nil).Times(2)
+	s.mockClient.EXPECT().LatestBlock().Return(big.NewInt(106), nil).AnyTimes()
+	s.mockEventHandler.EXPECT().HandleEvents(big.NewInt(100), big.NewInt(104)).Return(nil)
+	s.mockBlockStorer.EXPECT().StoreBlock(big.NewInt(105), s.domainID).DoAndReturn(func(block *big.Int, domainID uint8) error {
+		close(processed)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.listener(s.mockSubscriber, time.Millisecond*20).ListenToEvents(ctx, big.NewInt(100))
+
+	select {
+	case <-processed:
+	case <-time.After(time.Second):
+		s.Fail("listener did not poll after the subscription dropped")
+	}
+}
+
+func (s *ListenerTestSuite) Test_ListenToEvents_ProcessesMissedRanges() {
+	s.mockClient.EXPECT().LatestBlock().Return(big.NewInt(110), nil).AnyTimes()
+	s.mockEventHandler.EXPECT().HandleEvents(big.NewInt(100), big.NewInt(104)).Return(nil)
+	s.mockEventHandler.EXPECT().HandleEvents(big.NewInt(105), big.NewInt(109)).Return(nil)
+	s.mockBlockStorer.EXPECT().StoreBlock(big.NewInt(105), s.domainID).Return(nil)
+	s.mockBlockStorer.EXPECT().StoreBlock(big.NewInt(110), s.domainID).Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.listener(nil, time.Second).ListenToEvents(ctx, big.NewInt(100))
+
+	time.Sleep(time.Millisecond * 50)
+	cancel()
+}
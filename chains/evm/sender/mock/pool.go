@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/sender/pool.go
+
+// Package mock_sender is a generated GoMock package.
+package mock_sender
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	common "github.com/ethereum/go-ethereum/common"
+	gomock "github.com/golang/mock/gomock"
+	transactor "github.com/sygmaprotocol/sygma-core/chains/evm/transactor"
+)
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// Transact mocks base method.
+func (m *MockTransactor) Transact(to *common.Address, data []byte, opts transactor.TransactOptions) (*common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transact", to, data, opts)
+	ret0, _ := ret[0].(*common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transact indicates an expected call of Transact.
+func (mr *MockTransactorMockRecorder) Transact(to, data, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transact", reflect.TypeOf((*MockTransactor)(nil).Transact), to, data, opts)
+}
+
+// MockAccountClient is a mock of AccountClient interface.
+type MockAccountClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountClientMockRecorder
+}
+
+// MockAccountClientMockRecorder is the mock recorder for MockAccountClient.
+type MockAccountClientMockRecorder struct {
+	mock *MockAccountClient
+}
+
+// NewMockAccountClient creates a new mock instance.
+func NewMockAccountClient(ctrl *gomock.Controller) *MockAccountClient {
+	mock := &MockAccountClient{ctrl: ctrl}
+	mock.recorder = &MockAccountClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountClient) EXPECT() *MockAccountClientMockRecorder {
+	return m.recorder
+}
+
+// BalanceAt mocks base method.
+func (m *MockAccountClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalanceAt", ctx, account, blockNumber)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceAt indicates an expected call of BalanceAt.
+func (mr *MockAccountClientMockRecorder) BalanceAt(ctx, account, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceAt", reflect.TypeOf((*MockAccountClient)(nil).BalanceAt), ctx, account, blockNumber)
+}
+
+// From mocks base method.
+func (m *MockAccountClient) From() common.Address {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "From")
+	ret0, _ := ret[0].(common.Address)
+	return ret0
+}
+
+// From indicates an expected call of From.
+func (mr *MockAccountClientMockRecorder) From() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "From", reflect.TypeOf((*MockAccountClient)(nil).From))
+}
+
+// LockNonce mocks base method.
+func (m *MockAccountClient) LockNonce() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LockNonce")
+}
+
+// LockNonce indicates an expected call of LockNonce.
+func (mr *MockAccountClientMockRecorder) LockNonce() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockNonce", reflect.TypeOf((*MockAccountClient)(nil).LockNonce))
+}
+
+// NonceAt mocks base method.
+func (m *MockAccountClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NonceAt", ctx, account, blockNumber)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NonceAt indicates an expected call of NonceAt.
+func (mr *MockAccountClientMockRecorder) NonceAt(ctx, account, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NonceAt", reflect.TypeOf((*MockAccountClient)(nil).NonceAt), ctx, account, blockNumber)
+}
+
+// UnlockNonce mocks base method.
+func (m *MockAccountClient) UnlockNonce() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UnlockNonce")
+}
+
+// UnlockNonce indicates an expected call of UnlockNonce.
+func (mr *MockAccountClientMockRecorder) UnlockNonce() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockNonce", reflect.TypeOf((*MockAccountClient)(nil).UnlockNonce))
+}
+
+// UnsafeNonce mocks base method.
+func (m *MockAccountClient) UnsafeNonce() (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsafeNonce")
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnsafeNonce indicates an expected call of UnsafeNonce.
+func (mr *MockAccountClientMockRecorder) UnsafeNonce() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsafeNonce", reflect.TypeOf((*MockAccountClient)(nil).UnsafeNonce))
+}
@@ -0,0 +1,241 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sender
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor"
+)
+
+// MonitorInterval is the period in which pending transactions and balances of senders are checked
+var MonitorInterval = time.Second * 30
+
+type Transactor interface {
+	Transact(to *common.Address, data []byte, opts transactor.TransactOptions) (*common.Hash, error)
+}
+
+type AccountClient interface {
+	From() common.Address
+	LockNonce()
+	UnlockNonce()
+	UnsafeNonce() (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// Sender is a submitting account of the domain with the transactor that sends its transactions
+type Sender struct {
+	Client     AccountClient
+	Transactor Transactor
+}
+
+type pendingTx struct {
+	nonce  uint64
+	sentAt time.Time
+}
+
+type sender struct {
+	Sender
+
+	sendLock   sync.Mutex
+	pending    []pendingTx
+	stuck      bool
+	lowBalance bool
+}
+
+func (s *sender) available() bool {
+	return !s.stuck && !s.lowBalance
+}
+
+// KeyPool sends transactions from a pool of sender keys in round-robin order.
+// Nonces are tracked per key. Keys with a transaction pending for longer than the stuck timeout,
+// and keys with a balance below the minimum balance, are taken out of rotation until they recover.
+type KeyPool struct {
+	senders      []*sender
+	next         int
+	lock         sync.Mutex
+	stuckTimeout time.Duration
+	minBalance   *big.Int
+	log          zerolog.Logger
+}
+
+// NewKeyPool creates a pool of the senders. A minimum balance of zero disables balance checks.
+func NewKeyPool(domainID uint8, senders []Sender, stuckTimeout time.Duration, minBalance *big.Int) *KeyPool {
+	pool := &KeyPool{
+		senders:      make([]*sender, len(senders)),
+		stuckTimeout: stuckTimeout,
+		minBalance:   minBalance,
+		log:          log.With().Uint8("domainID", domainID).Logger(),
+	}
+	for i, s := range senders {
+		pool.senders[i] = &sender{Sender: s}
+	}
+	return pool
+}
+
+// Transact sends the transaction from the next sender in rotation
+func (p *KeyPool) Transact(to *common.Address, data []byte, opts transactor.TransactOptions) (*common.Hash, error) {
+	s := p.nextSender()
+	s.sendLock.Lock()
+	defer s.sendLock.Unlock()
+
+	s.Client.LockNonce()
+	nonce, err := s.Client.UnsafeNonce()
+	if err != nil {
+		s.Client.UnlockNonce()
+		return nil, err
+	}
+	txNonce := nonce.Uint64()
+	s.Client.UnlockNonce()
+
+	hash, err := s.Transactor.Transact(to, data, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	s.pending = append(s.pending, pendingTx{nonce: txNonce, sentAt: time.Now()})
+	p.lock.Unlock()
+
+	p.log.Debug().Msgf("Sent transaction %s from sender %s with nonce %d", hash, s.Client.From(), txNonce)
+	return hash, nil
+}
+
+// nextSender returns the next available sender in rotation.
+// If no sender is available, the next sender is used regardless.
+func (p *KeyPool) nextSender() *sender {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for i := 0; i < len(p.senders); i++ {
+		index := (p.next + i) % len(p.senders)
+		if p.senders[index].available() {
+			p.next = index + 1
+			return p.senders[index]
+		}
+	}
+
+	s := p.senders[p.next%len(p.senders)]
+	p.next = p.next%len(p.senders) + 1
+	p.log.Warn().Msgf("No sender available, sending from %s", s.Client.From())
+	return s
+}
+
+// Monitor periodically checks pending transactions and balances of senders
+func (p *KeyPool) Monitor(ctx context.Context) {
+	ticker := time.NewTicker(MonitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.check(ctx)
+		}
+	}
+}
+
+func (p *KeyPool) check(ctx context.Context) {
+	for _, s := range p.senders {
+		err := p.checkPending(ctx, s)
+		if err != nil {
+			p.log.Warn().Err(err).Msgf("Failed checking pending transactions of sender %s", s.Client.From())
+		}
+
+		err = p.checkBalance(ctx, s)
+		if err != nil {
+			p.log.Warn().Err(err).Msgf("Failed checking balance of sender %s", s.Client.From())
+		}
+	}
+}
+
+// checkPending removes mined transactions of the sender and marks the sender as stuck
+// while a transaction is pending for longer than the stuck timeout
+func (p *KeyPool) checkPending(ctx context.Context, s *sender) error {
+	minedNonce, err := s.Client.NonceAt(ctx, s.Client.From(), nil)
+	if err != nil {
+		return err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	pending := make([]pendingTx, 0, len(s.pending))
+	stuck := false
+	for _, tx := range s.pending {
+		if tx.nonce < minedNonce {
+			continue
+		}
+
+		pending = append(pending, tx)
+		if time.Since(tx.sentAt) > p.stuckTimeout {
+			stuck = true
+		}
+	}
+	s.pending = pending
+
+	if stuck && !s.stuck {
+		p.log.Warn().Msgf("Sender %s has a stuck transaction with nonce %d, taking it out of rotation", s.Client.From(), minedNonce)
+	}
+	if !stuck && s.stuck {
+		p.log.Info().Msgf("Sender %s transactions mined, returning it to rotation", s.Client.From())
+	}
+	s.stuck = stuck
+	return nil
+}
+
+// checkBalance marks the sender as underfunded while its balance is below the minimum balance
+func (p *KeyPool) checkBalance(ctx context.Context, s *sender) error {
+	if p.minBalance == nil || p.minBalance.Sign() == 0 {
+		return nil
+	}
+
+	balance, err := s.Client.BalanceAt(ctx, s.Client.From(), nil)
+	if err != nil {
+		return err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	lowBalance := balance.Cmp(p.minBalance) == -1
+	if lowBalance && !s.lowBalance {
+		p.log.Warn().Msgf("Sender %s balance %s below minimum balance %s, taking it out of rotation", s.Client.From(), balance, p.minBalance)
+	}
+	if !lowBalance && s.lowBalance {
+		p.log.Info().Msgf("Sender %s balance %s restored, returning it to rotation", s.Client.From(), balance)
+	}
+	s.lowBalance = lowBalance
+	return nil
+}
+
+// Senders returns addresses of senders in the pool
+func (p *KeyPool) Senders() []common.Address {
+	addresses := make([]common.Address, len(p.senders))
+	for i, s := range p.senders {
+		addresses[i] = s.Client.From()
+	}
+	return addresses
+}
+
+// Balances returns balances of senders in the pool by their addresses
+func (p *KeyPool) Balances(ctx context.Context) (map[string]*big.Int, error) {
+	balances := make(map[string]*big.Int, len(p.senders))
+	for _, s := range p.senders {
+		balance, err := s.Client.BalanceAt(ctx, s.Client.From(), nil)
+		if err != nil {
+			return nil, err
+		}
+		balances[s.Client.From().Hex()] = balance
+	}
+	return balances, nil
+}
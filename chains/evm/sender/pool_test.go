@@ -0,0 +1,139 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sender
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	mock_sender "github.com/ChainSafe/sygma-relayer/chains/evm/sender/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor"
+)
+
+type KeyPoolTestSuite struct {
+	suite.Suite
+	mockClients     []*mock_sender.MockAccountClient
+	mockTransactors []*mock_sender.MockTransactor
+	senders         []Sender
+}
+
+func TestRunKeyPoolTestSuite(t *testing.T) {
+	suite.Run(t, new(KeyPoolTestSuite))
+}
+
+func (s *KeyPoolTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockClients = make([]*mock_sender.MockAccountClient, 2)
+	s.mockTransactors = make([]*mock_sender.MockTransactor, 2)
+	s.senders = make([]Sender, 2)
+	for i := range s.senders {
+		s.mockClients[i] = mock_sender.NewMockAccountClient(ctrl)
+		s.mockClients[i].EXPECT().From().Return(common.BigToAddress(big.NewInt(int64(i + 1)))).AnyTimes()
+		s.mockClients[i].EXPECT().LockNonce().AnyTimes()
+		s.mockClients[i].EXPECT().UnlockNonce().AnyTimes()
+		s.mockClients[i].EXPECT().UnsafeNonce().Return(big.NewInt(5), nil).AnyTimes()
+		s.mockTransactors[i] = mock_sender.NewMockTransactor(ctrl)
+		s.senders[i] = Sender{Client: s.mockClients[i], Transactor: s.mockTransactors[i]}
+	}
+}
+
+func (s *KeyPoolTestSuite) Test_Transact_RoundRobin() {
+	pool := NewKeyPool(1, s.senders, time.Minute, big.NewInt(0))
+	gomock.InOrder(
+		s.mockTransactors[0].EXPECT().Transact(gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{1}, nil),
+		s.mockTransactors[1].EXPECT().Transact(gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{2}, nil),
+		s.mockTransactors[0].EXPECT().Transact(gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{3}, nil),
+	)
+
+	for i := 1; i <= 3; i++ {
+		hash, err := pool.Transact(&common.Address{}, []byte{}, transactor.TransactOptions{})
+		s.Nil(err)
+		s.Equal(*hash, common.Hash{byte(i)})
+	}
+}
+
+func (s *KeyPoolTestSuite) Test_Transact_StuckSenderOutOfRotation() {
+	pool := NewKeyPool(1, s.senders, 0, big.NewInt(0))
+	s.mockTransactors[0].EXPECT().Transact(gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{1}, nil)
+	_, err := pool.Transact(&common.Address{}, []byte{}, transactor.TransactOptions{})
+	s.Nil(err)
+
+	s.mockClients[0].EXPECT().NonceAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(5), nil)
+	s.mockClients[1].EXPECT().NonceAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(5), nil)
+	pool.check(context.Background())
+
+	s.mockTransactors[1].EXPECT().Transact(gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{2}, nil).Times(2)
+	_, err = pool.Transact(&common.Address{}, []byte{}, transactor.TransactOptions{})
+	s.Nil(err)
+	_, err = pool.Transact(&common.Address{}, []byte{}, transactor.TransactOptions{})
+	s.Nil(err)
+}
+
+func (s *KeyPoolTestSuite) Test_Transact_MinedSenderReturnsToRotation() {
+	pool := NewKeyPool(1, s.senders[:1], 0, big.NewInt(0))
+	s.mockTransactors[0].EXPECT().Transact(gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{1}, nil)
+	_, err := pool.Transact(&common.Address{}, []byte{}, transactor.TransactOptions{})
+	s.Nil(err)
+
+	s.mockClients[0].EXPECT().NonceAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(5), nil)
+	pool.check(context.Background())
+	s.False(pool.senders[0].available())
+
+	s.mockClients[0].EXPECT().NonceAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(6), nil)
+	pool.check(context.Background())
+	s.True(pool.senders[0].available())
+	s.Equal(len(pool.senders[0].pending), 0)
+}
+
+func (s *KeyPoolTestSuite) Test_Transact_LowBalanceSenderOutOfRotation() {
+	pool := NewKeyPool(1, s.senders, time.Minute, big.NewInt(100))
+	s.mockClients[0].EXPECT().NonceAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(5), nil)
+	s.mockClients[1].EXPECT().NonceAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(5), nil)
+	s.mockClients[0].EXPECT().BalanceAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(big.NewInt(99), nil)
+	s.mockClients[1].EXPECT().BalanceAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(big.NewInt(100), nil)
+	pool.check(context.Background())
+
+	s.mockTransactors[1].EXPECT().Transact(gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{2}, nil).Times(2)
+	_, err := pool.Transact(&common.Address{}, []byte{}, transactor.TransactOptions{})
+	s.Nil(err)
+	_, err = pool.Transact(&common.Address{}, []byte{}, transactor.TransactOptions{})
+	s.Nil(err)
+}
+
+func (s *KeyPoolTestSuite) Test_Transact_NoSenderAvailable() {
+	pool := NewKeyPool(1, s.senders, time.Minute, big.NewInt(100))
+	s.mockClients[0].EXPECT().NonceAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(5), nil)
+	s.mockClients[1].EXPECT().NonceAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(5), nil)
+	s.mockClients[0].EXPECT().BalanceAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(big.NewInt(1), nil)
+	s.mockClients[1].EXPECT().BalanceAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(big.NewInt(1), nil)
+	pool.check(context.Background())
+
+	gomock.InOrder(
+		s.mockTransactors[0].EXPECT().Transact(gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{1}, nil),
+		s.mockTransactors[1].EXPECT().Transact(gomock.Any(), gomock.Any(), gomock.Any()).Return(&common.Hash{2}, nil),
+	)
+	_, err := pool.Transact(&common.Address{}, []byte{}, transactor.TransactOptions{})
+	s.Nil(err)
+	_, err = pool.Transact(&common.Address{}, []byte{}, transactor.TransactOptions{})
+	s.Nil(err)
+}
+
+func (s *KeyPoolTestSuite) Test_Balances() {
+	pool := NewKeyPool(1, s.senders, time.Minute, big.NewInt(0))
+	s.mockClients[0].EXPECT().BalanceAt(gomock.Any(), common.BigToAddress(big.NewInt(1)), gomock.Any()).Return(big.NewInt(10), nil)
+	s.mockClients[1].EXPECT().BalanceAt(gomock.Any(), common.BigToAddress(big.NewInt(2)), gomock.Any()).Return(big.NewInt(20), nil)
+
+	balances, err := pool.Balances(context.Background())
+
+	s.Nil(err)
+	s.Equal(balances, map[string]*big.Int{
+		common.BigToAddress(big.NewInt(1)).Hex(): big.NewInt(10),
+		common.BigToAddress(big.NewInt(2)).Hex(): big.NewInt(20),
+	})
+}
@@ -10,13 +10,14 @@ import (
 )
 
 type Deposit struct {
-	DestDomainID types.U8      `mapstructure:"dest_domain_id"`
-	ResourceID   types.Bytes32 `mapstructure:"resource_id"`
-	DepositNonce types.U64     `mapstructure:"deposit_nonce"`
-	TransferType types.U8      `mapstructure:"sygma_traits_TransferType"`
-	CallData     []byte        `mapstructure:"deposit_data"`
-	Handler      [1]byte       `mapstructure:"handler_response"`
-	Timestamp    time.Time     `mapstructure:"block_timestamp"`
+	DestDomainID types.U8        `mapstructure:"dest_domain_id"`
+	ResourceID   types.Bytes32   `mapstructure:"resource_id"`
+	DepositNonce types.U64       `mapstructure:"deposit_nonce"`
+	Sender       types.AccountID `mapstructure:"sender"`
+	TransferType types.U8        `mapstructure:"sygma_traits_TransferType"`
+	CallData     []byte          `mapstructure:"deposit_data"`
+	Handler      [1]byte         `mapstructure:"handler_response"`
+	Timestamp    time.Time       `mapstructure:"block_timestamp"`
 }
 
 type Retry struct {
@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ChainSafe/sygma-relayer/relayer/limits"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/sourcegraph/conc/pool"
@@ -40,33 +41,51 @@ type BridgePallet interface {
 	TrackExtrinsic(extHash types.Hash, sub *author.ExtrinsicStatusSubscription) error
 }
 
+type VolumeLimiter interface {
+	Allow(source, destination uint8, depositNonce uint64, resourceID [32]byte, amount *big.Int) error
+}
+
+// FundingTracker prefers coordinators that can fund the execution transaction on the destination
+type FundingTracker interface {
+	PreferFunded(process tss.TssProcess, domainID uint8) tss.TssProcess
+}
+
 type Executor struct {
+	propStorer  PropStorer
 	coordinator *tss.Coordinator
 	host        host.Host
 	comm        comm.Communication
 	fetcher     signing.SaveDataFetcher
 	bridge      BridgePallet
+	limiter     VolumeLimiter
 	conn        *connection.Connection
 	exitLock    *sync.RWMutex
+	funding     FundingTracker
 }
 
 func NewExecutor(
+	propStorer PropStorer,
 	host host.Host,
 	comm comm.Communication,
 	coordinator *tss.Coordinator,
 	bridgePallet BridgePallet,
+	limiter VolumeLimiter,
 	fetcher signing.SaveDataFetcher,
 	conn *connection.Connection,
 	exitLock *sync.RWMutex,
+	funding FundingTracker,
 ) *Executor {
 	return &Executor{
+		propStorer:  propStorer,
 		host:        host,
 		comm:        comm,
 		coordinator: coordinator,
 		bridge:      bridgePallet,
+		limiter:     limiter,
 		fetcher:     fetcher,
 		conn:        conn,
 		exitLock:    exitLock,
+		funding:     funding,
 	}
 }
 
@@ -84,19 +103,31 @@ func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 			Type:        prop.Type,
 			MessageID:   prop.MessageID,
 		}
-		transferProposals = append(transferProposals, transferProposal)
-
 		isExecuted, err := e.bridge.IsProposalExecuted(transferProposal)
 		if err != nil {
 			return err
 		}
 		if isExecuted {
+			log.Info().Str("messageID", transferProposal.MessageID).Msgf("Proposal %p already executed", transferProposal)
 			continue
 		}
 
-		proposals = append(proposals, prop)
+		if transferProposal.Data.Type == transfer.FungibleTransfer {
+			err = e.limiter.Allow(
+				transferProposal.Source,
+				transferProposal.Destination,
+				transferProposal.Data.DepositNonce,
+				transferProposal.Data.ResourceId,
+				limits.FungibleAmount(transferProposal.Data.Data))
+			if err != nil {
+				log.Err(err).Str("messageID", transferProposal.MessageID).Msgf("Proposal %p rejected by volume limits", transferProposal)
+				e.storeProposalsFailure([]*transfer.TransferProposal{transferProposal}, err)
+				continue
+			}
+		}
+		transferProposals = append(transferProposals, transferProposal)
 	}
-	if len(proposals) == 0 {
+	if len(transferProposals) == 0 {
 		return nil
 	}
 
@@ -106,6 +137,7 @@ func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 	}
 
 	messageID := transferProposals[0].MessageID
+	e.storeProposalsAttempt(transferProposals, messageID, messageID)
 	msg := big.NewInt(0)
 	msg.SetBytes(propHash)
 	signing, err := signing.NewSigning(
@@ -125,8 +157,9 @@ func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 
 	pool := pool.New().WithErrors()
 	pool.Go(func() error {
-		err := e.coordinator.Execute(executionContext, []tss.TssProcess{signing}, sigChn)
+		err := e.coordinator.Execute(executionContext, []tss.TssProcess{e.funding.PreferFunded(signing, transferProposals[0].Destination)}, sigChn)
 		if err != nil {
+			e.storeProposalsFailure(transferProposals, err)
 			cancelWatch()
 		}
 
@@ -158,10 +191,18 @@ func (e *Executor) watchExecution(ctx context.Context, cancelExecution context.C
 				hash, sub, err := e.executeProposal(proposals, signatureData)
 				if err != nil {
 					_ = e.comm.Broadcast(e.host.Peerstore().Peers(), []byte{}, comm.TssFailMsg, sessionID)
+					e.storeProposalsFailure(proposals, err)
+					return err
+				}
+
+				err = e.bridge.TrackExtrinsic(hash, sub)
+				if err != nil {
+					e.storeProposalsFailure(proposals, err)
 					return err
 				}
 
-				return e.bridge.TrackExtrinsic(hash, sub)
+				e.storeProposalsExecution(proposals, hash.Hex())
+				return nil
 			}
 		case <-ticker.C:
 			{
@@ -169,12 +210,15 @@ func (e *Executor) watchExecution(ctx context.Context, cancelExecution context.C
 					continue
 				}
 
+				e.storeProposalsExecution(proposals, "")
 				log.Info().Str("messageID", sessionID).Msgf("Successfully executed proposals")
 				return nil
 			}
 		case <-timeout.C:
 			{
-				return fmt.Errorf("execution timed out in %s", signingTimeout)
+				err := fmt.Errorf("execution timed out in %s", signingTimeout)
+				e.storeProposalsFailure(proposals, err)
+				return err
 			}
 		case <-ctx.Done():
 			{
@@ -209,3 +253,30 @@ func (e *Executor) areProposalsExecuted(proposals []*transfer.TransferProposal)
 
 	return true
 }
+
+func (e *Executor) storeProposalsAttempt(proposals []*transfer.TransferProposal, messageID string, sessionID string) {
+	for _, prop := range proposals {
+		err := e.propStorer.StorePropAttempt(prop.Source, prop.Destination, prop.Data.DepositNonce, messageID, sessionID)
+		if err != nil {
+			log.Err(err).Str("messageID", messageID).Msgf("Failed storing proposal %+v attempt", prop)
+		}
+	}
+}
+
+func (e *Executor) storeProposalsExecution(proposals []*transfer.TransferProposal, txHash string) {
+	for _, prop := range proposals {
+		err := e.propStorer.StorePropExecution(prop.Source, prop.Destination, prop.Data.DepositNonce, txHash)
+		if err != nil {
+			log.Err(err).Str("messageID", prop.MessageID).Msgf("Failed storing proposal %+v execution", prop)
+		}
+	}
+}
+
+func (e *Executor) storeProposalsFailure(proposals []*transfer.TransferProposal, failure error) {
+	for _, prop := range proposals {
+		err := e.propStorer.StorePropFailure(prop.Source, prop.Destination, prop.Data.DepositNonce, failure)
+		if err != nil {
+			log.Err(err).Str("messageID", prop.MessageID).Msgf("Failed storing proposal %+v failure", prop)
+		}
+	}
+}
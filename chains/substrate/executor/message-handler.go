@@ -9,16 +9,32 @@ import (
 	"math/big"
 
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ChainSafe/sygma-relayer/store"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
 )
 
-type SubstrateMessageHandler struct{}
+type Screener interface {
+	Screen(m *message.Message, format screening.Format, address string) bool
+}
+
+type SubstrateMessageHandler struct {
+	screener Screener
+}
+
+func NewSubstrateMessageHandler(screener Screener) *SubstrateMessageHandler {
+	return &SubstrateMessageHandler{
+		screener: screener,
+	}
+}
 
+// HandleMessage converts the transfer message into a proposal.
+// Transfers to screened recipients are quarantined and no proposal is returned.
 func (mh *SubstrateMessageHandler) HandleMessage(m *message.Message) (*proposal.Proposal, error) {
 	transferMessage := &transfer.TransferMessage{
 		Source:      m.Source,
@@ -27,6 +43,10 @@ func (mh *SubstrateMessageHandler) HandleMessage(m *message.Message) (*proposal.
 		Type:        m.Type,
 		ID:          m.ID,
 	}
+	recipient, ok := transferRecipient(transferMessage.Data)
+	if ok && mh.screener.Screen(m, screening.SubstrateFormat, recipient) {
+		return nil, nil
+	}
 	switch transferMessage.Data.Type {
 	case transfer.FungibleTransfer:
 		return fungibleTransferMessageHandler(transferMessage)
@@ -34,6 +54,20 @@ func (mh *SubstrateMessageHandler) HandleMessage(m *message.Message) (*proposal.
 	return nil, errors.New("wrong message type passed while handling message")
 }
 
+// transferRecipient returns the account ID of the recipient which is the
+// last junction of the recipient multilocation
+func transferRecipient(data transfer.TransferMessageData) (string, bool) {
+	if data.Type != transfer.FungibleTransfer || len(data.Payload) != 2 {
+		return "", false
+	}
+
+	recipient, ok := data.Payload[1].([]byte)
+	if !ok || len(recipient) < types.AccountIDLen {
+		return "", false
+	}
+	return codec.HexEncodeToString(recipient[len(recipient)-types.AccountIDLen:]), true
+}
+
 func fungibleTransferMessageHandler(m *transfer.TransferMessage) (*proposal.Proposal, error) {
 	if len(m.Data.Payload) != 2 {
 		return nil, errors.New("malformed payload. Len  of payload should be 2")
@@ -57,12 +91,16 @@ func fungibleTransferMessageHandler(m *transfer.TransferMessage) (*proposal.Prop
 		ResourceId:   m.Data.ResourceId,
 		Metadata:     m.Data.Metadata,
 		Data:         data,
+		Type:         transfer.FungibleTransfer,
 	}, m.ID, transfer.TransferProposalType), nil
 }
 
 type PropStorer interface {
 	StorePropStatus(source, destination uint8, depositNonce uint64, status store.PropStatus) error
 	PropStatus(source, destination uint8, depositNonce uint64) (store.PropStatus, error)
+	StorePropAttempt(source, destination uint8, depositNonce uint64, messageID string, sessionID string) error
+	StorePropExecution(source, destination uint8, depositNonce uint64, txHash string) error
+	StorePropFailure(source, destination uint8, depositNonce uint64, failure error) error
 }
 
 type BlockFetcher interface {
@@ -74,28 +112,35 @@ type DepositProcessor interface {
 	ProcessDeposits(startBlock *big.Int, endBlock *big.Int) (map[uint8][]*message.Message, error)
 }
 
+type MessageQueue interface {
+	Enqueue(block *big.Int, domainMessages map[uint8][]*message.Message) error
+}
+
 type RetryMessageHandler struct {
 	depositProcessor DepositProcessor
 	blockFetcher     BlockFetcher
 	propStorer       PropStorer
-	msgChan          chan []*message.Message
+	msgQueue         MessageQueue
 }
 
 func NewRetryMessageHandler(
 	depositProcessor DepositProcessor,
 	blockFetcher BlockFetcher,
 	propStorer PropStorer,
-	msgChan chan []*message.Message) *RetryMessageHandler {
+	msgQueue MessageQueue) *RetryMessageHandler {
 	return &RetryMessageHandler{
 		depositProcessor: depositProcessor,
 		blockFetcher:     blockFetcher,
 		propStorer:       propStorer,
-		msgChan:          msgChan,
+		msgQueue:         msgQueue,
 	}
 }
 
 func (h *RetryMessageHandler) HandleMessage(msg *message.Message) (*proposal.Proposal, error) {
 	retryData := msg.Data.(retry.RetryMessageData)
+	if retryData.TxHash != "" {
+		return nil, fmt.Errorf("retry of transaction %s not supported", retryData.TxHash)
+	}
 	hash, err := h.blockFetcher.GetFinalizedHead()
 	if err != nil {
 		return nil, err
@@ -125,6 +170,8 @@ func (h *RetryMessageHandler) HandleMessage(msg *message.Message) (*proposal.Pro
 		return nil, nil
 	}
 
-	h.msgChan <- filteredDeposits
-	return nil, nil
+	retry.MarkRetried(filteredDeposits, msg.ID)
+	return nil, h.msgQueue.Enqueue(retryData.BlockHeight, map[uint8][]*message.Message{
+		retryData.DestinationDomainID: filteredDeposits,
+	})
 }
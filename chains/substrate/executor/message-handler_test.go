@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"math/big"
+	"sort"
 	"testing"
 	"unsafe"
 
@@ -24,9 +25,14 @@ import (
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
 
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
 	"github.com/stretchr/testify/suite"
 )
 
+// unscreened is a screener without a screening list that flags no address
+var unscreened, _ = screening.NewScreener(relayer.ScreeningConfig{}, nil, nil)
+
 var errIncorrectFungibleTransferPayloadLen = errors.New("malformed payload. Len  of payload should be 2")
 var errIncorrectAmount = errors.New("wrong payload amount format")
 var errIncorrectRecipient = errors.New("wrong payload recipient format")
@@ -65,11 +71,12 @@ func (s *FungibleTransferHandlerTestSuite) TestFungibleTransferHandleMessage() {
 			DepositNonce: 1,
 			ResourceId:   [32]byte{1},
 			Data:         data,
+			Type:         transfer.FungibleTransfer,
 		},
 		Type: transfer.TransferProposalType,
 	}
 
-	mh := executor.SubstrateMessageHandler{}
+	mh := executor.NewSubstrateMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(err)
@@ -92,7 +99,7 @@ func (s *FungibleTransferHandlerTestSuite) TestFungibleTransferHandleMessageInco
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.SubstrateMessageHandler{}
+	mh := executor.NewSubstrateMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -117,7 +124,7 @@ func (s *FungibleTransferHandlerTestSuite) TestFungibleTransferHandleMessageInco
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.SubstrateMessageHandler{}
+	mh := executor.NewSubstrateMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -142,7 +149,7 @@ func (s *FungibleTransferHandlerTestSuite) TestFungibleTransferHandleMessageInco
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.SubstrateMessageHandler{}
+	mh := executor.NewSubstrateMessageHandler(unscreened)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -187,7 +194,7 @@ func (s *FungibleTransferHandlerTestSuite) TestSuccesfullyRegisterFungibleTransf
 
 	depositMessageHandler := message.NewMessageHandler()
 	// Register FungibleTransferMessageHandler function
-	depositMessageHandler.RegisterMessageHandler(transfer.TransferMessageType, &executor.SubstrateMessageHandler{})
+	depositMessageHandler.RegisterMessageHandler(transfer.TransferMessageType, executor.NewSubstrateMessageHandler(unscreened))
 	prop1, err1 := depositMessageHandler.HandleMessage(messageData)
 	s.Nil(err1)
 	s.NotNil(prop1)
@@ -206,6 +213,7 @@ type RetryMessageHandlerTestSuite struct {
 	mockDepositProcessor *mock_executor.MockDepositProcessor
 	mockPropStorer       *mock_executor.MockPropStorer
 	msgChan              chan []*message.Message
+	mockMessageQueue     *mock_executor.MockMessageQueue
 }
 
 func TestRunRetryMessageHandlerTestSuite(t *testing.T) {
@@ -218,11 +226,23 @@ func (s *RetryMessageHandlerTestSuite) SetupTest() {
 	s.mockDepositProcessor = mock_executor.NewMockDepositProcessor(ctrl)
 	s.mockPropStorer = mock_executor.NewMockPropStorer(ctrl)
 	s.msgChan = make(chan []*message.Message, 1)
+	s.mockMessageQueue = mock_executor.NewMockMessageQueue(ctrl)
+	s.mockMessageQueue.EXPECT().Enqueue(gomock.Any(), gomock.Any()).DoAndReturn(func(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+		domains := make([]int, 0)
+		for domain := range domainMessages {
+			domains = append(domains, int(domain))
+		}
+		sort.Ints(domains)
+		for _, domain := range domains {
+			s.msgChan <- domainMessages[uint8(domain)]
+		}
+		return nil
+	}).AnyTimes()
 	s.messageHandler = executor.NewRetryMessageHandler(
 		s.mockDepositProcessor,
 		s.mockBlockFetcher,
 		s.mockPropStorer,
-		s.msgChan)
+		s.mockMessageQueue)
 }
 
 func (s *RetryMessageHandlerTestSuite) Test_HandleMessage_RetryNotFinalized() {
@@ -0,0 +1,41 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package listener
+
+import (
+	"math/big"
+)
+
+// BlockHashFetcher fetches hashes of canonical substrate blocks
+type BlockHashFetcher struct {
+	conn Connection
+}
+
+func NewBlockHashFetcher(conn Connection) *BlockHashFetcher {
+	return &BlockHashFetcher{
+		conn: conn,
+	}
+}
+
+// BlockHash returns the hash of the block
+func (f *BlockHashFetcher) BlockHash(block *big.Int) (string, error) {
+	hash, err := f.conn.GetBlockHash(block.Uint64())
+	if err != nil {
+		return "", err
+	}
+	return hash.Hex(), nil
+}
+
+// ParentHash returns the parent hash of the block
+func (f *BlockHashFetcher) ParentHash(block *big.Int) (string, error) {
+	hash, err := f.conn.GetBlockHash(block.Uint64())
+	if err != nil {
+		return "", err
+	}
+	signedBlock, err := f.conn.GetBlock(hash)
+	if err != nil {
+		return "", err
+	}
+	return signedBlock.Block.Header.ParentHash.Hex(), nil
+}
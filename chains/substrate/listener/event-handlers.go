@@ -9,8 +9,13 @@ import (
 	"time"
 
 	"github.com/ChainSafe/sygma-relayer/chains/substrate/events"
+	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ChainSafe/sygma-relayer/store"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
@@ -56,6 +61,10 @@ func (eh *SystemUpdateEventHandler) HandleEvents(startBlock *big.Int, endBlock *
 	return nil
 }
 
+type MessageQueue interface {
+	Enqueue(block *big.Int, domainMessages map[uint8][]*message.Message) error
+}
+
 type DepositHandler interface {
 	HandleDeposit(
 		sourceID uint8,
@@ -69,21 +78,32 @@ type DepositHandler interface {
 	) (*message.Message, error)
 }
 
+type PropStorer interface {
+	StorePropStatus(source, destination uint8, depositNonce uint64, status store.PropStatus) error
+	PropStatus(source, destination uint8, depositNonce uint64) (store.PropStatus, error)
+}
+
+type Screener interface {
+	Screen(m *message.Message, format screening.Format, address string) bool
+}
+
 type FungibleTransferEventHandler struct {
 	domainID       uint8
 	depositHandler DepositHandler
 	log            zerolog.Logger
-	msgChan        chan []*message.Message
+	msgQueue       MessageQueue
 	conn           Connection
+	screener       Screener
 }
 
-func NewFungibleTransferEventHandler(logC zerolog.Context, domainID uint8, depositHandler DepositHandler, msgChan chan []*message.Message, conn Connection) *FungibleTransferEventHandler {
+func NewFungibleTransferEventHandler(logC zerolog.Context, domainID uint8, depositHandler DepositHandler, msgQueue MessageQueue, conn Connection, screener Screener) *FungibleTransferEventHandler {
 	return &FungibleTransferEventHandler{
 		depositHandler: depositHandler,
 		domainID:       domainID,
 		log:            logC.Logger(),
-		msgChan:        msgChan,
+		msgQueue:       msgQueue,
 		conn:           conn,
+		screener:       screener,
 	}
 }
 
@@ -93,23 +113,32 @@ func (eh *FungibleTransferEventHandler) HandleEvents(startBlock *big.Int, endBlo
 		return err
 	}
 
-	for _, deposits := range domainDeposits {
-		go func(d []*message.Message) {
-			eh.msgChan <- d
-		}(deposits)
-	}
-	return nil
+	return eh.msgQueue.Enqueue(endBlock, domainDeposits)
 }
 
+// ProcessDeposits fetches events block by block so that each
+// deposit message can record the block it was found in
 func (eh *FungibleTransferEventHandler) ProcessDeposits(startBlock *big.Int, endBlock *big.Int) (map[uint8][]*message.Message, error) {
-	evts, err := eh.conn.FetchEvents(startBlock, endBlock)
-	if err != nil {
-		log.Error().Err(err).Msg("Error fetching events")
-		return nil, err
-	}
-
 	domainDeposits := make(map[uint8][]*message.Message)
+	for block := new(big.Int).Set(startBlock); block.Cmp(endBlock) <= 0; block.Add(block, big.NewInt(1)) {
+		evts, err := eh.conn.FetchEvents(block, block)
+		if err != nil {
+			log.Error().Err(err).Msg("Error fetching events")
+			return nil, err
+		}
 
+		eh.processBlockDeposits(domainDeposits, evts, block.Uint64(), startBlock, endBlock)
+	}
+	return domainDeposits, nil
+}
+
+func (eh *FungibleTransferEventHandler) processBlockDeposits(
+	domainDeposits map[uint8][]*message.Message,
+	evts []*parser.Event,
+	block uint64,
+	startBlock *big.Int,
+	endBlock *big.Int,
+) {
 	for _, evt := range evts {
 		if evt.Name == events.DepositEvent {
 			func(evt parser.Event) {
@@ -131,13 +160,16 @@ func (eh *FungibleTransferEventHandler) ProcessDeposits(startBlock *big.Int, end
 					log.Error().Err(err).Msgf("%v", err)
 					return
 				}
+				transfer.SetDepositBlock(m, block)
+				if screenDepositor(eh.screener, m, d) {
+					return
+				}
 
 				eh.log.Info().Str("messageID", messageID).Msgf("Resolved deposit message %+v", d)
 				domainDeposits[m.Destination] = append(domainDeposits[m.Destination], m)
 			}(*evt)
 		}
 	}
-	return domainDeposits, nil
 }
 
 type RetryEventHandler struct {
@@ -145,16 +177,20 @@ type RetryEventHandler struct {
 	domainID       uint8
 	depositHandler DepositHandler
 	log            zerolog.Logger
-	msgChan        chan []*message.Message
+	msgQueue       MessageQueue
+	screener       Screener
+	propStorer     PropStorer
 }
 
-func NewRetryEventHandler(logC zerolog.Context, conn Connection, depositHandler DepositHandler, domainID uint8, msgChan chan []*message.Message) *RetryEventHandler {
+func NewRetryEventHandler(logC zerolog.Context, conn Connection, depositHandler DepositHandler, domainID uint8, msgQueue MessageQueue, screener Screener, propStorer PropStorer) *RetryEventHandler {
 	return &RetryEventHandler{
 		depositHandler: depositHandler,
 		domainID:       domainID,
 		conn:           conn,
 		log:            logC.Logger(),
-		msgChan:        msgChan,
+		msgQueue:       msgQueue,
+		screener:       screener,
+		propStorer:     propStorer,
 	}
 }
 
@@ -218,6 +254,10 @@ func (rh *RetryEventHandler) HandleEvents(startBlock *big.Int, endBlock *big.Int
 						if err != nil {
 							return err
 						}
+						transfer.SetDepositBlock(m, er.DepositOnBlockHeight.Uint64())
+						if screenDepositor(rh.screener, m, d) {
+							continue
+						}
 
 						rh.log.Info().Str("messageID", messageID).Msgf("Resolved retry message %+v", d)
 
@@ -233,8 +273,20 @@ func (rh *RetryEventHandler) HandleEvents(startBlock *big.Int, endBlock *big.Int
 		}
 	}
 
-	for _, deposits := range domainDeposits {
-		rh.msgChan <- deposits
+	for domainID, deposits := range domainDeposits {
+		domainDeposits[domainID] = retry.FilterExecuted(rh.propStorer, deposits)
 	}
-	return nil
+	return rh.msgQueue.Enqueue(endBlock, domainDeposits)
+}
+
+// screenDepositor records the depositor of the deposit in the message and returns
+// true if the deposit was quarantined. Deposit events without a sender are not screened.
+func screenDepositor(screener Screener, m *message.Message, d events.Deposit) bool {
+	if d.Sender == (types.AccountID{}) {
+		return false
+	}
+
+	depositor := codec.HexEncodeToString(d.Sender[:])
+	transfer.SetDepositor(m, depositor)
+	return screener.Screen(m, screening.SubstrateFormat, depositor)
 }
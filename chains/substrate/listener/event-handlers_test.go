@@ -6,10 +6,13 @@ package listener_test
 import (
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/ChainSafe/sygma-relayer/chains/substrate/listener"
 	mock_events "github.com/ChainSafe/sygma-relayer/chains/substrate/listener/mock"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ChainSafe/sygma-relayer/store"
 	"github.com/rs/zerolog"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 
@@ -79,7 +82,9 @@ type DepositHandlerTestSuite struct {
 	mockDepositHandler  *mock_events.MockDepositHandler
 	domainID            uint8
 	msgChan             chan []*message.Message
+	mockMessageQueue    *mock_events.MockMessageQueue
 	mockConn            *mock_events.MockConnection
+	mockScreener        *mock_events.MockScreener
 }
 
 func TestRunDepositHandlerTestSuite(t *testing.T) {
@@ -91,8 +96,21 @@ func (s *DepositHandlerTestSuite) SetupTest() {
 	s.domainID = 1
 	s.mockDepositHandler = mock_events.NewMockDepositHandler(ctrl)
 	s.msgChan = make(chan []*message.Message, 2)
+	s.mockMessageQueue = mock_events.NewMockMessageQueue(ctrl)
+	s.mockMessageQueue.EXPECT().Enqueue(gomock.Any(), gomock.Any()).DoAndReturn(func(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+		domains := make([]int, 0)
+		for domain := range domainMessages {
+			domains = append(domains, int(domain))
+		}
+		sort.Ints(domains)
+		for _, domain := range domains {
+			s.msgChan <- domainMessages[uint8(domain)]
+		}
+		return nil
+	}).AnyTimes()
 	s.mockConn = mock_events.NewMockConnection(ctrl)
-	s.depositEventHandler = listener.NewFungibleTransferEventHandler(zerolog.Context{}, s.domainID, s.mockDepositHandler, s.msgChan, s.mockConn)
+	s.mockScreener = mock_events.NewMockScreener(ctrl)
+	s.depositEventHandler = listener.NewFungibleTransferEventHandler(zerolog.Context{}, s.domainID, s.mockDepositHandler, s.mockMessageQueue, s.mockConn, s.mockScreener)
 }
 
 func (s *DepositHandlerTestSuite) Test_HandleDepositFails_ExecutionContinue() {
@@ -161,7 +179,8 @@ func (s *DepositHandlerTestSuite) Test_HandleDepositFails_ExecutionContinue() {
 			},
 		},
 	}
-	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return(evts, nil)
+	s.mockConn.EXPECT().FetchEvents(big.NewInt(0), big.NewInt(0)).Return(evts, nil)
+	s.mockConn.EXPECT().FetchEvents(big.NewInt(1), big.NewInt(1)).Return([]*parser.Event{}, nil)
 
 	err := s.depositEventHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
 	msgs := <-s.msgChan
@@ -239,7 +258,8 @@ func (s *DepositHandlerTestSuite) Test_SuccessfulHandleDeposit() {
 			},
 		},
 	}
-	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return(evts, nil)
+	s.mockConn.EXPECT().FetchEvents(big.NewInt(0), big.NewInt(0)).Return(evts, nil)
+	s.mockConn.EXPECT().FetchEvents(big.NewInt(1), big.NewInt(1)).Return([]*parser.Event{}, nil)
 
 	err := s.depositEventHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
 	msgs := <-s.msgChan
@@ -317,7 +337,8 @@ func (s *DepositHandlerTestSuite) Test_HandleDepositPanics_ExecutionContinues()
 		},
 	}
 
-	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return(evts, nil)
+	s.mockConn.EXPECT().FetchEvents(big.NewInt(0), big.NewInt(0)).Return(evts, nil)
+	s.mockConn.EXPECT().FetchEvents(big.NewInt(1), big.NewInt(1)).Return([]*parser.Event{}, nil)
 
 	err := s.depositEventHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
 	msgs := <-s.msgChan
@@ -326,6 +347,90 @@ func (s *DepositHandlerTestSuite) Test_HandleDepositPanics_ExecutionContinues()
 	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 2}}})
 }
 
+func (s *DepositHandlerTestSuite) Test_ScreenedSender_DepositQuarantined() {
+	screenedSender := types.AccountID{1}
+	sender := types.AccountID{2}
+	d1 := map[string]any{
+		"dest_domain_id":            types.NewU8(2),
+		"deposit_nonce":             types.NewU64(1),
+		"resource_id":               types.Bytes32{1},
+		"sygma_traits_TransferType": types.NewU8(0),
+		"handler_response":          [1]byte{0},
+		"deposit_data":              []byte{},
+	}
+	d2 := map[string]any{
+		"deposit_nonce":             types.NewU64(2),
+		"dest_domain_id":            types.NewU8(2),
+		"resource_id":               types.Bytes32{1},
+		"sygma_traits_TransferType": types.NewU8(0),
+		"handler_response":          [1]byte{0},
+		"deposit_data":              []byte{},
+	}
+	s.mockDepositHandler.EXPECT().HandleDeposit(
+		s.domainID,
+		d1["dest_domain_id"],
+		d1["deposit_nonce"],
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&message.Message{Data: transfer.TransferMessageData{DepositNonce: 1}}, nil)
+	s.mockDepositHandler.EXPECT().HandleDeposit(
+		s.domainID,
+		d2["dest_domain_id"],
+		d2["deposit_nonce"],
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&message.Message{Data: transfer.TransferMessageData{DepositNonce: 2}}, nil)
+	s.mockScreener.EXPECT().Screen(gomock.Any(), screening.SubstrateFormat, fmt.Sprintf("0x%x", screenedSender[:])).Return(true)
+	s.mockScreener.EXPECT().Screen(gomock.Any(), screening.SubstrateFormat, fmt.Sprintf("0x%x", sender[:])).Return(false)
+
+	evts := []*parser.Event{
+		{
+			Name: "SygmaBridge.Deposit",
+			Fields: registry.DecodedFields{
+				&registry.DecodedField{Name: "dest_domain_id", Value: d1["dest_domain_id"]},
+				&registry.DecodedField{Name: "resource_id", Value: d1["resource_id"]},
+				&registry.DecodedField{Name: "deposit_nonce", Value: d1["deposit_nonce"]},
+				&registry.DecodedField{Name: "sender", Value: registry.DecodedFields{
+					&registry.DecodedField{Value: screenedSender},
+				}},
+				&registry.DecodedField{Name: "sygma_traits_TransferType", Value: d1["sygma_traits_TransferType"]},
+				&registry.DecodedField{Name: "deposit_data", Value: d1["deposit_data"]},
+				&registry.DecodedField{Name: "handler_response", Value: d1["handler_response"]},
+			},
+		},
+		{
+			Name: "SygmaBridge.Deposit",
+			Fields: registry.DecodedFields{
+				&registry.DecodedField{Name: "dest_domain_id", Value: d2["dest_domain_id"]},
+				&registry.DecodedField{Name: "resource_id", Value: d2["resource_id"]},
+				&registry.DecodedField{Name: "deposit_nonce", Value: d2["deposit_nonce"]},
+				&registry.DecodedField{Name: "sender", Value: registry.DecodedFields{
+					&registry.DecodedField{Value: sender},
+				}},
+				&registry.DecodedField{Name: "sygma_traits_TransferType", Value: d2["sygma_traits_TransferType"]},
+				&registry.DecodedField{Name: "deposit_data", Value: d2["deposit_data"]},
+				&registry.DecodedField{Name: "handler_response", Value: d2["handler_response"]},
+			},
+		},
+	}
+	s.mockConn.EXPECT().FetchEvents(big.NewInt(0), big.NewInt(0)).Return(evts, nil)
+
+	err := s.depositEventHandler.HandleEvents(big.NewInt(0), big.NewInt(0))
+	msgs := <-s.msgChan
+
+	s.Nil(err)
+	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{
+		DepositNonce: 2,
+		Metadata:     map[string]interface{}{transfer.DepositorMetadataKey: fmt.Sprintf("0x%x", sender[:])},
+	}}})
+}
+
 type RetryHandlerTestSuite struct {
 	suite.Suite
 	retryHandler       *listener.RetryEventHandler
@@ -333,6 +438,9 @@ type RetryHandlerTestSuite struct {
 	mockConn           *mock_events.MockConnection
 	domainID           uint8
 	msgChan            chan []*message.Message
+	mockMessageQueue   *mock_events.MockMessageQueue
+	mockScreener       *mock_events.MockScreener
+	mockPropStorer     *mock_events.MockPropStorer
 }
 
 func TestRunRetryHandlerTestSuite(t *testing.T) {
@@ -345,7 +453,21 @@ func (s *RetryHandlerTestSuite) SetupTest() {
 	s.mockDepositHandler = mock_events.NewMockDepositHandler(ctrl)
 	s.mockConn = mock_events.NewMockConnection(ctrl)
 	s.msgChan = make(chan []*message.Message, 2)
-	s.retryHandler = listener.NewRetryEventHandler(zerolog.Context{}, s.mockConn, s.mockDepositHandler, s.domainID, s.msgChan)
+	s.mockMessageQueue = mock_events.NewMockMessageQueue(ctrl)
+	s.mockMessageQueue.EXPECT().Enqueue(gomock.Any(), gomock.Any()).DoAndReturn(func(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+		domains := make([]int, 0)
+		for domain := range domainMessages {
+			domains = append(domains, int(domain))
+		}
+		sort.Ints(domains)
+		for _, domain := range domains {
+			s.msgChan <- domainMessages[uint8(domain)]
+		}
+		return nil
+	}).AnyTimes()
+	s.mockScreener = mock_events.NewMockScreener(ctrl)
+	s.mockPropStorer = mock_events.NewMockPropStorer(ctrl)
+	s.retryHandler = listener.NewRetryEventHandler(zerolog.Context{}, s.mockConn, s.mockDepositHandler, s.domainID, s.mockMessageQueue, s.mockScreener, s.mockPropStorer)
 
 }
 
@@ -559,6 +681,9 @@ func (s *RetryHandlerTestSuite) Test_ValidEvents() {
 		},
 	}
 	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return(evts, nil)
+	s.mockPropStorer.EXPECT().PropStatus(uint8(0), uint8(0), uint64(1)).Return(store.PendingProp, nil)
+	s.mockPropStorer.EXPECT().StorePropStatus(uint8(0), uint8(0), uint64(1), store.FailedProp).Return(nil)
+	s.mockPropStorer.EXPECT().PropStatus(uint8(0), uint8(0), uint64(2)).Return(store.FailedProp, nil)
 	s.mockConn.EXPECT().GetBlockEvents(gomock.Any()).Return(blockEvts, nil)
 
 	err := s.retryHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
@@ -566,7 +691,7 @@ func (s *RetryHandlerTestSuite) Test_ValidEvents() {
 
 	s.Nil(err)
 	s.Equal(len(s.msgChan), 0)
-	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 1}}, {Data: transfer.TransferMessageData{DepositNonce: 2}}})
+	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 1, DepositBlock: 95}}, {Data: transfer.TransferMessageData{DepositNonce: 2, DepositBlock: 95}}})
 }
 
 func (s *RetryHandlerTestSuite) Test_EventPanics() {
@@ -667,6 +792,7 @@ func (s *RetryHandlerTestSuite) Test_EventPanics() {
 		},
 	}
 	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return(evts, nil)
+	s.mockPropStorer.EXPECT().PropStatus(uint8(0), uint8(0), uint64(2)).Return(store.FailedProp, nil)
 	s.mockConn.EXPECT().GetBlockEvents(gomock.Any()).Return(blockEvts1, nil)
 	s.mockConn.EXPECT().GetBlockEvents(gomock.Any()).Return(blockEvts2, nil)
 
@@ -675,5 +801,66 @@ func (s *RetryHandlerTestSuite) Test_EventPanics() {
 
 	s.Nil(err)
 	s.Equal(len(s.msgChan), 0)
-	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 2}}})
+	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 2, DepositBlock: 95}}})
+}
+
+func (s *RetryHandlerTestSuite) Test_ExecutedDepositsFiltered() {
+	s.mockConn.EXPECT().GetFinalizedHead().Return(types.Hash{}, nil)
+	s.mockConn.EXPECT().GetBlock(gomock.Any()).Return(&types.SignedBlock{Block: types.Block{
+		Header: types.Header{
+			Number: types.BlockNumber(uint32(100)),
+		},
+	}}, nil)
+	s.mockConn.EXPECT().GetBlockHash(uint64(95)).Return(types.Hash{}, nil)
+	d1 := map[string]any{
+		"dest_domain_id":            types.NewU8(2),
+		"deposit_nonce":             types.NewU64(1),
+		"resource_id":               types.Bytes32{1},
+		"sygma_traits_TransferType": types.NewU8(0),
+		"handler_response":          [1]byte{0},
+		"deposit_data":              []byte{},
+	}
+	blockEvts := []*parser.Event{
+		{
+			Name: "SygmaBridge.Deposit",
+			Fields: registry.DecodedFields{
+				&registry.DecodedField{Name: "dest_domain_id", Value: d1["dest_domain_id"]},
+				&registry.DecodedField{Name: "resource_id", Value: d1["resource_id"]},
+				&registry.DecodedField{Name: "deposit_nonce", Value: d1["deposit_nonce"]},
+				&registry.DecodedField{Name: "sygma_traits_TransferType", Value: d1["sygma_traits_TransferType"]},
+				&registry.DecodedField{Name: "deposit_data", Value: d1["deposit_data"]},
+				&registry.DecodedField{Name: "handler_response", Value: d1["handler_response"]},
+			},
+		},
+	}
+	s.mockDepositHandler.EXPECT().HandleDeposit(
+		s.domainID,
+		d1["dest_domain_id"],
+		d1["deposit_nonce"],
+		d1["resource_id"],
+		d1["deposit_data"],
+		d1["sygma_traits_TransferType"],
+		gomock.Any(),
+		gomock.Any(),
+	).Return(
+		&message.Message{Destination: 2, Data: transfer.TransferMessageData{DepositNonce: 1}},
+		nil,
+	)
+	evts := []*parser.Event{
+		{
+			Name: "SygmaBridge.Retry",
+			Fields: registry.DecodedFields{
+				&registry.DecodedField{Name: "deposit_on_block_height", Value: types.NewU128(*big.NewInt(95))},
+			},
+		},
+	}
+	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return(evts, nil)
+	s.mockConn.EXPECT().GetBlockEvents(gomock.Any()).Return(blockEvts, nil)
+	s.mockPropStorer.EXPECT().PropStatus(uint8(0), uint8(2), uint64(1)).Return(store.ExecutedProp, nil)
+
+	err := s.retryHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
+	msgs := <-s.msgChan
+
+	s.Nil(err)
+	s.Equal(len(msgs), 0)
 }
@@ -6,6 +6,7 @@ package listener
 import (
 	"github.com/ChainSafe/sygma-relayer/chains/substrate/events"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/mitchellh/mapstructure"
 )
 
@@ -29,6 +30,11 @@ func DecodeDepositEvent(evtFields registry.DecodedFields) (events.Deposit, error
 			if err != nil {
 				return events.Deposit{}, err
 			}
+		case "sender":
+			err := decodeAccountID(evtField.Value, &d.Sender)
+			if err != nil {
+				return events.Deposit{}, err
+			}
 		case "sygma_traits_TransferType":
 			err := mapstructure.Decode(evtField.Value, &d.TransferType)
 			if err != nil {
@@ -54,6 +60,16 @@ func DecodeDepositEvent(evtFields registry.DecodedFields) (events.Deposit, error
 	return d, nil
 }
 
+// decodeAccountID decodes the account ID which the registry decodes
+// as a composite type with a single byte array field
+func decodeAccountID(value interface{}, accountID *types.AccountID) error {
+	fields, ok := value.(registry.DecodedFields)
+	if ok && len(fields) == 1 {
+		value = fields[0].Value
+	}
+	return mapstructure.Decode(value, accountID)
+}
+
 func DecodeRetryEvent(evtFields registry.DecodedFields) (events.Retry, error) {
 	var er events.Retry
 
@@ -0,0 +1,49 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pallet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/sygmaprotocol/sygma-core/chains/substrate/connection"
+)
+
+// BalanceFetcher reads the free balance of the submitting account of the domain
+type BalanceFetcher struct {
+	conn    *connection.Connection
+	keyPair signature.KeyringPair
+}
+
+func NewBalanceFetcher(conn *connection.Connection, keyPair signature.KeyringPair) *BalanceFetcher {
+	return &BalanceFetcher{
+		conn:    conn,
+		keyPair: keyPair,
+	}
+}
+
+// Balances returns the free balance of the submitting account by its address
+func (f *BalanceFetcher) Balances(ctx context.Context) (map[string]*big.Int, error) {
+	meta := f.conn.GetMetadata()
+	key, err := types.CreateStorageKey(&meta, "System", "Account", f.keyPair.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var account types.AccountInfo
+	ok, err := f.conn.RPC.State.GetStorageLatest(key, &account)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("account %s not found", f.keyPair.Address)
+	}
+
+	return map[string]*big.Int{
+		f.keyPair.Address: account.Data.Free.Int,
+	}, nil
+}
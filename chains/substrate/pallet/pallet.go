@@ -64,6 +64,19 @@ func (p *Pallet) ProposalsHash(proposals []*transfer.TransferProposal) ([]byte,
 	return chains.ProposalsHash(proposals, p.ChainID.Int64(), verifyingContract, bridgeVersion)
 }
 
+// AreProposalsExecuted returns the execution status of each proposal
+func (p *Pallet) AreProposalsExecuted(proposals []*transfer.TransferProposal) ([]bool, error) {
+	executed := make([]bool, len(proposals))
+	for i, prop := range proposals {
+		isExecuted, err := p.IsProposalExecuted(prop)
+		if err != nil {
+			return nil, err
+		}
+		executed[i] = isExecuted
+	}
+	return executed, nil
+}
+
 func (p *Pallet) IsProposalExecuted(prop *transfer.TransferProposal) (bool, error) {
 
 	log.Debug().
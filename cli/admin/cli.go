@@ -0,0 +1,45 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package admin
+
+import (
+	"os"
+
+	"github.com/ChainSafe/sygma-relayer/admin"
+	"github.com/spf13/cobra"
+)
+
+const tokenEnv = "SYG_ADMIN_TOKEN"
+
+var AdminCLI = &cobra.Command{
+	Use:   "admin",
+	Short: "commands to inspect and control a running relayer through its admin API",
+	Long:  "Commands call the admin API of the running relayer. The admin token can be provided with the --token flag or the SYG_ADMIN_TOKEN environment variable.",
+}
+
+var (
+	url   string
+	token string
+)
+
+func init() {
+	AdminCLI.PersistentFlags().StringVar(&url, "url", "http://localhost:9002", "URL of the relayer admin API")
+	AdminCLI.PersistentFlags().StringVar(&token, "token", os.Getenv(tokenEnv), "admin API token")
+
+	AdminCLI.AddCommand(domainsCMD)
+	AdminCLI.AddCommand(proposalsCMD)
+	AdminCLI.AddCommand(sessionsCMD)
+	AdminCLI.AddCommand(peersCMD)
+	AdminCLI.AddCommand(pausedCMD)
+	AdminCLI.AddCommand(pauseCMD)
+	AdminCLI.AddCommand(resumeCMD)
+	AdminCLI.AddCommand(retryCMD)
+	AdminCLI.AddCommand(sweepCMD)
+	AdminCLI.AddCommand(deadLettersCMD)
+	AdminCLI.AddCommand(requeueCMD)
+}
+
+func newClient() *admin.Client {
+	return admin.NewClient(url, token)
+}
@@ -0,0 +1,156 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package admin
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ChainSafe/sygma-relayer/admin"
+	"github.com/ChainSafe/sygma-relayer/relayer/pause"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pauseCMD = &cobra.Command{
+		Use:   "pause",
+		Short: "Pause relaying to a domain, over a route or of a resource",
+		RunE:  pauseRelaying,
+	}
+	resumeCMD = &cobra.Command{
+		Use:   "resume",
+		Short: "Resume relaying to a domain, over a route or of a resource",
+		RunE:  resumeRelaying,
+	}
+	retryCMD = &cobra.Command{
+		Use:   "retry",
+		Short: "Retry deposits made in a block or a transaction",
+		Long:  "Retry deposits of the resource to the destination domain made in the block on the source domain, or all deposits made in the transaction on the source domain. Retries do not require an on-chain retry transaction. The retry has to be triggered on every relayer with the same arguments.",
+		RunE:  retryDeposits,
+	}
+	sweepCMD = &cobra.Command{
+		Use:   "sweep",
+		Short: "Retry stuck proposals",
+		Long:  "Retry stuck proposals immediately. The sweep is not broadcasted so it has to be triggered on every relayer.",
+		RunE:  sweepProposals,
+	}
+	requeueCMD = &cobra.Command{
+		Use:   "requeue",
+		Short: "Relay a dead letter again",
+		Long:  "Move the dead letter back to the message queue with a reset failure count and relay it again. Dead letters are stored per relayer so the requeue has to be triggered on every relayer that dead lettered the message.",
+		RunE:  requeueDeadLetter,
+	}
+)
+
+var (
+	domain      uint8
+	resource    string
+	blockHeight uint64
+	txHash      string
+	key         string
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{pauseCMD, resumeCMD} {
+		cmd.Flags().Uint8Var(&domain, "domain", 0, "domain ID")
+		cmd.Flags().Uint8Var(&source, "source", 0, "source domain ID of the route")
+		cmd.Flags().Uint8Var(&destination, "destination", 0, "destination domain ID of the route")
+		cmd.Flags().StringVar(&resource, "resource", "", "hex encoded resource ID")
+	}
+
+	retryCMD.Flags().Uint8Var(&source, "source", 0, "source domain ID of the deposit")
+	retryCMD.Flags().Uint8Var(&destination, "destination", 0, "destination domain ID of the deposit")
+	retryCMD.Flags().Uint64Var(&blockHeight, "block", 0, "block of the deposit on the source domain")
+	retryCMD.Flags().StringVar(&resource, "resource", "", "hex encoded resource ID of the deposit")
+	retryCMD.Flags().StringVar(&txHash, "tx", "", "hash of the deposit transaction on the source domain")
+	_ = retryCMD.MarkFlagRequired("source")
+
+	requeueCMD.Flags().StringVar(&key, "key", "", "key of the dead letter")
+	_ = requeueCMD.MarkFlagRequired("key")
+}
+
+func pauseTarget() (pause.Target, error) {
+	target := pause.Target{
+		DomainID:    domain,
+		Source:      source,
+		Destination: destination,
+		ResourceID:  resource,
+	}
+	_, err := target.Key()
+	return target, err
+}
+
+func pauseRelaying(cmd *cobra.Command, args []string) error {
+	target, err := pauseTarget()
+	if err != nil {
+		return err
+	}
+
+	pauses, err := newClient().Pause(target)
+	if err != nil {
+		return err
+	}
+
+	return printPauses(pauses)
+}
+
+func resumeRelaying(cmd *cobra.Command, args []string) error {
+	target, err := pauseTarget()
+	if err != nil {
+		return err
+	}
+
+	pauses, err := newClient().Resume(target)
+	if err != nil {
+		return err
+	}
+
+	return printPauses(pauses)
+}
+
+func retryDeposits(cmd *cobra.Command, args []string) error {
+	req := admin.RetryRequest{
+		Source: source,
+		TxHash: txHash,
+	}
+	switch {
+	case txHash != "" && blockHeight != 0:
+		return fmt.Errorf("either transaction hash or block has to be provided")
+	case txHash == "":
+		if blockHeight == 0 || destination == 0 || resource == "" {
+			return fmt.Errorf("destination, block and resource have to be provided without a transaction hash")
+		}
+		req.Destination = destination
+		req.BlockHeight = new(big.Int).SetUint64(blockHeight)
+		req.ResourceID = resource
+	}
+
+	resp, err := newClient().Retry(req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Enqueued retry %s\n", resp.MessageID)
+	return nil
+}
+
+func sweepProposals(cmd *cobra.Command, args []string) error {
+	resp, err := newClient().Sweep()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Retried %d stuck deposit blocks\n", resp.Retries)
+	return nil
+}
+
+func requeueDeadLetter(cmd *cobra.Command, args []string) error {
+	resp, err := newClient().Requeue(key)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Requeued dead letter %s\n", resp.Key)
+	return nil
+}
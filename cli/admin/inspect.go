@@ -0,0 +1,178 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package admin
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/admin"
+	"github.com/ChainSafe/sygma-relayer/relayer/pause"
+	"github.com/spf13/cobra"
+)
+
+var (
+	domainsCMD = &cobra.Command{
+		Use:   "domains",
+		Short: "List relayed domains and latest processed blocks",
+		RunE:  listDomains,
+	}
+	proposalsCMD = &cobra.Command{
+		Use:   "proposals",
+		Short: "List and search proposals of the running relayer",
+		RunE:  listProposals,
+	}
+	sessionsCMD = &cobra.Command{
+		Use:   "sessions",
+		Short: "List active TSS sessions",
+		RunE:  listSessions,
+	}
+	peersCMD = &cobra.Command{
+		Use:   "peers",
+		Short: "List peers and their connection status",
+		RunE:  listPeers,
+	}
+	pausedCMD = &cobra.Command{
+		Use:   "paused",
+		Short: "List paused domains and resources",
+		RunE:  listPaused,
+	}
+	deadLettersCMD = &cobra.Command{
+		Use:   "dead-letters",
+		Short: "List queued messages that failed delivery too many times",
+		RunE:  listDeadLetters,
+	}
+)
+
+var (
+	status       string
+	source       uint8
+	destination  uint8
+	depositNonce uint64
+	limit        uint32
+)
+
+func init() {
+	proposalsCMD.Flags().StringVar(&status, "status", "", "filter by status (pending, failed, executed, quarantined, reorged)")
+	proposalsCMD.Flags().Uint8Var(&source, "source", 0, "filter by source domain ID, requires destination")
+	proposalsCMD.Flags().Uint8Var(&destination, "destination", 0, "filter by destination domain ID, requires source")
+	proposalsCMD.Flags().Uint64Var(&depositNonce, "nonce", 0, "find the proposal with the deposit nonce, requires source and destination")
+	proposalsCMD.Flags().Uint32Var(&limit, "limit", 100, "maximum number of listed proposals")
+}
+
+func listDomains(cmd *cobra.Command, args []string) error {
+	domains, err := newClient().Domains()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tLATEST BLOCK")
+	for _, d := range domains {
+		fmt.Fprintf(w, "%d\t%s\t%s\n", d.ID, d.Name, d.LatestBlock)
+	}
+	return w.Flush()
+}
+
+func listProposals(cmd *cobra.Command, args []string) error {
+	query := admin.ProposalsQuery{
+		Status:      status,
+		Source:      source,
+		Destination: destination,
+		Limit:       limit,
+	}
+	if cmd.Flags().Changed("nonce") {
+		query.DepositNonce = &depositNonce
+	}
+	proposals, err := newClient().Proposals(query)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SOURCE\tDESTINATION\tNONCE\tSTATUS\tATTEMPTS\tLAST UPDATED\tMESSAGE ID\tSESSIONS\tTX HASH\tLAST ERROR")
+	for _, p := range proposals {
+		fmt.Fprintf(w, "%d\t%d\t%d\t%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			p.Source,
+			p.Destination,
+			p.DepositNonce,
+			p.Status,
+			p.Attempts,
+			formatTime(p.LastUpdated),
+			p.MessageID,
+			strings.Join(p.SessionIDs, ","),
+			p.TxHash,
+			p.LastError,
+		)
+	}
+	return w.Flush()
+}
+
+func listSessions(cmd *cobra.Command, args []string) error {
+	sessions, err := newClient().Sessions()
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		fmt.Println(session)
+	}
+	return nil
+}
+
+func listPeers(cmd *cobra.Command, args []string) error {
+	peers, err := newClient().Peers()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PEER ID\tCONNECTED\tADDRESSES")
+	for _, p := range peers {
+		fmt.Fprintf(w, "%s\t%t\t%s\n", p.ID, p.Connected, strings.Join(p.Addrs, ","))
+	}
+	return w.Flush()
+}
+
+func listPaused(cmd *cobra.Command, args []string) error {
+	pauses, err := newClient().Paused()
+	if err != nil {
+		return err
+	}
+
+	return printPauses(pauses)
+}
+
+func listDeadLetters(cmd *cobra.Command, args []string) error {
+	deadLetters, err := newClient().DeadLetters()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tSOURCE\tDESTINATION\tTYPE\tMESSAGE ID\tFAILURES")
+	for _, d := range deadLetters {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%d\n", d.Key, d.Source, d.Destination, d.Type, d.MessageID, d.Failures)
+	}
+	return w.Flush()
+}
+
+func printPauses(pauses []pause.Pause) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tPAUSED BY\tPAUSED AT")
+	for _, p := range pauses {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", p.Key, p.PausedBy, formatTime(p.PausedAt))
+	}
+	return w.Flush()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,50 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package blockstore
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/ChainSafe/sygma-relayer/relayer/audit"
+	"github.com/ChainSafe/sygma-relayer/store/lvldb"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const AuditComponent = "blockstore"
+
+var BlockstoreCLI = &cobra.Command{
+	Use:   "blockstore",
+	Short: "commands to inspect and edit blocks where domain listeners resume",
+	Long:  "Commands show, set, export and import the blocks domain listeners resume from. Changes are recorded in the audit log. The blockstore is opened directly, so the relayer using it has to be stopped.",
+}
+
+var (
+	auditLogFile string
+)
+
+func init() {
+	BlockstoreCLI.PersistentFlags().StringVar(&auditLogFile, "audit-log", "audit.log", "path to the relayer audit log")
+
+	BlockstoreCLI.AddCommand(showCMD)
+	BlockstoreCLI.AddCommand(setCMD)
+	BlockstoreCLI.AddCommand(exportCMD)
+	BlockstoreCLI.AddCommand(importCMD)
+}
+
+func openBlockstore() (*lvldb.LVLDB, *audit.Log, error) {
+	path := viper.GetString(config.BlockstoreFlagName)
+	db, err := lvldb.NewLvlDB(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to open blockstore %s, make sure the relayer is stopped: %w", path, err)
+	}
+
+	auditLog, err := audit.NewLog(auditLogFile)
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, err
+	}
+	return db, auditLog, nil
+}
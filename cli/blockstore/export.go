@@ -0,0 +1,107 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package blockstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/spf13/cobra"
+	coreStore "github.com/sygmaprotocol/sygma-core/store"
+)
+
+var (
+	exportCMD = &cobra.Command{
+		Use:   "export",
+		Short: "Export stored blocks",
+		Long:  "Export the latest block of every domain as JSON to the file or to stdout",
+		RunE:  exportBlocks,
+	}
+	importCMD = &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import stored blocks",
+		Long:  "Import blocks exported with the export command. Blocks of domains not in the file are kept.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  importBlocks,
+	}
+)
+
+var (
+	exportFile string
+)
+
+func init() {
+	exportCMD.Flags().StringVar(&exportFile, "file", "", "path of the export file, stdout if empty")
+}
+
+func exportBlocks(cmd *cobra.Command, args []string) error {
+	db, auditLog, err := openBlockstore()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	blocks, err := store.StoredBlocks(db)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(blocks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if exportFile == "" {
+		fmt.Println(string(data))
+	} else {
+		err = os.WriteFile(exportFile, data, 0600)
+		if err != nil {
+			return err
+		}
+	}
+	auditLog.Record(AuditComponent, "export", map[string]interface{}{
+		"file":   exportFile,
+		"blocks": blocks,
+	})
+	return nil
+}
+
+func importBlocks(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	blocks := make([]store.StoredBlock, 0)
+	err = json.Unmarshal(data, &blocks)
+	if err != nil {
+		return fmt.Errorf("invalid export file %s: %w", args[0], err)
+	}
+	for _, b := range blocks {
+		if b.Block == nil || b.Block.Sign() < 0 {
+			return fmt.Errorf("invalid block of domain %d", b.DomainID)
+		}
+	}
+
+	db, auditLog, err := openBlockstore()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	blockStore := coreStore.NewBlockStore(db)
+	for _, b := range blocks {
+		err = blockStore.StoreBlock(b.Block, b.DomainID)
+		if err != nil {
+			return err
+		}
+	}
+	auditLog.Record(AuditComponent, "import", map[string]interface{}{
+		"file":   args[0],
+		"blocks": blocks,
+	})
+
+	fmt.Printf("Imported blocks of %d domains\n", len(blocks))
+	return nil
+}
@@ -0,0 +1,88 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package blockstore
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/spf13/cobra"
+	coreStore "github.com/sygmaprotocol/sygma-core/store"
+)
+
+var (
+	showCMD = &cobra.Command{
+		Use:   "show",
+		Short: "Show stored blocks",
+		Long:  "Show the latest block processed by the listener of every domain",
+		RunE:  showBlocks,
+	}
+	setCMD = &cobra.Command{
+		Use:   "set <domain> <block>",
+		Short: "Set the stored block of a domain",
+		Long:  "Set the block from which the listener of the domain resumes on the next start",
+		Args:  cobra.ExactArgs(2),
+		RunE:  setBlock,
+	}
+)
+
+func showBlocks(cmd *cobra.Command, args []string) error {
+	db, auditLog, err := openBlockstore()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	blocks, err := store.StoredBlocks(db)
+	if err != nil {
+		return err
+	}
+	auditLog.Record(AuditComponent, "show", map[string]interface{}{"domains": len(blocks)})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DOMAIN\tBLOCK")
+	for _, b := range blocks {
+		fmt.Fprintf(w, "%d\t%s\n", b.DomainID, b.Block)
+	}
+	return w.Flush()
+}
+
+func setBlock(cmd *cobra.Command, args []string) error {
+	domainID, err := strconv.ParseUint(args[0], 10, 8)
+	if err != nil {
+		return fmt.Errorf("invalid domain ID %s", args[0])
+	}
+	block, ok := new(big.Int).SetString(args[1], 10)
+	if !ok || block.Sign() < 0 {
+		return fmt.Errorf("invalid block %s", args[1])
+	}
+
+	db, auditLog, err := openBlockstore()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	blockStore := coreStore.NewBlockStore(db)
+	previousBlock, err := blockStore.GetLastStoredBlock(uint8(domainID))
+	if err != nil {
+		return err
+	}
+	err = blockStore.StoreBlock(block, uint8(domainID))
+	if err != nil {
+		return err
+	}
+	auditLog.Record(AuditComponent, "set", map[string]interface{}{
+		"domainID":      domainID,
+		"previousBlock": previousBlock.String(),
+		"block":         block.String(),
+	})
+
+	fmt.Printf("Set block of domain %d from %s to %s\n", domainID, previousBlock, block)
+	return nil
+}
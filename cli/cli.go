@@ -8,8 +8,13 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/ChainSafe/sygma-relayer/cli/admin"
+	"github.com/ChainSafe/sygma-relayer/cli/blockstore"
 	"github.com/ChainSafe/sygma-relayer/cli/keygen"
+	"github.com/ChainSafe/sygma-relayer/cli/keyshare"
+	"github.com/ChainSafe/sygma-relayer/cli/limits"
 	"github.com/ChainSafe/sygma-relayer/cli/peer"
+	"github.com/ChainSafe/sygma-relayer/cli/proposals"
 	"github.com/ChainSafe/sygma-relayer/cli/topology"
 	"github.com/ChainSafe/sygma-relayer/cli/utils"
 	"github.com/ChainSafe/sygma-relayer/config"
@@ -31,7 +36,7 @@ func init() {
 }
 
 func Execute() {
-	rootCMD.AddCommand(runCMD, peer.PeerCLI, topology.TopologyCLI, utils.UtilsCLI, keygen.KeygenCLI)
+	rootCMD.AddCommand(runCMD, peer.PeerCLI, topology.TopologyCLI, utils.UtilsCLI, keygen.KeygenCLI, proposals.ProposalsCLI, limits.LimitsCLI, admin.AdminCLI, blockstore.BlockstoreCLI, keyshare.KeyshareCLI)
 	if err := rootCMD.Execute(); err != nil {
 		log.Fatal().Err(err).Msg("failed to execute root cmd")
 	}
@@ -0,0 +1,41 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"strings"
+
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var KeyshareCLI = &cobra.Command{
+	Use:   "keyshare",
+	Short: "commands to inspect relayer keyshares",
+	Long:  "Commands read keyshare and topology files configured in the relayer configuration.",
+}
+
+func init() {
+	KeyshareCLI.AddCommand(infoCMD)
+}
+
+// loadConfig loads the relayer configuration the same way the relayer does on start
+func loadConfig() (*config.Config, error) {
+	var configuration *config.Config
+	var err error
+	configURL := viper.GetString("config-url")
+	if configURL != "" {
+		configuration, err = config.GetSharedConfigFromNetwork(configURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	configFlag := viper.GetString(config.ConfigFlagName)
+	if strings.ToLower(configFlag) == "env" {
+		return config.GetConfigFromENV(configuration)
+	}
+	return config.GetConfigFromFile(configFlag, configuration)
+}
@@ -0,0 +1,157 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/ChainSafe/sygma-relayer/chains/btc/config"
+	"github.com/ChainSafe/sygma-relayer/cli/utils"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/topology"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/spf13/cobra"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+)
+
+var (
+	infoCMD = &cobra.Command{
+		Use:   "info",
+		Short: "Print what the relayer keyshares control",
+		Long:  "Print threshold and committee peers of the ECDSA and FROST keyshares with their public keys and derived EVM, SS58 and P2TR addresses",
+		RunE:  printInfo,
+	}
+)
+
+var (
+	ss58NetworkID uint16
+)
+
+func init() {
+	infoCMD.Flags().Uint16Var(&ss58NetworkID, "ss58-network", 42, "network id of the SS58 address. Registry https://github.com/paritytech/ss58-registry/blob/main/ss58-registry.json")
+}
+
+func printInfo(cmd *cobra.Command, args []string) error {
+	configuration, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	mpcConfig := configuration.RelayerConfig.MpcConfig
+
+	var topologyPeers []peer.ID
+	networkTopology, err := topology.NewTopologyStore(mpcConfig.TopologyConfiguration.Path).Topology()
+	if err != nil {
+		fmt.Printf("WARNING: unable to read stored topology: %s\n\n", err)
+	} else {
+		for _, p := range networkTopology.Peers {
+			topologyPeers = append(topologyPeers, p.ID)
+		}
+	}
+
+	fmt.Printf("ECDSA keyshare (%s)\n", mpcConfig.KeysharePath)
+	ecdsaKeyshare, err := keyshare.NewECDSAKeyshareStore(mpcConfig.KeysharePath).GetKeyshare()
+	if err != nil {
+		fmt.Printf("  unavailable: %s\n", err)
+	} else {
+		printCommittee(ecdsaKeyshare.Threshold, ecdsaKeyshare.Peers, topologyPeers)
+		publicKey := ecdsaKeyshare.Key.ECDSAPub.ToBtcecPubKey()
+		compressedKey := publicKey.SerializeCompressed()
+		ss58Address, err := utils.SS58AddressFromPublicKey(compressedKey, ss58NetworkID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  Public key: 0x%x\n", compressedKey)
+		fmt.Printf("  EVM address: %s\n", crypto.PubkeyToAddress(*publicKey.ToECDSA()))
+		fmt.Printf("  SS58 address (network %d): %s\n", ss58NetworkID, ss58Address)
+	}
+
+	fmt.Printf("\nFROST keyshare (%s)\n", mpcConfig.FrostKeysharePath)
+	frostKeyshare, err := keyshare.NewFrostKeyshareStore(mpcConfig.FrostKeysharePath).GetKeyshare()
+	if err != nil {
+		fmt.Printf("  unavailable: %s\n", err)
+		return nil
+	}
+	printCommittee(frostKeyshare.Threshold, frostKeyshare.Peers, topologyPeers)
+	fmt.Printf("  Taproot public key: %x\n", []byte(frostKeyshare.Key.PublicKey))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  DOMAIN\tRESOURCE\tP2TR ADDRESS\tCONFIGURED ADDRESS")
+	for _, chainConfig := range configuration.ChainConfigs {
+		if chainConfig["type"] != "btc" {
+			continue
+		}
+		btcConfig, err := config.NewBtcConfig(chainConfig)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range btcConfig.Resources {
+			address, err := tweakedAddress(frostKeyshare, resource.Tweak, &btcConfig.Network)
+			if err != nil {
+				address = fmt.Sprintf("invalid tweak: %s", err)
+			}
+			fmt.Fprintf(w, "  %d\t%x\t%s\t%s\n", *btcConfig.GeneralChainConfig.Id, resource.ResourceID, address, resource.Address)
+		}
+	}
+	return w.Flush()
+}
+
+// tweakedAddress returns the P2TR address of the FROST public key tweaked with the resource tweak
+func tweakedAddress(frostKeyshare keyshare.FrostKeyshare, tweak string, network *chaincfg.Params) (string, error) {
+	tweakBytes, err := hex.DecodeString(tweak)
+	if err != nil {
+		return "", err
+	}
+	h := &curve.Secp256k1Scalar{}
+	err = h.UnmarshalBinary(tweakBytes)
+	if err != nil {
+		return "", err
+	}
+	key, err := frostKeyshare.Key.Derive(h, nil)
+	if err != nil {
+		return "", err
+	}
+
+	address, err := btcutil.NewAddressTaproot(key.PublicKey, network)
+	if err != nil {
+		return "", err
+	}
+	return address.EncodeAddress(), nil
+}
+
+func printCommittee(threshold int, peers []peer.ID, topologyPeers []peer.ID) {
+	fmt.Printf("  Threshold: %d\n", threshold)
+	fmt.Printf("  Peers:\n")
+	for _, p := range peers {
+		fmt.Printf("    %s\n", p)
+	}
+
+	if topologyPeers != nil && !samePeers(peers, topologyPeers) {
+		fmt.Printf("  WARNING: keyshare peers do not match the stored topology\n")
+	}
+}
+
+func samePeers(a []peer.ID, b []peer.ID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sortedA := append([]peer.ID{}, a...)
+	sortedB := append([]peer.ID{}, b...)
+	sort.Slice(sortedA, func(i, j int) bool { return sortedA[i] < sortedA[j] })
+	sort.Slice(sortedB, func(i, j int) bool { return sortedB[i] < sortedB[j] })
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
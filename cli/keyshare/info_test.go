@@ -0,0 +1,93 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/suite"
+)
+
+type InfoTestSuite struct {
+	suite.Suite
+	frostKeyshare keyshare.FrostKeyshare
+	peers         []peer.ID
+}
+
+func TestRunInfoTestSuite(t *testing.T) {
+	suite.Run(t, new(InfoTestSuite))
+}
+
+func (s *InfoTestSuite) SetupTest() {
+	frostKeyshare, err := keyshare.NewFrostKeyshareStore("../../example/cfg/keyshares/0-frost.keyshare").GetKeyshare()
+	s.Nil(err)
+	s.frostKeyshare = frostKeyshare
+	s.peers = []peer.ID{peer.ID("peer1"), peer.ID("peer2"), peer.ID("peer3")}
+}
+
+func (s *InfoTestSuite) captureOutput(f func()) string {
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	s.Nil(err)
+	os.Stdout = w
+	f()
+	os.Stdout = stdout
+	_ = w.Close()
+
+	output, err := io.ReadAll(r)
+	s.Nil(err)
+	return string(output)
+}
+
+func (s *InfoTestSuite) Test_TweakedAddress_MatchesConfiguredAddress() {
+	address, err := tweakedAddress(
+		s.frostKeyshare,
+		"c82aa6ae534bb28aaafeb3660c31d6a52e187d8f05d48bb6bdb9b733a9b42212",
+		&chaincfg.RegressionNetParams)
+
+	s.Nil(err)
+	s.Equal(address, "bcrt1pdf5c3q35ssem2l25n435fa69qr7dzwkc6gsqehuflr3euh905l2sjyr5ek")
+}
+
+func (s *InfoTestSuite) Test_TweakedAddress_InvalidTweak() {
+	_, err := tweakedAddress(s.frostKeyshare, "invalid", &chaincfg.RegressionNetParams)
+
+	s.NotNil(err)
+}
+
+func (s *InfoTestSuite) Test_SamePeers() {
+	s.True(samePeers(s.peers, []peer.ID{s.peers[2], s.peers[0], s.peers[1]}))
+	s.False(samePeers(s.peers, s.peers[:2]))
+	s.False(samePeers(s.peers, []peer.ID{s.peers[0], s.peers[1], peer.ID("peer4")}))
+}
+
+func (s *InfoTestSuite) Test_PrintCommittee_WarnsOnTopologyMismatch() {
+	output := s.captureOutput(func() {
+		printCommittee(2, s.peers, s.peers[:2])
+	})
+
+	s.Contains(output, "WARNING: keyshare peers do not match the stored topology")
+}
+
+func (s *InfoTestSuite) Test_PrintCommittee_MatchingTopology() {
+	output := s.captureOutput(func() {
+		printCommittee(2, s.peers, []peer.ID{s.peers[1], s.peers[2], s.peers[0]})
+	})
+
+	s.Contains(output, "Threshold: 2")
+	s.NotContains(output, "WARNING")
+}
+
+func (s *InfoTestSuite) Test_PrintCommittee_MissingTopology() {
+	output := s.captureOutput(func() {
+		printCommittee(2, s.peers, nil)
+	})
+
+	s.NotContains(output, "WARNING")
+}
@@ -0,0 +1,36 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package limits
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/relayer/limits"
+	"github.com/ChainSafe/sygma-relayer/store/lvldb"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var LimitsCLI = &cobra.Command{
+	Use:   "limits",
+	Short: "commands to inspect and release transfers halted by volume limits",
+	Long:  "Commands list resources and routes halted by the volume limit circuit breaker and release them. The blockstore is opened directly, so the relayer using it has to be stopped.",
+}
+
+func init() {
+	LimitsCLI.AddCommand(listCMD)
+	LimitsCLI.AddCommand(releaseCMD)
+}
+
+func openVolumeLimiter() (*limits.VolumeLimiter, *lvldb.LVLDB, error) {
+	path := viper.GetString(config.BlockstoreFlagName)
+	db, err := lvldb.NewLvlDB(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to open blockstore %s, make sure the relayer is stopped: %w", path, err)
+	}
+
+	return limits.NewVolumeLimiter(db, relayer.VolumeLimitsConfig{}), db, nil
+}
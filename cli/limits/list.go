@@ -0,0 +1,55 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package limits
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	listCMD = &cobra.Command{
+		Use:   "list",
+		Short: "List halted resources and routes",
+		Long:  "List resources and routes halted because they exceeded configured volume limits",
+		RunE:  listHalts,
+	}
+)
+
+func listHalts(cmd *cobra.Command, args []string) error {
+	limiter, db, err := openVolumeLimiter()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	halts, err := limiter.Halts()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RESOURCE\tSOURCE\tDESTINATION\tHALTED AT\tREASON")
+	for _, halt := range halts {
+		source := "-"
+		destination := "-"
+		if halt.Route {
+			source = fmt.Sprint(halt.Source)
+			destination = fmt.Sprint(halt.Destination)
+		}
+
+		fmt.Fprintf(w, "%x\t%s\t%s\t%s\t%s\n",
+			halt.ResourceID,
+			source,
+			destination,
+			halt.HaltedAt.UTC().Format(time.RFC3339),
+			halt.Reason,
+		)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,65 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package limits
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	releaseCMD = &cobra.Command{
+		Use:   "release",
+		Short: "Release a halted resource or route",
+		Long:  "Release a resource or route halted by volume limits and reset its rolling volume",
+		RunE:  releaseHalt,
+	}
+)
+
+var (
+	resource    string
+	source      uint8
+	destination uint8
+)
+
+func init() {
+	releaseCMD.PersistentFlags().StringVar(&resource, "resource", "", "hex encoded resource ID of the halted resource")
+	releaseCMD.PersistentFlags().Uint8Var(&source, "source", 0, "source domain ID of the halted route, requires destination")
+	releaseCMD.PersistentFlags().Uint8Var(&destination, "destination", 0, "destination domain ID of the halted route, requires source")
+	_ = releaseCMD.MarkPersistentFlagRequired("resource")
+}
+
+func releaseHalt(cmd *cobra.Command, args []string) error {
+	if (source == 0) != (destination == 0) {
+		return fmt.Errorf("source and destination have to be provided together")
+	}
+
+	var resourceID [32]byte
+	rBytes, err := hex.DecodeString(strings.TrimPrefix(resource, "0x"))
+	if err != nil || len(rBytes) != len(resourceID) {
+		return fmt.Errorf("invalid resource ID %s", resource)
+	}
+	copy(resourceID[:], rBytes)
+
+	limiter, db, err := openVolumeLimiter()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if source != 0 {
+		err = limiter.ReleaseRoute(source, destination, resourceID)
+	} else {
+		err = limiter.ReleaseResource(resourceID)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Released %x\n", resourceID)
+	return nil
+}
@@ -15,4 +15,5 @@ var PeerCLI = &cobra.Command{
 func init() {
 	PeerCLI.AddCommand(peerInfoCMD)
 	PeerCLI.AddCommand(generateKeyCMD)
+	PeerCLI.AddCommand(pingCMD)
 }
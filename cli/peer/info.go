@@ -29,13 +29,17 @@ func init() {
 	_ = peerInfoCMD.MarkFlagRequired("private-key")
 }
 
-func peerInfo(cmd *cobra.Command, args []string) error {
+func decodePrivateKey() (crypto.PrivKey, error) {
 	privBytes, err := crypto.ConfigDecodeKey(privateKey)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	priv, err := crypto.UnmarshalPrivateKey(privBytes)
+	return crypto.UnmarshalPrivateKey(privBytes)
+}
+
+func peerInfo(cmd *cobra.Command, args []string) error {
+	priv, err := decodePrivateKey()
 	if err != nil {
 		return err
 	}
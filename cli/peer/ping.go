@@ -0,0 +1,127 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package peer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/comm/p2p"
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/topology"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pingCMD = &cobra.Command{
+		Use:   "ping",
+		Short: "Check connectivity to topology peers",
+		Long: "Create a temporary libp2p host from the private key and dial every peer of the topology " +
+			"with the same connection rules as the relayer. DNS addresses are resolved and every address " +
+			"is tried until one of them connects.",
+		RunE: pingPeers,
+	}
+)
+
+var (
+	url           string
+	hash          string
+	decryptionKey string
+	topologyPath  string
+	timeout       time.Duration
+)
+
+func init() {
+	pingCMD.Flags().StringVar(&privateKey, "private-key", "", "Base64 encoded libp2p private key")
+	_ = pingCMD.MarkFlagRequired("private-key")
+	pingCMD.Flags().StringVar(&url, "url", "", "url to fetch topology")
+	pingCMD.Flags().StringVar(&decryptionKey, "decryption-key", "", "password to decrypt topology")
+	pingCMD.Flags().StringVar(&hash, "hash", "", "hash of topology")
+	pingCMD.Flags().StringVar(&topologyPath, "topology-path", "", "path to the stored topology used instead of the url")
+	pingCMD.Flags().DurationVar(&timeout, "timeout", time.Second*10, "timeout of resolving and dialing a single address")
+}
+
+func loadTopology() (*topology.NetworkTopology, error) {
+	if topologyPath != "" {
+		return topology.NewTopologyStore(topologyPath).Topology()
+	}
+	if url == "" || decryptionKey == "" {
+		return nil, fmt.Errorf("either topology path or url and decryption key have to be provided")
+	}
+
+	nt, err := topology.NewNetworkTopologyProvider(relayer.TopologyConfiguration{
+		EncryptionKey: decryptionKey,
+		Url:           url,
+	}, http.DefaultClient)
+	if err != nil {
+		return nil, err
+	}
+	return nt.NetworkTopology(hash)
+}
+
+func pingPeers(cmd *cobra.Command, args []string) error {
+	priv, err := decodePrivateKey()
+	if err != nil {
+		return err
+	}
+	networkTopology, err := loadTopology()
+	if err != nil {
+		return err
+	}
+
+	h, err := p2p.NewHost(priv, networkTopology, p2p.NewConnectionGate(networkTopology), 0)
+	if err != nil {
+		return err
+	}
+	defer h.Close()
+
+	if !networkTopology.IsAllowedPeer(h.ID()) {
+		fmt.Printf("WARNING: peer %s is not part of the topology, peers will refuse the connection\n\n", h.ID().Pretty())
+	}
+
+	probes := []p2p.PeerProbe{}
+	for _, p := range networkTopology.Peers {
+		if p.ID == h.ID() {
+			continue
+		}
+
+		probes = append(probes, p2p.ProbePeer(context.Background(), h, peer.AddrInfo{
+			ID:    p.ID,
+			Addrs: p.Addrs,
+		}, timeout))
+	}
+
+	unreachable := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PEER\tSTATUS\tLATENCY\tADDRESS\tPROTOCOLS")
+	for _, probe := range probes {
+		if !probe.Reachable {
+			unreachable++
+			fmt.Fprintf(w, "%s\tunreachable\t-\t-\t%s\n", probe.ID.Pretty(), probe.Err)
+			continue
+		}
+
+		fmt.Fprintf(w, "%s\treachable\t%s\t%s\t%s\n",
+			probe.ID.Pretty(),
+			probe.Latency.Round(time.Microsecond),
+			probe.Address,
+			strings.Join(probe.Protocols, ","),
+		)
+	}
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+
+	if unreachable > 0 {
+		return fmt.Errorf("%d of %d peers unreachable", unreachable, len(probes))
+	}
+	return nil
+}
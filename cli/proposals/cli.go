@@ -0,0 +1,41 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package proposals
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/ChainSafe/sygma-relayer/store/lvldb"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var ProposalsCLI = &cobra.Command{
+	Use:   "proposals",
+	Short: "commands to inspect and prune proposals stored in the relayer blockstore",
+	Long:  "Commands list proposal records with their execution status and prune executed ones. The blockstore is opened directly, so the relayer using it has to be stopped.",
+}
+
+func init() {
+	ProposalsCLI.AddCommand(listCMD)
+	ProposalsCLI.AddCommand(pruneCMD)
+}
+
+func openPropStore() (*store.PropStore, *lvldb.LVLDB, error) {
+	path := viper.GetString(config.BlockstoreFlagName)
+	db, err := lvldb.NewLvlDB(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to open blockstore %s, make sure the relayer is stopped: %w", path, err)
+	}
+
+	propStore := store.NewPropStore(db)
+	err = propStore.Migrate()
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, err
+	}
+	return propStore, db, nil
+}
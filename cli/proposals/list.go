@@ -0,0 +1,108 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package proposals
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listCMD = &cobra.Command{
+		Use:   "list",
+		Short: "List stored proposals",
+		Long:  "List stored proposals filtered by status, route and age",
+		RunE:  listProposals,
+	}
+)
+
+var (
+	status      string
+	source      uint8
+	destination uint8
+	olderThan   time.Duration
+	limit       int
+)
+
+func init() {
+	listCMD.PersistentFlags().StringVar(&status, "status", "", "filter by status (pending, failed, executed, quarantined, reorged)")
+	listCMD.PersistentFlags().Uint8Var(&source, "source", 0, "filter by source domain ID, requires destination")
+	listCMD.PersistentFlags().Uint8Var(&destination, "destination", 0, "filter by destination domain ID, requires source")
+	listCMD.PersistentFlags().DurationVar(&olderThan, "older-than", 0, "list only proposals not updated for the duration, for example 24h")
+	listCMD.PersistentFlags().IntVar(&limit, "limit", 100, "maximum number of listed proposals, 0 lists all")
+}
+
+func listProposals(cmd *cobra.Command, args []string) error {
+	if (source == 0) != (destination == 0) {
+		return fmt.Errorf("source and destination have to be provided together")
+	}
+
+	propStore, db, err := openPropStore()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SOURCE\tDESTINATION\tNONCE\tSTATUS\tATTEMPTS\tFIRST SEEN\tLAST UPDATED\tMESSAGE ID\tSESSIONS\tTX HASH\tLAST ERROR")
+
+	count := 0
+	cutoff := time.Now().Add(-olderThan)
+	errLimitReached := fmt.Errorf("limit reached")
+	printProp := func(key store.PropKey, record *store.PropRecord) error {
+		if status != "" && record.Status != store.PropStatus(status) {
+			return nil
+		}
+		if olderThan != 0 && record.LastUpdated.After(cutoff) {
+			return nil
+		}
+		if limit != 0 && count >= limit {
+			return errLimitReached
+		}
+
+		count++
+		fmt.Fprintf(w, "%d\t%d\t%d\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			key.Source,
+			key.Destination,
+			key.DepositNonce,
+			record.Status,
+			record.Attempts,
+			formatTime(record.FirstSeen),
+			formatTime(record.LastUpdated),
+			record.MessageID,
+			strings.Join(record.SessionIDs, ","),
+			record.TxHash,
+			record.LastError,
+		)
+		return nil
+	}
+
+	switch {
+	case source != 0:
+		err = propStore.PropsByRoute(source, destination, printProp)
+	case status != "":
+		err = propStore.PropsByStatus(store.PropStatus(status), printProp)
+	default:
+		err = propStore.IterateProps(printProp)
+	}
+	if err != nil && !errors.Is(err, errLimitReached) {
+		return err
+	}
+
+	return w.Flush()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,45 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package proposals
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	pruneCMD = &cobra.Command{
+		Use:   "prune",
+		Short: "Prune executed proposals",
+		Long:  "Delete executed proposal records older than the provided number of days",
+		RunE:  pruneProposals,
+	}
+)
+
+var (
+	days uint64
+)
+
+func init() {
+	pruneCMD.PersistentFlags().Uint64Var(&days, "days", 0, "delete executed proposals older than the number of days")
+	_ = pruneCMD.MarkPersistentFlagRequired("days")
+}
+
+func pruneProposals(cmd *cobra.Command, args []string) error {
+	propStore, db, err := openPropStore()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	pruned, err := propStore.PruneExecutedProps(time.Now().Add(-time.Duration(days) * 24 * time.Hour))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Pruned %d executed proposals older than %d days\n", pruned, days)
+	return nil
+}
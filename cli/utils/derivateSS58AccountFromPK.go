@@ -8,6 +8,8 @@ import (
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	"github.com/spf13/cobra"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
 )
 
 var (
@@ -40,3 +42,14 @@ func derivateSS58(cmd *cobra.Command, args []string) error {
 	fmt.Println(account.Address)
 	return nil
 }
+
+// SS58AddressFromPublicKey returns SS58 formatted address of the account
+// controlled by the compressed ECDSA public key
+func SS58AddressFromPublicKey(publicKey []byte, networkID uint16) (string, error) {
+	key, err := ecdsa.Scheme{}.FromPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	return subkey.SS58Encode(key.AccountID(), networkID), nil
+}
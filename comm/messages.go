@@ -33,6 +33,12 @@ const (
 	CoordinatorPingMsg
 	// CoordinatorPingResponseMsg message type used to respond on CoordinatorPingMsg message.
 	CoordinatorPingResponseMsg
+	// SweepMsg message type sent by the sweep leader with stuck proposals that should be retried.
+	SweepMsg
+	// PauseMsg message type sent by relayers that pause or resume relaying so peers can pause together.
+	PauseMsg
+	// FundingMsg message type sent periodically by relayers with domains they cannot fund execution transactions on.
+	FundingMsg
 	// Unknown message type
 	Unknown
 )
@@ -66,6 +72,12 @@ func (msgType MessageType) String() string {
 		return "CoordinatorPingMsg"
 	case CoordinatorPingResponseMsg:
 		return "CoordinatorPingResponseMsg"
+	case SweepMsg:
+		return "SweepMsg"
+	case PauseMsg:
+		return "PauseMsg"
+	case FundingMsg:
+		return "FundingMsg"
 	default:
 		return "UnknownMsg"
 	}
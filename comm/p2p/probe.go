@@ -0,0 +1,125 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/identify"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+	ma "github.com/multiformats/go-multiaddr"
+	madns "github.com/multiformats/go-multiaddr-dns"
+)
+
+// PeerProbe is the result of dialing a single peer
+type PeerProbe struct {
+	ID        peer.ID
+	Reachable bool
+	// Address is the resolved address the connection was established on
+	Address   ma.Multiaddr
+	Latency   time.Duration
+	Protocols []string
+	Err       error
+}
+
+type identifyingHost interface {
+	IDService() identify.IDService
+}
+
+// ProbePeer resolves addresses of the peer and dials them one by one until a connection
+// is established. Latency is measured with the libp2p ping protocol and supported protocols
+// are read after the identify exchange completes.
+// The dial is subject to the connection gater of the host.
+func ProbePeer(ctx context.Context, h host.Host, p peer.AddrInfo, timeout time.Duration) PeerProbe {
+	probe := PeerProbe{ID: p.ID}
+	if len(p.Addrs) == 0 {
+		probe.Err = fmt.Errorf("peer %s has no defined addresses", p.ID.Pretty())
+		return probe
+	}
+
+	for _, addr := range p.Addrs {
+		resolved, err := resolveAddr(ctx, addr, timeout)
+		if err != nil {
+			probe.Err = err
+			continue
+		}
+
+		for _, resolvedAddr := range resolved {
+			err = dialAddr(ctx, h, p.ID, resolvedAddr, timeout, &probe)
+			if err != nil {
+				probe.Err = fmt.Errorf("%s: %w", resolvedAddr, err)
+				continue
+			}
+
+			probe.Reachable = true
+			probe.Address = resolvedAddr
+			probe.Err = nil
+			return probe
+		}
+	}
+	return probe
+}
+
+func resolveAddr(ctx context.Context, addr ma.Multiaddr, timeout time.Duration) ([]ma.Multiaddr, error) {
+	if !madns.Matches(addr) {
+		return []ma.Multiaddr{addr}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	resolved, err := madns.DefaultResolver.Resolve(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve %s: %w", addr, err)
+	}
+	if len(resolved) == 0 {
+		return nil, fmt.Errorf("%s resolved to no addresses", addr)
+	}
+	return resolved, nil
+}
+
+func dialAddr(ctx context.Context, h host.Host, id peer.ID, addr ma.Multiaddr, timeout time.Duration, probe *PeerProbe) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// only the probed address is kept so the dial does not fall back to other addresses
+	_ = h.Network().ClosePeer(id)
+	h.Peerstore().ClearAddrs(id)
+
+	start := time.Now()
+	err := h.Connect(ctx, peer.AddrInfo{ID: id, Addrs: []ma.Multiaddr{addr}})
+	if err != nil {
+		return err
+	}
+	probe.Latency = time.Since(start)
+
+	result := <-ping.Ping(ctx, h, id)
+	if result.Error == nil {
+		probe.Latency = result.RTT
+	}
+
+	if ih, ok := h.(identifyingHost); ok {
+		for _, conn := range h.Network().ConnsToPeer(id) {
+			waitIdentify(ctx, ih, conn)
+		}
+	}
+	protocols, err := h.Peerstore().GetProtocols(id)
+	if err == nil {
+		sort.Strings(protocols)
+		probe.Protocols = protocols
+	}
+	return nil
+}
+
+func waitIdentify(ctx context.Context, h identifyingHost, conn network.Conn) {
+	select {
+	case <-h.IDService().IdentifyWait(conn):
+	case <-ctx.Done():
+	}
+}
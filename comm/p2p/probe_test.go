@@ -0,0 +1,101 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package p2p_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/comm/p2p"
+	"github.com/ChainSafe/sygma-relayer/topology"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProbePeerTestSuite struct {
+	suite.Suite
+	hosts    []host.Host
+	topology *topology.NetworkTopology
+}
+
+func TestRunProbePeerTestSuite(t *testing.T) {
+	suite.Run(t, new(ProbePeerTestSuite))
+}
+
+func (s *ProbePeerTestSuite) SetupTest() {
+	keys := []crypto.PrivKey{}
+	placeholderAddr, _ := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/1")
+	s.topology = &topology.NetworkTopology{}
+	for i := 0; i < 2; i++ {
+		privKey, _, err := crypto.GenerateKeyPair(crypto.ECDSA, 0)
+		s.Nil(err)
+		keys = append(keys, privKey)
+		id, err := peer.IDFromPrivateKey(privKey)
+		s.Nil(err)
+		s.topology.Peers = append(s.topology.Peers, &peer.AddrInfo{ID: id, Addrs: []ma.Multiaddr{placeholderAddr}})
+	}
+
+	s.hosts = []host.Host{}
+	for _, key := range keys {
+		h, err := p2p.NewHost(key, s.topology, p2p.NewConnectionGate(s.topology), 0)
+		s.Nil(err)
+		s.hosts = append(s.hosts, h)
+	}
+}
+
+func (s *ProbePeerTestSuite) TearDownTest() {
+	for _, h := range s.hosts {
+		_ = h.Close()
+	}
+}
+
+func (s *ProbePeerTestSuite) port() string {
+	port, err := s.hosts[1].Addrs()[0].ValueForProtocol(ma.P_TCP)
+	s.Nil(err)
+	return port
+}
+
+func (s *ProbePeerTestSuite) Test_ProbePeer_Reachable() {
+	unreachableAddr, _ := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/1")
+	addr, _ := ma.NewMultiaddr(fmt.Sprintf("/dns4/localhost/tcp/%s", s.port()))
+
+	probe := p2p.ProbePeer(context.Background(), s.hosts[0], peer.AddrInfo{
+		ID:    s.hosts[1].ID(),
+		Addrs: []ma.Multiaddr{unreachableAddr, addr},
+	}, time.Second*5)
+
+	s.Nil(probe.Err)
+	s.True(probe.Reachable)
+	s.Equal(probe.Address.String(), fmt.Sprintf("/ip4/127.0.0.1/tcp/%s", s.port()))
+	s.NotZero(probe.Latency)
+	s.Contains(probe.Protocols, "/ipfs/ping/1.0.0")
+}
+
+func (s *ProbePeerTestSuite) Test_ProbePeer_NotInTopology() {
+	privKey, _, _ := crypto.GenerateKeyPair(crypto.ECDSA, 0)
+	h, err := p2p.NewHost(privKey, s.topology, p2p.NewConnectionGate(s.topology), 0)
+	s.Nil(err)
+	defer h.Close()
+	addr, _ := ma.NewMultiaddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%s", s.port()))
+
+	probe := p2p.ProbePeer(context.Background(), h, peer.AddrInfo{
+		ID:    s.hosts[1].ID(),
+		Addrs: []ma.Multiaddr{addr},
+	}, time.Second*5)
+
+	s.False(probe.Reachable)
+	s.NotNil(probe.Err)
+}
+
+func (s *ProbePeerTestSuite) Test_ProbePeer_NoAddresses() {
+	probe := p2p.ProbePeer(context.Background(), s.hosts[0], peer.AddrInfo{ID: s.hosts[1].ID()}, time.Second)
+
+	s.False(probe.Reachable)
+	s.NotNil(probe.Err)
+}
@@ -42,11 +42,21 @@ func (c *GeneralChainConfig) ParseFlags() {
 		c.BlockstorePath = blockstore
 	}
 	freshStart := viper.GetBool(config.FreshStartFlagName)
-	if freshStart {
-		c.FreshStart = freshStart
+	if freshStart || c.listed(viper.GetIntSlice(config.FreshDomainsFlagName)) {
+		c.FreshStart = true
 	}
 	latestBlock := viper.GetBool(config.LatestBlockFlagName)
-	if latestBlock {
-		c.LatestBlock = latestBlock
+	if latestBlock || c.listed(viper.GetIntSlice(config.LatestDomainsFlagName)) {
+		c.LatestBlock = true
 	}
 }
+
+// listed returns true if the domain ID of the chain is in the list of domain IDs
+func (c *GeneralChainConfig) listed(domainIDs []int) bool {
+	for _, domainID := range domainIDs {
+		if c.Id != nil && domainID == int(*c.Id) {
+			return true
+		}
+	}
+	return false
+}
@@ -5,6 +5,9 @@ package chain
 
 import (
 	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/spf13/viper"
 )
 
 func TestValidateConfig(t *testing.T) {
@@ -52,3 +55,23 @@ func TestValidateConfig(t *testing.T) {
 		t.Fatalf("must require domain id field, %v", err)
 	}
 }
+
+func TestParseFlags_PerDomain(t *testing.T) {
+	var id uint8 = 2
+	listed := GeneralChainConfig{Id: &id}
+	var otherID uint8 = 3
+	notListed := GeneralChainConfig{Id: &otherID}
+	viper.Set(config.FreshDomainsFlagName, []int{1, 2})
+	viper.Set(config.LatestDomainsFlagName, []int{2})
+	defer viper.Reset()
+
+	listed.ParseFlags()
+	notListed.ParseFlags()
+
+	if !listed.FreshStart || !listed.LatestBlock {
+		t.Fatalf("listed domain must start fresh from the latest block, %+v", listed)
+	}
+	if notListed.FreshStart || notListed.LatestBlock {
+		t.Fatalf("domain not listed must load from blockstore, %+v", notListed)
+	}
+}
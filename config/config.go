@@ -164,11 +164,13 @@ func compareDomainID(a, b interface{}) bool {
 
 var (
 	// Flags for running the app
-	ConfigFlagName      = "config"
-	KeystoreFlagName    = "keystore"
-	BlockstoreFlagName  = "blockstore"
-	FreshStartFlagName  = "fresh"
-	LatestBlockFlagName = "latest"
+	ConfigFlagName        = "config"
+	KeystoreFlagName      = "keystore"
+	BlockstoreFlagName    = "blockstore"
+	FreshStartFlagName    = "fresh"
+	LatestBlockFlagName   = "latest"
+	FreshDomainsFlagName  = "fresh-domains"
+	LatestDomainsFlagName = "latest-domains"
 )
 
 func BindFlags(rootCMD *cobra.Command) {
@@ -184,6 +186,12 @@ func BindFlags(rootCMD *cobra.Command) {
 	rootCMD.PersistentFlags().Bool(LatestBlockFlagName, false, "Overrides blockstore and start block, starts from latest block (default: false)")
 	_ = viper.BindPFlag(LatestBlockFlagName, rootCMD.PersistentFlags().Lookup(LatestBlockFlagName))
 
+	rootCMD.PersistentFlags().IntSlice(FreshDomainsFlagName, []int{}, "Disables loading from blockstore at start for the listed domain IDs (default: none)")
+	_ = viper.BindPFlag(FreshDomainsFlagName, rootCMD.PersistentFlags().Lookup(FreshDomainsFlagName))
+
+	rootCMD.PersistentFlags().IntSlice(LatestDomainsFlagName, []int{}, "Starts the listed domain IDs from the latest block (default: none)")
+	_ = viper.BindPFlag(LatestDomainsFlagName, rootCMD.PersistentFlags().Lookup(LatestDomainsFlagName))
+
 	rootCMD.PersistentFlags().String(KeystoreFlagName, "./keys", "Path to keystore directory")
 	_ = viper.BindPFlag(KeystoreFlagName, rootCMD.PersistentFlags().Lookup(KeystoreFlagName))
 }
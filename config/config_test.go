@@ -5,6 +5,7 @@ package config_test
 
 import (
 	"encoding/json"
+	"math/big"
 	"os"
 	"testing"
 	"time"
@@ -87,11 +88,12 @@ func (s *GetConfigTestSuite) Test_GetConfigFromENV() {
 
 	s.Equal(config.Config{
 		RelayerConfig: relayer.RelayerConfig{
-			LogLevel:   1,
-			LogFile:    "out.log",
-			Env:        "TEST",
-			Id:         "123",
-			HealthPort: 9001,
+			LogLevel:     1,
+			LogFile:      "out.log",
+			AuditLogFile: "audit.log",
+			Env:          "TEST",
+			Id:           "123",
+			HealthPort:   9001,
 			MpcConfig: relayer.MpcRelayerConfig{
 				TopologyConfiguration: relayer.TopologyConfiguration{
 					EncryptionKey: "test-enc-key",
@@ -204,11 +206,12 @@ func (s *GetConfigTestSuite) Test_GetConfigFromENV_RandomOrder() {
 
 	s.Equal(config.Config{
 		RelayerConfig: relayer.RelayerConfig{
-			LogLevel:   1,
-			LogFile:    "out.log",
-			Env:        "TEST",
-			Id:         "123",
-			HealthPort: 9001,
+			LogLevel:     1,
+			LogFile:      "out.log",
+			AuditLogFile: "audit.log",
+			Env:          "TEST",
+			Id:           "123",
+			HealthPort:   9001,
 			MpcConfig: relayer.MpcRelayerConfig{
 				TopologyConfiguration: relayer.TopologyConfiguration{
 					EncryptionKey: "test-enc-key",
@@ -486,6 +489,7 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 				RelayerConfig: relayer.RelayerConfig{
 					LogLevel:                  1,
 					LogFile:                   "out.log",
+					AuditLogFile:              "audit.log",
 					OpenTelemetryCollectorURL: "",
 					HealthPort:                9001,
 					MpcConfig: relayer.MpcRelayerConfig{
@@ -522,9 +526,10 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 			name: "valid config",
 			inConfig: config.RawConfig{
 				RelayerConfig: relayer.RawRelayerConfig{
-					LogLevel:   "debug",
-					LogFile:    "custom.log",
-					HealthPort: "9002",
+					LogLevel:     "debug",
+					LogFile:      "custom.log",
+					AuditLogFile: "custom-audit.log",
+					HealthPort:   "9002",
 					MpcConfig: relayer.RawMpcRelayerConfig{
 						TopologyConfiguration: relayer.TopologyConfiguration{
 							EncryptionKey: "enc-key",
@@ -535,6 +540,7 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 						KeysharePath:            "./share.key",
 						Key:                     "./key.pk",
 						CommHealthCheckInterval: "10m",
+						RequireMessageHashes:    true,
 					},
 					BullyConfig: relayer.RawBullyConfig{
 						PingWaitTime:     "1s",
@@ -549,6 +555,15 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 						MaxRetries:     5,
 						MaxElapsedTime: 5 * time.Minute,
 					},
+					ScreeningConfig: relayer.RawScreeningConfig{
+						ListFile: "screening.json",
+					},
+					AdminConfig: relayer.RawAdminConfig{
+						Token: "admin-token",
+					},
+					PauseConfig: relayer.PauseConfig{
+						HaltListeners: true,
+					},
 				},
 				ChainConfigs: []map[string]interface{}{{
 					"id":   float64(1),
@@ -562,6 +577,7 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 				RelayerConfig: relayer.RelayerConfig{
 					LogLevel:                  0,
 					LogFile:                   "custom.log",
+					AuditLogFile:              "custom-audit.log",
 					OpenTelemetryCollectorURL: "",
 					HealthPort:                9002,
 					MpcConfig: relayer.MpcRelayerConfig{
@@ -574,6 +590,7 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 							Path:          "path",
 						},
 						CommHealthCheckInterval: 10 * time.Minute,
+						RequireMessageHashes:    true,
 					},
 					BullyConfig: relayer.BullyConfig{
 						PingWaitTime:     time.Second,
@@ -588,6 +605,335 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 						MaxRetries:     5,
 						MaxElapsedTime: 5 * time.Minute,
 					},
+					ScreeningConfig: relayer.ScreeningConfig{
+						ListFile:       "screening.json",
+						ReloadInterval: time.Minute,
+					},
+					AdminConfig: relayer.AdminConfig{
+						Port:  9002,
+						Token: "admin-token",
+					},
+					PauseConfig: relayer.PauseConfig{
+						HaltListeners: true,
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "evm1",
+				}},
+			},
+		},
+		{
+			name: "invalid sweeper config",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port: "2020",
+					},
+					SweeperConfig: relayer.RawSweeperConfig{
+						Enabled:  true,
+						Interval: "2z",
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "evm1",
+				}},
+			},
+			shouldFail: true,
+			errorMsg:   "unable to parse sweeper interval: time: unknown unit \"z\" in duration \"2z\"",
+			outConfig:  config.Config{},
+		},
+		{
+			name: "valid sweeper config",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port: "2020",
+					},
+					SweeperConfig: relayer.RawSweeperConfig{
+						Enabled:    true,
+						StuckAfter: "1h",
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "evm1",
+				}},
+			},
+			shouldFail: false,
+			outConfig: config.Config{
+				RelayerConfig: relayer.RelayerConfig{
+					LogLevel:     1,
+					LogFile:      "out.log",
+					AuditLogFile: "audit.log",
+					HealthPort:   9001,
+					MpcConfig: relayer.MpcRelayerConfig{
+						Port: 2020,
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						CommHealthCheckInterval: 5 * time.Minute,
+					},
+					BullyConfig: relayer.BullyConfig{
+						PingWaitTime:     1 * time.Second,
+						PingBackOff:      1 * time.Second,
+						PingInterval:     1 * time.Second,
+						ElectionWaitTime: 2 * time.Second,
+						BullyWaitTime:    3 * time.Minute,
+					},
+					UploaderConfig: relayer.UploaderConfig{
+						MaxRetries:     5,
+						MaxElapsedTime: 300000,
+					},
+					SweeperConfig: relayer.SweeperConfig{
+						Enabled:     true,
+						Interval:    10 * time.Minute,
+						StuckAfter:  time.Hour,
+						BackOff:     10 * time.Minute,
+						MaxBackOff:  6 * time.Hour,
+						MaxAttempts: 5,
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "evm1",
+				}},
+			},
+		},
+		{
+			name: "invalid balance monitor config",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port: "2020",
+					},
+					BalanceMonitorConfig: relayer.RawBalanceMonitor{
+						Enabled:  true,
+						Interval: "1h",
+						Window:   "30m",
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "evm1",
+				}},
+			},
+			shouldFail: true,
+			errorMsg:   "balance monitor window 30m0s shorter than interval 1h0m0s",
+			outConfig:  config.Config{},
+		},
+		{
+			name: "valid balance monitor config",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port: "2020",
+					},
+					BalanceMonitorConfig: relayer.RawBalanceMonitor{
+						Enabled:    true,
+						WebhookURL: "https://alerts.example.com",
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "evm1",
+				}},
+			},
+			shouldFail: false,
+			outConfig: config.Config{
+				RelayerConfig: relayer.RelayerConfig{
+					LogLevel:     1,
+					LogFile:      "out.log",
+					AuditLogFile: "audit.log",
+					HealthPort:   9001,
+					MpcConfig: relayer.MpcRelayerConfig{
+						Port: 2020,
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						CommHealthCheckInterval: 5 * time.Minute,
+					},
+					BullyConfig: relayer.BullyConfig{
+						PingWaitTime:     1 * time.Second,
+						PingBackOff:      1 * time.Second,
+						PingInterval:     1 * time.Second,
+						ElectionWaitTime: 2 * time.Second,
+						BullyWaitTime:    3 * time.Minute,
+					},
+					UploaderConfig: relayer.UploaderConfig{
+						MaxRetries:     5,
+						MaxElapsedTime: 300000,
+					},
+					BalanceMonitorConfig: relayer.BalanceMonitorConfig{
+						Enabled:       true,
+						Interval:      5 * time.Minute,
+						Window:        24 * time.Hour,
+						MinRunwayDays: 3,
+						WebhookURL:    "https://alerts.example.com",
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "evm1",
+				}},
+			},
+		},
+		{
+			name: "invalid volume limits config",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port: "2020",
+					},
+					VolumeLimitsConfig: relayer.RawVolumeLimits{
+						Resources: []relayer.RawResourceLimit{
+							{ResourceID: "0x01", Limit: "100"},
+						},
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "evm1",
+				}},
+			},
+			shouldFail: true,
+			errorMsg:   "invalid volume limit: invalid resource ID 0x01",
+			outConfig:  config.Config{},
+		},
+		{
+			name: "valid volume limits config",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port: "2020",
+					},
+					VolumeLimitsConfig: relayer.RawVolumeLimits{
+						Resources: []relayer.RawResourceLimit{
+							{ResourceID: "0x0000000000000000000000000000000000000000000000000000000000000001", Limit: "1000"},
+						},
+						Routes: []relayer.RawRouteLimit{
+							{Source: 1, Destination: 2, ResourceID: "0000000000000000000000000000000000000000000000000000000000000001", Limit: "100"},
+						},
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "evm1",
+				}},
+			},
+			shouldFail: false,
+			outConfig: config.Config{
+				RelayerConfig: relayer.RelayerConfig{
+					LogLevel:     1,
+					LogFile:      "out.log",
+					AuditLogFile: "audit.log",
+					HealthPort:   9001,
+					MpcConfig: relayer.MpcRelayerConfig{
+						Port: 2020,
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						CommHealthCheckInterval: 5 * time.Minute,
+					},
+					BullyConfig: relayer.BullyConfig{
+						PingWaitTime:     1 * time.Second,
+						PingBackOff:      1 * time.Second,
+						PingInterval:     1 * time.Second,
+						ElectionWaitTime: 2 * time.Second,
+						BullyWaitTime:    3 * time.Minute,
+					},
+					UploaderConfig: relayer.UploaderConfig{
+						MaxRetries:     5,
+						MaxElapsedTime: 300000,
+					},
+					VolumeLimitsConfig: relayer.VolumeLimitsConfig{
+						Window: 24 * time.Hour,
+						Resources: []relayer.ResourceLimit{
+							{ResourceID: [32]byte{31: 1}, Limit: big.NewInt(1000)},
+						},
+						Routes: []relayer.RouteLimit{
+							{Source: 1, Destination: 2, ResourceID: [32]byte{31: 1}, Limit: big.NewInt(100)},
+						},
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "evm1",
+				}},
+			},
+		},
+		{
+			name: "invalid signing policy config",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port: "2020",
+					},
+					SigningPolicyConfig: relayer.RawSigningPolicy{
+						Enabled: true,
+						MaxAmounts: []relayer.RawResourceLimit{
+							{ResourceID: "0x0000000000000000000000000000000000000000000000000000000000000001", Limit: "1e18"},
+						},
+					},
 				},
 				ChainConfigs: []map[string]interface{}{{
 					"id":   float64(1),
@@ -595,6 +941,65 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 					"name": "evm1",
 				}},
 			},
+			shouldFail: true,
+			errorMsg:   "invalid signing policy max amount: invalid limit 1e18 for resource 0x0000000000000000000000000000000000000000000000000000000000000001",
+			outConfig:  config.Config{},
+		},
+		{
+			name: "invalid screening reload interval",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port: "2020",
+					},
+					ScreeningConfig: relayer.RawScreeningConfig{
+						ListFile:       "screening.json",
+						ReloadInterval: "1",
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "evm1",
+				}},
+			},
+			shouldFail: true,
+			errorMsg:   "unable to parse screening reload interval: time: missing unit in duration \"1\"",
+			outConfig:  config.Config{},
+		},
+		{
+			name: "invalid admin port",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port: "2020",
+					},
+					AdminConfig: relayer.RawAdminConfig{
+						Port:  "invalid",
+						Token: "admin-token",
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "evm1",
+				}},
+			},
+			shouldFail: true,
+			errorMsg:   "unable to parse admin port strconv.ParseInt: parsing \"invalid\": invalid syntax",
+			outConfig:  config.Config{},
 		},
 	}
 
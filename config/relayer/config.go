@@ -4,9 +4,12 @@
 package relayer
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -16,12 +19,67 @@ type RelayerConfig struct {
 	OpenTelemetryCollectorURL string
 	LogLevel                  zerolog.Level
 	LogFile                   string
+	AuditLogFile              string
 	HealthPort                uint16
 	Env                       string
 	Id                        string
 	MpcConfig                 MpcRelayerConfig
 	BullyConfig               BullyConfig
 	UploaderConfig            UploaderConfig
+	PropRetentionDays         uint64
+	SweeperConfig             SweeperConfig
+	VolumeLimitsConfig        VolumeLimitsConfig
+	SigningPolicyConfig       SigningPolicyConfig
+	ScreeningConfig           ScreeningConfig
+	AdminConfig               AdminConfig
+	PauseConfig               PauseConfig
+	BalanceMonitorConfig      BalanceMonitorConfig
+}
+
+// BalanceMonitorConfig configures tracking of submitting account balances.
+// Alerts are sent to the webhook when the estimated runway drops below the minimum.
+type BalanceMonitorConfig struct {
+	Enabled       bool
+	Interval      time.Duration
+	Window        time.Duration
+	MinRunwayDays float64
+	WebhookURL    string
+}
+
+// PauseConfig configures how the pause state is honoured and shared with peers
+type PauseConfig struct {
+	HaltListeners bool `mapstructure:"HaltListeners" json:"haltListeners"`
+	Broadcast     bool `mapstructure:"Broadcast" json:"broadcast"`
+}
+
+// AdminConfig configures the authenticated admin API.
+// The API is disabled if the token is not set.
+type AdminConfig struct {
+	Port  uint16
+	Token string
+}
+
+// ScreeningConfig points to the list of addresses whose transfers are quarantined.
+// Screening is disabled if the list file is not set.
+type ScreeningConfig struct {
+	ListFile       string
+	ReloadInterval time.Duration
+}
+
+// SigningPolicyConfig is the local policy every relayer evaluates before it
+// agrees to sign a transfer. Empty allowlists allow everything.
+type SigningPolicyConfig struct {
+	Enabled          bool
+	Routes           []PolicyRoute
+	Resources        [][32]byte
+	MaxAmounts       []ResourceLimit
+	Targets          []string
+	DeniedDepositors []string
+}
+
+type PolicyRoute struct {
+	Source      uint8
+	Destination uint8
 }
 
 type MpcRelayerConfig struct {
@@ -31,6 +89,7 @@ type MpcRelayerConfig struct {
 	FrostKeysharePath       string
 	Key                     string
 	CommHealthCheckInterval time.Duration
+	RequireMessageHashes    bool
 }
 
 type BullyConfig struct {
@@ -41,6 +100,33 @@ type BullyConfig struct {
 	BullyWaitTime    time.Duration
 }
 
+type SweeperConfig struct {
+	Enabled     bool
+	Interval    time.Duration
+	StuckAfter  time.Duration
+	BackOff     time.Duration
+	MaxBackOff  time.Duration
+	MaxAttempts uint64
+}
+
+type VolumeLimitsConfig struct {
+	Window    time.Duration
+	Resources []ResourceLimit
+	Routes    []RouteLimit
+}
+
+type ResourceLimit struct {
+	ResourceID [32]byte
+	Limit      *big.Int
+}
+
+type RouteLimit struct {
+	Source      uint8
+	Destination uint8
+	ResourceID  [32]byte
+	Limit       *big.Int
+}
+
 type TopologyConfiguration struct {
 	EncryptionKey string `mapstructure:"EncryptionKey" json:"encryptionKey"`
 	Url           string `mapstructure:"Url" json:"url"`
@@ -57,12 +143,21 @@ type RawRelayerConfig struct {
 	OpenTelemetryCollectorURL string              `mapstructure:"OpenTelemetryCollectorURL" json:"opentelemetryCollectorURL"`
 	LogLevel                  string              `mapstructure:"LogLevel" json:"logLevel" default:"info"`
 	LogFile                   string              `mapstructure:"LogFile" json:"logFile" default:"out.log"`
+	AuditLogFile              string              `mapstructure:"AuditLogFile" json:"auditLogFile" default:"audit.log"`
 	HealthPort                string              `mapstructure:"HealthPort" json:"healthPort" default:"9001"`
 	Env                       string              `mapstructure:"Env" json:"env"`
 	Id                        string              `mapstructure:"Id" json:"id"`
 	MpcConfig                 RawMpcRelayerConfig `mapstructure:"MpcConfig" json:"mpcConfig"`
 	BullyConfig               RawBullyConfig      `mapstructure:"BullyConfig" json:"bullyConfig"`
 	UploaderConfig            UploaderConfig      `mapstructure:"uploaderConfig"`
+	PropRetentionDays         uint64              `mapstructure:"PropRetentionDays" json:"propRetentionDays"`
+	SweeperConfig             RawSweeperConfig    `mapstructure:"SweeperConfig" json:"sweeperConfig"`
+	VolumeLimitsConfig        RawVolumeLimits     `mapstructure:"VolumeLimitsConfig" json:"volumeLimitsConfig"`
+	SigningPolicyConfig       RawSigningPolicy    `mapstructure:"SigningPolicyConfig" json:"signingPolicyConfig"`
+	ScreeningConfig           RawScreeningConfig  `mapstructure:"ScreeningConfig" json:"screeningConfig"`
+	AdminConfig               RawAdminConfig      `mapstructure:"AdminConfig" json:"adminConfig"`
+	PauseConfig               PauseConfig         `mapstructure:"PauseConfig" json:"pauseConfig"`
+	BalanceMonitorConfig      RawBalanceMonitor   `mapstructure:"BalanceMonitorConfig" json:"balanceMonitorConfig"`
 }
 
 type RawMpcRelayerConfig struct {
@@ -72,6 +167,7 @@ type RawMpcRelayerConfig struct {
 	Port                    string                `mapstructure:"Port" json:"port" default:"9000"`
 	TopologyConfiguration   TopologyConfiguration `mapstructure:"TopologyConfiguration" json:"topologyConfiguration"`
 	CommHealthCheckInterval string                `mapstructure:"CommHealthCheckInterval" json:"commHealthCheckInterval" default:"5m"`
+	RequireMessageHashes    bool                  `mapstructure:"RequireMessageHashes" json:"requireMessageHashes"`
 }
 
 type RawBullyConfig struct {
@@ -82,6 +178,60 @@ type RawBullyConfig struct {
 	BullyWaitTime    string `mapstructure:"BullyWaitTime" json:"bullyWaitTime" default:"3m"`
 }
 
+type RawSweeperConfig struct {
+	Enabled     bool   `mapstructure:"Enabled" json:"enabled"`
+	Interval    string `mapstructure:"Interval" json:"interval" default:"10m"`
+	StuckAfter  string `mapstructure:"StuckAfter" json:"stuckAfter" default:"30m"`
+	BackOff     string `mapstructure:"BackOff" json:"backOff" default:"10m"`
+	MaxBackOff  string `mapstructure:"MaxBackOff" json:"maxBackOff" default:"6h"`
+	MaxAttempts uint64 `mapstructure:"MaxAttempts" json:"maxAttempts" default:"5"`
+}
+
+type RawBalanceMonitor struct {
+	Enabled       bool    `mapstructure:"Enabled" json:"enabled"`
+	Interval      string  `mapstructure:"Interval" json:"interval" default:"5m"`
+	Window        string  `mapstructure:"Window" json:"window" default:"24h"`
+	MinRunwayDays float64 `mapstructure:"MinRunwayDays" json:"minRunwayDays" default:"3"`
+	WebhookURL    string  `mapstructure:"WebhookURL" json:"webhookURL"`
+}
+
+type RawVolumeLimits struct {
+	Window    string             `mapstructure:"Window" json:"window" default:"24h"`
+	Resources []RawResourceLimit `mapstructure:"Resources" json:"resources"`
+	Routes    []RawRouteLimit    `mapstructure:"Routes" json:"routes"`
+}
+
+type RawResourceLimit struct {
+	ResourceID string `mapstructure:"ResourceID" json:"resourceID"`
+	Limit      string `mapstructure:"Limit" json:"limit"`
+}
+
+type RawRouteLimit struct {
+	Source      uint8  `mapstructure:"Source" json:"source"`
+	Destination uint8  `mapstructure:"Destination" json:"destination"`
+	ResourceID  string `mapstructure:"ResourceID" json:"resourceID"`
+	Limit       string `mapstructure:"Limit" json:"limit"`
+}
+
+type RawSigningPolicy struct {
+	Enabled          bool               `mapstructure:"Enabled" json:"enabled"`
+	Routes           []PolicyRoute      `mapstructure:"Routes" json:"routes"`
+	Resources        []string           `mapstructure:"Resources" json:"resources"`
+	MaxAmounts       []RawResourceLimit `mapstructure:"MaxAmounts" json:"maxAmounts"`
+	Targets          []string           `mapstructure:"Targets" json:"targets"`
+	DeniedDepositors []string           `mapstructure:"DeniedDepositors" json:"deniedDepositors"`
+}
+
+type RawScreeningConfig struct {
+	ListFile       string `mapstructure:"ListFile" json:"listFile"`
+	ReloadInterval string `mapstructure:"ReloadInterval" json:"reloadInterval" default:"1m"`
+}
+
+type RawAdminConfig struct {
+	Port  string `mapstructure:"Port" json:"port" default:"9002"`
+	Token string `mapstructure:"Token" json:"token"`
+}
+
 func (c *RawRelayerConfig) Validate() error {
 	if c.MpcConfig.TopologyConfiguration.EncryptionKey == "" {
 		return errors.New("topology configuration encryption key not provided")
@@ -110,6 +260,7 @@ func NewRelayerConfig(rawConfig RawRelayerConfig) (RelayerConfig, error) {
 	config.LogLevel = logLevel
 
 	config.LogFile = rawConfig.LogFile
+	config.AuditLogFile = rawConfig.AuditLogFile
 	config.OpenTelemetryCollectorURL = rawConfig.OpenTelemetryCollectorURL
 
 	healthPort, err := strconv.ParseInt(rawConfig.HealthPort, 0, 16)
@@ -132,6 +283,44 @@ func NewRelayerConfig(rawConfig RawRelayerConfig) (RelayerConfig, error) {
 	config.Env = rawConfig.Env
 	config.Id = rawConfig.Id
 	config.UploaderConfig = rawConfig.UploaderConfig
+	config.PropRetentionDays = rawConfig.PropRetentionDays
+
+	sweeperConfig, err := parseSweeperConfig(rawConfig)
+	if err != nil {
+		return RelayerConfig{}, err
+	}
+	config.SweeperConfig = sweeperConfig
+
+	volumeLimitsConfig, err := parseVolumeLimitsConfig(rawConfig)
+	if err != nil {
+		return RelayerConfig{}, err
+	}
+	config.VolumeLimitsConfig = volumeLimitsConfig
+
+	signingPolicyConfig, err := parseSigningPolicyConfig(rawConfig)
+	if err != nil {
+		return RelayerConfig{}, err
+	}
+	config.SigningPolicyConfig = signingPolicyConfig
+
+	screeningConfig, err := parseScreeningConfig(rawConfig)
+	if err != nil {
+		return RelayerConfig{}, err
+	}
+	config.ScreeningConfig = screeningConfig
+
+	adminConfig, err := parseAdminConfig(rawConfig)
+	if err != nil {
+		return RelayerConfig{}, err
+	}
+	config.AdminConfig = adminConfig
+	config.PauseConfig = rawConfig.PauseConfig
+
+	balanceMonitorConfig, err := parseBalanceMonitorConfig(rawConfig)
+	if err != nil {
+		return RelayerConfig{}, err
+	}
+	config.BalanceMonitorConfig = balanceMonitorConfig
 	return config, nil
 }
 
@@ -148,6 +337,7 @@ func parseMpcConfig(rawConfig RawRelayerConfig) (MpcRelayerConfig, error) {
 	mpcConfig.KeysharePath = rawConfig.MpcConfig.KeysharePath
 	mpcConfig.FrostKeysharePath = rawConfig.MpcConfig.FrostKeysharePath
 	mpcConfig.Key = rawConfig.MpcConfig.Key
+	mpcConfig.RequireMessageHashes = rawConfig.MpcConfig.RequireMessageHashes
 
 	duration, err := time.ParseDuration(rawConfig.MpcConfig.CommHealthCheckInterval)
 	if err != nil {
@@ -192,3 +382,197 @@ func parseBullyConfig(rawConfig RawRelayerConfig) (BullyConfig, error) {
 		BullyWaitTime:    bullyWaitTime,
 	}, nil
 }
+
+func parseSweeperConfig(rawConfig RawRelayerConfig) (SweeperConfig, error) {
+	if !rawConfig.SweeperConfig.Enabled {
+		return SweeperConfig{}, nil
+	}
+
+	interval, err := time.ParseDuration(rawConfig.SweeperConfig.Interval)
+	if err != nil {
+		return SweeperConfig{}, fmt.Errorf("unable to parse sweeper interval: %w", err)
+	}
+
+	stuckAfter, err := time.ParseDuration(rawConfig.SweeperConfig.StuckAfter)
+	if err != nil {
+		return SweeperConfig{}, fmt.Errorf("unable to parse sweeper stuck after: %w", err)
+	}
+
+	backOff, err := time.ParseDuration(rawConfig.SweeperConfig.BackOff)
+	if err != nil {
+		return SweeperConfig{}, fmt.Errorf("unable to parse sweeper back off: %w", err)
+	}
+
+	maxBackOff, err := time.ParseDuration(rawConfig.SweeperConfig.MaxBackOff)
+	if err != nil {
+		return SweeperConfig{}, fmt.Errorf("unable to parse sweeper max back off: %w", err)
+	}
+
+	return SweeperConfig{
+		Enabled:     true,
+		Interval:    interval,
+		StuckAfter:  stuckAfter,
+		BackOff:     backOff,
+		MaxBackOff:  maxBackOff,
+		MaxAttempts: rawConfig.SweeperConfig.MaxAttempts,
+	}, nil
+}
+
+func parseBalanceMonitorConfig(rawConfig RawRelayerConfig) (BalanceMonitorConfig, error) {
+	if !rawConfig.BalanceMonitorConfig.Enabled {
+		return BalanceMonitorConfig{}, nil
+	}
+
+	interval, err := time.ParseDuration(rawConfig.BalanceMonitorConfig.Interval)
+	if err != nil {
+		return BalanceMonitorConfig{}, fmt.Errorf("unable to parse balance monitor interval: %w", err)
+	}
+
+	window, err := time.ParseDuration(rawConfig.BalanceMonitorConfig.Window)
+	if err != nil {
+		return BalanceMonitorConfig{}, fmt.Errorf("unable to parse balance monitor window: %w", err)
+	}
+	if window < interval {
+		return BalanceMonitorConfig{}, fmt.Errorf("balance monitor window %s shorter than interval %s", window, interval)
+	}
+
+	if rawConfig.BalanceMonitorConfig.MinRunwayDays < 0 {
+		return BalanceMonitorConfig{}, fmt.Errorf("balance monitor min runway days has to be positive")
+	}
+
+	return BalanceMonitorConfig{
+		Enabled:       true,
+		Interval:      interval,
+		Window:        window,
+		MinRunwayDays: rawConfig.BalanceMonitorConfig.MinRunwayDays,
+		WebhookURL:    rawConfig.BalanceMonitorConfig.WebhookURL,
+	}, nil
+}
+
+func parseVolumeLimitsConfig(rawConfig RawRelayerConfig) (VolumeLimitsConfig, error) {
+	rawLimits := rawConfig.VolumeLimitsConfig
+	if len(rawLimits.Resources) == 0 && len(rawLimits.Routes) == 0 {
+		return VolumeLimitsConfig{}, nil
+	}
+
+	window, err := time.ParseDuration(rawLimits.Window)
+	if err != nil {
+		return VolumeLimitsConfig{}, fmt.Errorf("unable to parse volume limits window: %w", err)
+	}
+
+	config := VolumeLimitsConfig{
+		Window:    window,
+		Resources: make([]ResourceLimit, len(rawLimits.Resources)),
+		Routes:    make([]RouteLimit, len(rawLimits.Routes)),
+	}
+	for i, r := range rawLimits.Resources {
+		resourceID, limit, err := parseLimit(r.ResourceID, r.Limit)
+		if err != nil {
+			return VolumeLimitsConfig{}, fmt.Errorf("invalid volume limit: %w", err)
+		}
+		config.Resources[i] = ResourceLimit{
+			ResourceID: resourceID,
+			Limit:      limit,
+		}
+	}
+	for i, r := range rawLimits.Routes {
+		resourceID, limit, err := parseLimit(r.ResourceID, r.Limit)
+		if err != nil {
+			return VolumeLimitsConfig{}, fmt.Errorf("invalid volume limit: %w", err)
+		}
+		config.Routes[i] = RouteLimit{
+			Source:      r.Source,
+			Destination: r.Destination,
+			ResourceID:  resourceID,
+			Limit:       limit,
+		}
+	}
+	return config, nil
+}
+
+func parseSigningPolicyConfig(rawConfig RawRelayerConfig) (SigningPolicyConfig, error) {
+	rawPolicy := rawConfig.SigningPolicyConfig
+	if !rawPolicy.Enabled {
+		return SigningPolicyConfig{}, nil
+	}
+
+	config := SigningPolicyConfig{
+		Enabled:          true,
+		Routes:           rawPolicy.Routes,
+		Resources:        make([][32]byte, len(rawPolicy.Resources)),
+		MaxAmounts:       make([]ResourceLimit, len(rawPolicy.MaxAmounts)),
+		Targets:          make([]string, len(rawPolicy.Targets)),
+		DeniedDepositors: make([]string, len(rawPolicy.DeniedDepositors)),
+	}
+	for i, r := range rawPolicy.Resources {
+		resourceBytes, err := hex.DecodeString(strings.TrimPrefix(r, "0x"))
+		if err != nil || len(resourceBytes) != len(config.Resources[i]) {
+			return SigningPolicyConfig{}, fmt.Errorf("invalid signing policy resource ID %s", r)
+		}
+		copy(config.Resources[i][:], resourceBytes)
+	}
+	for i, r := range rawPolicy.MaxAmounts {
+		resourceID, limit, err := parseLimit(r.ResourceID, r.Limit)
+		if err != nil {
+			return SigningPolicyConfig{}, fmt.Errorf("invalid signing policy max amount: %w", err)
+		}
+		config.MaxAmounts[i] = ResourceLimit{
+			ResourceID: resourceID,
+			Limit:      limit,
+		}
+	}
+	for i, t := range rawPolicy.Targets {
+		config.Targets[i] = strings.ToLower(t)
+	}
+	for i, d := range rawPolicy.DeniedDepositors {
+		config.DeniedDepositors[i] = strings.ToLower(d)
+	}
+	return config, nil
+}
+
+func parseLimit(rawResourceID string, rawLimit string) ([32]byte, *big.Int, error) {
+	var resourceID [32]byte
+	resourceBytes, err := hex.DecodeString(strings.TrimPrefix(rawResourceID, "0x"))
+	if err != nil || len(resourceBytes) != len(resourceID) {
+		return resourceID, nil, fmt.Errorf("invalid resource ID %s", rawResourceID)
+	}
+	copy(resourceID[:], resourceBytes)
+
+	limit, ok := new(big.Int).SetString(rawLimit, 10)
+	if !ok {
+		return resourceID, nil, fmt.Errorf("invalid limit %s for resource %s", rawLimit, rawResourceID)
+	}
+	return resourceID, limit, nil
+}
+
+func parseScreeningConfig(rawConfig RawRelayerConfig) (ScreeningConfig, error) {
+	if rawConfig.ScreeningConfig.ListFile == "" {
+		return ScreeningConfig{}, nil
+	}
+
+	reloadInterval, err := time.ParseDuration(rawConfig.ScreeningConfig.ReloadInterval)
+	if err != nil {
+		return ScreeningConfig{}, fmt.Errorf("unable to parse screening reload interval: %w", err)
+	}
+
+	return ScreeningConfig{
+		ListFile:       rawConfig.ScreeningConfig.ListFile,
+		ReloadInterval: reloadInterval,
+	}, nil
+}
+
+func parseAdminConfig(rawConfig RawRelayerConfig) (AdminConfig, error) {
+	if rawConfig.AdminConfig.Token == "" {
+		return AdminConfig{}, nil
+	}
+
+	port, err := strconv.ParseInt(rawConfig.AdminConfig.Port, 0, 16)
+	if err != nil {
+		return AdminConfig{}, fmt.Errorf("unable to parse admin port %v", err)
+	}
+
+	return AdminConfig{
+		Port:  uint16(port),
+		Token: rawConfig.AdminConfig.Token,
+	}, nil
+}
@@ -0,0 +1,231 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/rs/zerolog/log"
+)
+
+// MaxRunwayDays caps the reported runway of accounts without recent spend
+const MaxRunwayDays = 365.0
+
+const (
+	LowBalanceStatus       = "low"
+	RecoveredBalanceStatus = "recovered"
+)
+
+type BalanceFetcher interface {
+	Balances(ctx context.Context) (map[string]*big.Int, error)
+}
+
+type BalanceMeter interface {
+	TrackSenderBalance(domainID uint8, account string, balance *big.Int)
+	TrackSenderRunway(domainID uint8, account string, days float64)
+}
+
+type FundingUpdater interface {
+	Update(underfunded []uint8)
+}
+
+// BalanceAlert is posted to the webhook when the runway of a submitting account
+// drops below the configured minimum and when it recovers
+type BalanceAlert struct {
+	RelayerID  string  `json:"relayerID"`
+	DomainID   uint8   `json:"domainID"`
+	Account    string  `json:"account"`
+	Balance    string  `json:"balance"`
+	RunwayDays float64 `json:"runwayDays"`
+	Status     string  `json:"status"`
+}
+
+type balanceSample struct {
+	balance *big.Int
+	time    time.Time
+}
+
+type senderAccount struct {
+	domainID uint8
+	account  string
+}
+
+// BalanceMonitor periodically reads balances of submitting accounts on every domain and
+// estimates their runway from the spend within the configured window. Domains on which all
+// submitting accounts are low are shared with peers so funded relayers coordinate executions.
+type BalanceMonitor struct {
+	relayerID  string
+	fetchers   map[uint8]BalanceFetcher
+	metrics    BalanceMeter
+	funding    FundingUpdater
+	config     relayer.BalanceMonitorConfig
+	httpClient *http.Client
+
+	samples     map[senderAccount][]balanceSample
+	low         map[senderAccount]bool
+	underfunded map[uint8]bool
+}
+
+func NewBalanceMonitor(
+	relayerID string,
+	fetchers map[uint8]BalanceFetcher,
+	metrics BalanceMeter,
+	funding FundingUpdater,
+	config relayer.BalanceMonitorConfig,
+) *BalanceMonitor {
+	return &BalanceMonitor{
+		relayerID:   relayerID,
+		fetchers:    fetchers,
+		metrics:     metrics,
+		funding:     funding,
+		config:      config,
+		httpClient:  &http.Client{Timeout: time.Second * 10},
+		samples:     make(map[senderAccount][]balanceSample),
+		low:         make(map[senderAccount]bool),
+		underfunded: make(map[uint8]bool),
+	}
+}
+
+// Start checks balances every configured interval until the context is cancelled
+func (m *BalanceMonitor) Start(ctx context.Context) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	m.Check(ctx, time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.Check(ctx, now)
+		}
+	}
+}
+
+// Check reads balances of all domains, updates runway estimates and
+// sends alerts for accounts whose funding status changed. Domains whose
+// balances could not be read keep their previous funding status.
+func (m *BalanceMonitor) Check(ctx context.Context, now time.Time) {
+	for domainID, fetcher := range m.fetchers {
+		balances, err := fetcher.Balances(ctx)
+		if err != nil {
+			log.Warn().Err(err).Uint8("domainID", domainID).Msg("Failed fetching sender balances")
+			continue
+		}
+
+		lowAccounts := 0
+		for account, balance := range balances {
+			key := senderAccount{domainID: domainID, account: account}
+			runway := m.runway(key, balance, now)
+			m.metrics.TrackSenderBalance(domainID, account, balance)
+			m.metrics.TrackSenderRunway(domainID, account, runway)
+
+			low := balance.Sign() == 0 || runway < m.config.MinRunwayDays
+			if low {
+				lowAccounts++
+			}
+			if low != m.low[key] {
+				m.alert(ctx, key, balance, runway, low)
+			}
+			m.low[key] = low
+		}
+
+		m.underfunded[domainID] = len(balances) > 0 && lowAccounts == len(balances)
+	}
+
+	underfunded := make([]uint8, 0)
+	for domainID, isUnderfunded := range m.underfunded {
+		if isUnderfunded {
+			underfunded = append(underfunded, domainID)
+		}
+	}
+	sort.Slice(underfunded, func(i, j int) bool { return underfunded[i] < underfunded[j] })
+	m.funding.Update(underfunded)
+}
+
+// runway stores the balance sample and estimates days until the account runs out
+// of funds from balance decreases within the window. Increases are treated as top ups.
+func (m *BalanceMonitor) runway(key senderAccount, balance *big.Int, now time.Time) float64 {
+	samples := append(m.samples[key], balanceSample{balance: balance, time: now})
+	for len(samples) > 1 && now.Sub(samples[0].time) > m.config.Window {
+		samples = samples[1:]
+	}
+	m.samples[key] = samples
+
+	spent := big.NewInt(0)
+	for i := 1; i < len(samples); i++ {
+		diff := new(big.Int).Sub(samples[i-1].balance, samples[i].balance)
+		if diff.Sign() == 1 {
+			spent.Add(spent, diff)
+		}
+	}
+	elapsed := now.Sub(samples[0].time)
+	if spent.Sign() == 0 || elapsed <= 0 {
+		return MaxRunwayDays
+	}
+
+	dailySpend := new(big.Float).Quo(new(big.Float).SetInt(spent), big.NewFloat(elapsed.Hours()/24))
+	days, _ := new(big.Float).Quo(new(big.Float).SetInt(balance), dailySpend).Float64()
+	if days > MaxRunwayDays {
+		return MaxRunwayDays
+	}
+	return days
+}
+
+func (m *BalanceMonitor) alert(ctx context.Context, key senderAccount, balance *big.Int, runway float64, low bool) {
+	status := RecoveredBalanceStatus
+	if low {
+		status = LowBalanceStatus
+		log.Warn().Uint8("domainID", key.domainID).Msgf("Sender %s balance %s low with runway of %.2f days", key.account, balance, runway)
+	} else {
+		log.Info().Uint8("domainID", key.domainID).Msgf("Sender %s balance %s recovered with runway of %.2f days", key.account, balance, runway)
+	}
+	if m.config.WebhookURL == "" {
+		return
+	}
+
+	err := m.postAlert(ctx, BalanceAlert{
+		RelayerID:  m.relayerID,
+		DomainID:   key.domainID,
+		Account:    key.account,
+		Balance:    balance.String(),
+		RunwayDays: runway,
+		Status:     status,
+	})
+	if err != nil {
+		log.Err(err).Uint8("domainID", key.domainID).Msgf("Failed sending balance alert for sender %s", key.account)
+	}
+}
+
+func (m *BalanceMonitor) postAlert(ctx context.Context, alert BalanceAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.config.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package jobs_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/jobs"
+	mock_jobs "github.com/ChainSafe/sygma-relayer/jobs/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type BalanceMonitorTestSuite struct {
+	suite.Suite
+	mockFetcher *mock_jobs.MockBalanceFetcher
+	mockMeter   *mock_jobs.MockBalanceMeter
+	mockFunding *mock_jobs.MockFundingUpdater
+	alerts      chan jobs.BalanceAlert
+	server      *httptest.Server
+	monitor     *jobs.BalanceMonitor
+	start       time.Time
+}
+
+func TestRunBalanceMonitorTestSuite(t *testing.T) {
+	suite.Run(t, new(BalanceMonitorTestSuite))
+}
+
+func (s *BalanceMonitorTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockFetcher = mock_jobs.NewMockBalanceFetcher(ctrl)
+	s.mockMeter = mock_jobs.NewMockBalanceMeter(ctrl)
+	s.mockMeter.EXPECT().TrackSenderBalance(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	s.mockFunding = mock_jobs.NewMockFundingUpdater(ctrl)
+
+	s.alerts = make(chan jobs.BalanceAlert, 10)
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		alert := jobs.BalanceAlert{}
+		_ = json.NewDecoder(r.Body).Decode(&alert)
+		s.alerts <- alert
+	}))
+	s.monitor = jobs.NewBalanceMonitor(
+		"relayer1",
+		map[uint8]jobs.BalanceFetcher{1: s.mockFetcher},
+		s.mockMeter,
+		s.mockFunding,
+		relayer.BalanceMonitorConfig{
+			Enabled:       true,
+			Interval:      time.Hour,
+			Window:        24 * time.Hour,
+			MinRunwayDays: 3,
+			WebhookURL:    s.server.URL,
+		})
+	s.start = time.Unix(1700000000, 0)
+}
+
+func (s *BalanceMonitorTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *BalanceMonitorTestSuite) Test_Check_NoSpend() {
+	s.mockFetcher.EXPECT().Balances(gomock.Any()).Return(map[string]*big.Int{"0x1": big.NewInt(100)}, nil).Times(2)
+	s.mockMeter.EXPECT().TrackSenderRunway(uint8(1), "0x1", jobs.MaxRunwayDays).Times(2)
+	s.mockFunding.EXPECT().Update([]uint8{}).Times(2)
+
+	s.monitor.Check(context.Background(), s.start)
+	s.monitor.Check(context.Background(), s.start.Add(time.Hour))
+
+	s.Equal(len(s.alerts), 0)
+}
+
+func (s *BalanceMonitorTestSuite) Test_Check_LowRunwayAlertsAndUnderfundsDomain() {
+	gomock.InOrder(
+		s.mockFetcher.EXPECT().Balances(gomock.Any()).Return(map[string]*big.Int{"0x1": big.NewInt(1000)}, nil),
+		s.mockFetcher.EXPECT().Balances(gomock.Any()).Return(map[string]*big.Int{"0x1": big.NewInt(800)}, nil),
+	)
+	gomock.InOrder(
+		s.mockMeter.EXPECT().TrackSenderRunway(uint8(1), "0x1", jobs.MaxRunwayDays),
+		s.mockMeter.EXPECT().TrackSenderRunway(uint8(1), "0x1", float64(4)),
+	)
+	s.mockFunding.EXPECT().Update([]uint8{}).Times(2)
+
+	s.monitor.Check(context.Background(), s.start)
+	s.monitor.Check(context.Background(), s.start.Add(24*time.Hour))
+	s.Equal(len(s.alerts), 0)
+
+	// first sample leaves the window, 400 spent in 12 hours leaves 400 for half a day
+	s.mockFetcher.EXPECT().Balances(gomock.Any()).Return(map[string]*big.Int{"0x1": big.NewInt(400)}, nil)
+	s.mockMeter.EXPECT().TrackSenderRunway(uint8(1), "0x1", 0.5)
+	s.mockFunding.EXPECT().Update([]uint8{1})
+	s.monitor.Check(context.Background(), s.start.Add(36*time.Hour))
+
+	alert := <-s.alerts
+	s.Equal(alert, jobs.BalanceAlert{
+		RelayerID:  "relayer1",
+		DomainID:   1,
+		Account:    "0x1",
+		Balance:    "400",
+		RunwayDays: 0.5,
+		Status:     jobs.LowBalanceStatus,
+	})
+}
+
+func (s *BalanceMonitorTestSuite) Test_Check_TopUpRecovers() {
+	gomock.InOrder(
+		s.mockFetcher.EXPECT().Balances(gomock.Any()).Return(map[string]*big.Int{"0x1": big.NewInt(0)}, nil),
+		s.mockFetcher.EXPECT().Balances(gomock.Any()).Return(map[string]*big.Int{"0x1": big.NewInt(1000)}, nil),
+	)
+	s.mockMeter.EXPECT().TrackSenderRunway(uint8(1), "0x1", jobs.MaxRunwayDays).Times(2)
+	gomock.InOrder(
+		s.mockFunding.EXPECT().Update([]uint8{1}),
+		s.mockFunding.EXPECT().Update([]uint8{}),
+	)
+
+	s.monitor.Check(context.Background(), s.start)
+	alert := <-s.alerts
+	s.Equal(alert.Status, jobs.LowBalanceStatus)
+
+	s.monitor.Check(context.Background(), s.start.Add(time.Hour))
+	alert = <-s.alerts
+	s.Equal(alert.Status, jobs.RecoveredBalanceStatus)
+	s.Equal(alert.Balance, "1000")
+}
+
+func (s *BalanceMonitorTestSuite) Test_Check_DomainFundedIfAnyAccountFunded() {
+	s.mockFetcher.EXPECT().Balances(gomock.Any()).Return(map[string]*big.Int{
+		"0x1": big.NewInt(0),
+		"0x2": big.NewInt(100),
+	}, nil)
+	s.mockMeter.EXPECT().TrackSenderRunway(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+	s.mockFunding.EXPECT().Update([]uint8{})
+
+	s.monitor.Check(context.Background(), s.start)
+
+	alert := <-s.alerts
+	s.Equal(alert.Account, "0x1")
+}
+
+func (s *BalanceMonitorTestSuite) Test_Check_FetchFailureKeepsStatus() {
+	gomock.InOrder(
+		s.mockFetcher.EXPECT().Balances(gomock.Any()).Return(map[string]*big.Int{"0x1": big.NewInt(0)}, nil),
+		s.mockFetcher.EXPECT().Balances(gomock.Any()).Return(nil, fmt.Errorf("error")),
+	)
+	s.mockMeter.EXPECT().TrackSenderRunway(gomock.Any(), gomock.Any(), gomock.Any())
+	s.mockFunding.EXPECT().Update([]uint8{1}).Times(2)
+
+	s.monitor.Check(context.Background(), s.start)
+	s.monitor.Check(context.Background(), s.start.Add(time.Hour))
+
+	s.Equal(len(s.alerts), 1)
+}
@@ -13,6 +13,10 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+type PropPruner interface {
+	PruneExecutedProps(before time.Time) (int, error)
+}
+
 type RelayerStatusMeter interface {
 	TrackRelayerStatus(unavailable peer.IDSlice, all peer.IDSlice)
 }
@@ -35,3 +39,19 @@ func StartCommunicationHealthCheckJob(h host.Host, interval time.Duration, metri
 		metrics.TrackRelayerStatus(unavailable, all)
 	}
 }
+
+// StartPropPruningJob periodically deletes executed proposal records older than retention
+func StartPropPruningJob(pruner PropPruner, retention time.Duration, interval time.Duration) {
+	for {
+		log.Debug().Msg("Starting proposal pruning")
+
+		pruned, err := pruner.PruneExecutedProps(time.Now().Add(-retention))
+		if err != nil {
+			log.Err(err).Msg("Failed pruning executed proposals")
+		} else {
+			log.Info().Msgf("Pruned %d executed proposals older than %s", pruned, retention)
+		}
+
+		time.Sleep(interval)
+	}
+}
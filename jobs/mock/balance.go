@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./jobs/balance.go
+
+// Package mock_jobs is a generated GoMock package.
+package mock_jobs
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBalanceFetcher is a mock of BalanceFetcher interface.
+type MockBalanceFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceFetcherMockRecorder
+}
+
+// MockBalanceFetcherMockRecorder is the mock recorder for MockBalanceFetcher.
+type MockBalanceFetcherMockRecorder struct {
+	mock *MockBalanceFetcher
+}
+
+// NewMockBalanceFetcher creates a new mock instance.
+func NewMockBalanceFetcher(ctrl *gomock.Controller) *MockBalanceFetcher {
+	mock := &MockBalanceFetcher{ctrl: ctrl}
+	mock.recorder = &MockBalanceFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceFetcher) EXPECT() *MockBalanceFetcherMockRecorder {
+	return m.recorder
+}
+
+// Balances mocks base method.
+func (m *MockBalanceFetcher) Balances(ctx context.Context) (map[string]*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balances", ctx)
+	ret0, _ := ret[0].(map[string]*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balances indicates an expected call of Balances.
+func (mr *MockBalanceFetcherMockRecorder) Balances(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balances", reflect.TypeOf((*MockBalanceFetcher)(nil).Balances), ctx)
+}
+
+// MockBalanceMeter is a mock of BalanceMeter interface.
+type MockBalanceMeter struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceMeterMockRecorder
+}
+
+// MockBalanceMeterMockRecorder is the mock recorder for MockBalanceMeter.
+type MockBalanceMeterMockRecorder struct {
+	mock *MockBalanceMeter
+}
+
+// NewMockBalanceMeter creates a new mock instance.
+func NewMockBalanceMeter(ctrl *gomock.Controller) *MockBalanceMeter {
+	mock := &MockBalanceMeter{ctrl: ctrl}
+	mock.recorder = &MockBalanceMeterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceMeter) EXPECT() *MockBalanceMeterMockRecorder {
+	return m.recorder
+}
+
+// TrackSenderBalance mocks base method.
+func (m *MockBalanceMeter) TrackSenderBalance(domainID uint8, account string, balance *big.Int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackSenderBalance", domainID, account, balance)
+}
+
+// TrackSenderBalance indicates an expected call of TrackSenderBalance.
+func (mr *MockBalanceMeterMockRecorder) TrackSenderBalance(domainID, account, balance interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackSenderBalance", reflect.TypeOf((*MockBalanceMeter)(nil).TrackSenderBalance), domainID, account, balance)
+}
+
+// TrackSenderRunway mocks base method.
+func (m *MockBalanceMeter) TrackSenderRunway(domainID uint8, account string, days float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackSenderRunway", domainID, account, days)
+}
+
+// TrackSenderRunway indicates an expected call of TrackSenderRunway.
+func (mr *MockBalanceMeterMockRecorder) TrackSenderRunway(domainID, account, days interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackSenderRunway", reflect.TypeOf((*MockBalanceMeter)(nil).TrackSenderRunway), domainID, account, days)
+}
+
+// MockFundingUpdater is a mock of FundingUpdater interface.
+type MockFundingUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockFundingUpdaterMockRecorder
+}
+
+// MockFundingUpdaterMockRecorder is the mock recorder for MockFundingUpdater.
+type MockFundingUpdaterMockRecorder struct {
+	mock *MockFundingUpdater
+}
+
+// NewMockFundingUpdater creates a new mock instance.
+func NewMockFundingUpdater(ctrl *gomock.Controller) *MockFundingUpdater {
+	mock := &MockFundingUpdater{ctrl: ctrl}
+	mock.recorder = &MockFundingUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFundingUpdater) EXPECT() *MockFundingUpdaterMockRecorder {
+	return m.recorder
+}
+
+// Update mocks base method.
+func (m *MockFundingUpdater) Update(underfunded []uint8) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Update", underfunded)
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockFundingUpdaterMockRecorder) Update(underfunded interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFundingUpdater)(nil).Update), underfunded)
+}
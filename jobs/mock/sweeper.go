@@ -0,0 +1,194 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./jobs/sweeper.go
+
+// Package mock_jobs is a generated GoMock package.
+package mock_jobs
+
+import (
+	big "math/big"
+	reflect "reflect"
+
+	transfer "github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	store "github.com/ChainSafe/sygma-relayer/store"
+	topology "github.com/ChainSafe/sygma-relayer/topology"
+	gomock "github.com/golang/mock/gomock"
+	message "github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+// MockSweepPropStorer is a mock of SweepPropStorer interface.
+type MockSweepPropStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockSweepPropStorerMockRecorder
+}
+
+// MockSweepPropStorerMockRecorder is the mock recorder for MockSweepPropStorer.
+type MockSweepPropStorerMockRecorder struct {
+	mock *MockSweepPropStorer
+}
+
+// NewMockSweepPropStorer creates a new mock instance.
+func NewMockSweepPropStorer(ctrl *gomock.Controller) *MockSweepPropStorer {
+	mock := &MockSweepPropStorer{ctrl: ctrl}
+	mock.recorder = &MockSweepPropStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSweepPropStorer) EXPECT() *MockSweepPropStorerMockRecorder {
+	return m.recorder
+}
+
+// PropsByStatus mocks base method.
+func (m *MockSweepPropStorer) PropsByStatus(status store.PropStatus, fn func(store.PropKey, *store.PropRecord) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PropsByStatus", status, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PropsByStatus indicates an expected call of PropsByStatus.
+func (mr *MockSweepPropStorerMockRecorder) PropsByStatus(status, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropsByStatus", reflect.TypeOf((*MockSweepPropStorer)(nil).PropsByStatus), status, fn)
+}
+
+// StorePropExecution mocks base method.
+func (m *MockSweepPropStorer) StorePropExecution(source, destination uint8, depositNonce uint64, txHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorePropExecution", source, destination, depositNonce, txHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StorePropExecution indicates an expected call of StorePropExecution.
+func (mr *MockSweepPropStorerMockRecorder) StorePropExecution(source, destination, depositNonce, txHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePropExecution", reflect.TypeOf((*MockSweepPropStorer)(nil).StorePropExecution), source, destination, depositNonce, txHash)
+}
+
+// StorePropSweep mocks base method.
+func (m *MockSweepPropStorer) StorePropSweep(source, destination uint8, depositNonce uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorePropSweep", source, destination, depositNonce)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StorePropSweep indicates an expected call of StorePropSweep.
+func (mr *MockSweepPropStorerMockRecorder) StorePropSweep(source, destination, depositNonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePropSweep", reflect.TypeOf((*MockSweepPropStorer)(nil).StorePropSweep), source, destination, depositNonce)
+}
+
+// MockExecutionChecker is a mock of ExecutionChecker interface.
+type MockExecutionChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockExecutionCheckerMockRecorder
+}
+
+// MockExecutionCheckerMockRecorder is the mock recorder for MockExecutionChecker.
+type MockExecutionCheckerMockRecorder struct {
+	mock *MockExecutionChecker
+}
+
+// NewMockExecutionChecker creates a new mock instance.
+func NewMockExecutionChecker(ctrl *gomock.Controller) *MockExecutionChecker {
+	mock := &MockExecutionChecker{ctrl: ctrl}
+	mock.recorder = &MockExecutionCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExecutionChecker) EXPECT() *MockExecutionCheckerMockRecorder {
+	return m.recorder
+}
+
+// AreProposalsExecuted mocks base method.
+func (m *MockExecutionChecker) AreProposalsExecuted(proposals []*transfer.TransferProposal) ([]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AreProposalsExecuted", proposals)
+	ret0, _ := ret[0].([]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AreProposalsExecuted indicates an expected call of AreProposalsExecuted.
+func (mr *MockExecutionCheckerMockRecorder) AreProposalsExecuted(proposals interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AreProposalsExecuted", reflect.TypeOf((*MockExecutionChecker)(nil).AreProposalsExecuted), proposals)
+}
+
+// MockTopologyStorer is a mock of TopologyStorer interface.
+type MockTopologyStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTopologyStorerMockRecorder
+}
+
+// MockTopologyStorerMockRecorder is the mock recorder for MockTopologyStorer.
+type MockTopologyStorerMockRecorder struct {
+	mock *MockTopologyStorer
+}
+
+// NewMockTopologyStorer creates a new mock instance.
+func NewMockTopologyStorer(ctrl *gomock.Controller) *MockTopologyStorer {
+	mock := &MockTopologyStorer{ctrl: ctrl}
+	mock.recorder = &MockTopologyStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTopologyStorer) EXPECT() *MockTopologyStorerMockRecorder {
+	return m.recorder
+}
+
+// Topology mocks base method.
+func (m *MockTopologyStorer) Topology() (*topology.NetworkTopology, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Topology")
+	ret0, _ := ret[0].(*topology.NetworkTopology)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Topology indicates an expected call of Topology.
+func (mr *MockTopologyStorerMockRecorder) Topology() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Topology", reflect.TypeOf((*MockTopologyStorer)(nil).Topology))
+}
+
+// MockMessageQueue is a mock of MessageQueue interface.
+type MockMessageQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageQueueMockRecorder
+}
+
+// MockMessageQueueMockRecorder is the mock recorder for MockMessageQueue.
+type MockMessageQueueMockRecorder struct {
+	mock *MockMessageQueue
+}
+
+// NewMockMessageQueue creates a new mock instance.
+func NewMockMessageQueue(ctrl *gomock.Controller) *MockMessageQueue {
+	mock := &MockMessageQueue{ctrl: ctrl}
+	mock.recorder = &MockMessageQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageQueue) EXPECT() *MockMessageQueueMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockMessageQueue) Enqueue(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", block, domainMessages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockMessageQueueMockRecorder) Enqueue(block, domainMessages interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockMessageQueue)(nil).Enqueue), block, domainMessages)
+}
@@ -0,0 +1,385 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package jobs
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/comm/elector"
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/ChainSafe/sygma-relayer/topology"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+const (
+	SweepSessionID = "sweep"
+
+	// maxBackOffShift bounds the back off exponent so it does not overflow
+	maxBackOffShift = 32
+)
+
+type SweepPropStorer interface {
+	PropsByStatus(status store.PropStatus, fn func(key store.PropKey, record *store.PropRecord) error) error
+	StorePropExecution(source, destination uint8, depositNonce uint64, txHash string) error
+	StorePropSweep(source, destination uint8, depositNonce uint64) error
+}
+
+type ExecutionChecker interface {
+	AreProposalsExecuted(proposals []*transfer.TransferProposal) ([]bool, error)
+}
+
+type TopologyStorer interface {
+	Topology() (*topology.NetworkTopology, error)
+}
+
+type MessageQueue interface {
+	Enqueue(block *big.Int, domainMessages map[uint8][]*message.Message) error
+}
+
+// SweepMessage is broadcasted by the sweep leader to all relayers
+type SweepMessage struct {
+	Round   int64        `json:"round"`
+	Retries []SweepRetry `json:"retries"`
+}
+
+// SweepRetry is a retry of all stuck proposals from the same deposit block
+type SweepRetry struct {
+	MessageID string                 `json:"messageID"`
+	Retry     retry.RetryMessageData `json:"retry"`
+	Props     []store.PropKey        `json:"props"`
+}
+
+// PropSweeper periodically finds proposals that are pending or failed for too long
+// and retries them. Each sweep round is executed by a single relayer statically
+// elected for that round from the topology peers. The leader broadcasts retries to other relayers so every
+// relayer re-injects the same retry messages and joins the same signing sessions.
+type PropSweeper struct {
+	host          host.Host
+	comm          comm.Communication
+	topologyStore TopologyStorer
+	propStorer    SweepPropStorer
+	checkers      map[uint8]ExecutionChecker
+	msgQueue      MessageQueue
+	config        relayer.SweeperConfig
+}
+
+func NewPropSweeper(
+	host host.Host,
+	communication comm.Communication,
+	topologyStore TopologyStorer,
+	propStorer SweepPropStorer,
+	checkers map[uint8]ExecutionChecker,
+	msgQueue MessageQueue,
+	config relayer.SweeperConfig,
+) *PropSweeper {
+	return &PropSweeper{
+		host:          host,
+		comm:          communication,
+		topologyStore: topologyStore,
+		propStorer:    propStorer,
+		checkers:      checkers,
+		msgQueue:      msgQueue,
+		config:        config,
+	}
+}
+
+// Start sweeps stuck proposals every configured interval if the relayer
+// is the leader of the round and retries proposals swept by other leaders
+func (s *PropSweeper) Start(ctx context.Context) {
+	msgChan := make(chan *comm.WrappedMessage)
+	subID := s.comm.Subscribe(SweepSessionID, comm.SweepMsg, msgChan)
+	defer s.comm.UnSubscribe(subID)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-msgChan:
+			err := s.HandleSweepMessage(ctx, msg, time.Now())
+			if err != nil {
+				log.Err(err).Str("peerID", msg.From.String()).Msg("Failed handling sweep message")
+			}
+		case now := <-ticker.C:
+			err := s.Sweep(ctx, now)
+			if err != nil {
+				log.Err(err).Msg("Failed sweeping stuck proposals")
+			}
+		}
+	}
+}
+
+// Sweep retries stuck proposals if the relayer is the leader of the current round
+func (s *PropSweeper) Sweep(ctx context.Context, now time.Time) error {
+	round := s.round(now)
+	peers, err := s.peers()
+	if err != nil {
+		return err
+	}
+	leader, err := s.leader(ctx, round, peers)
+	if err != nil {
+		return err
+	}
+	if leader != s.host.ID() {
+		log.Debug().Msgf("Skipping sweep round %d led by %s", round, leader)
+		return nil
+	}
+
+	retries, err := s.stuckProposals(round, now)
+	if err != nil {
+		return err
+	}
+	if len(retries) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(SweepMessage{
+		Round:   round,
+		Retries: retries,
+	})
+	if err != nil {
+		return err
+	}
+	err = s.comm.Broadcast(peers, payload, comm.SweepMsg, SweepSessionID)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed broadcasting sweep round %d to all relayers", round)
+	}
+
+	log.Info().Msgf("Sweep round %d retrying %d stuck deposit blocks", round, len(retries))
+	return s.retry(retries)
+}
+
+// SweepNow immediately retries stuck proposals on this relayer regardless of the
+// round leader. Retries are not broadcasted so other relayers join the signing
+// sessions only if the sweep is triggered on them too.
+func (s *PropSweeper) SweepNow(now time.Time) (int, error) {
+	retries, err := s.stuckProposals(s.round(now), now)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Info().Msgf("Manual sweep retrying %d stuck deposit blocks", len(retries))
+	return len(retries), s.retry(retries)
+}
+
+// HandleSweepMessage retries proposals swept by the leader of a recent round
+func (s *PropSweeper) HandleSweepMessage(ctx context.Context, msg *comm.WrappedMessage, now time.Time) error {
+	sweepMsg := &SweepMessage{}
+	err := json.Unmarshal(msg.Payload, sweepMsg)
+	if err != nil {
+		return err
+	}
+
+	currentRound := s.round(now)
+	if sweepMsg.Round < currentRound-1 || sweepMsg.Round > currentRound+1 {
+		return fmt.Errorf("sweep round %d outside of current round %d", sweepMsg.Round, currentRound)
+	}
+	peers, err := s.peers()
+	if err != nil {
+		return err
+	}
+	leader, err := s.leader(ctx, sweepMsg.Round, peers)
+	if err != nil {
+		return err
+	}
+	if leader != msg.From {
+		return fmt.Errorf("peer %s is not the leader of sweep round %d", msg.From, sweepMsg.Round)
+	}
+
+	log.Info().Str("peerID", msg.From.String()).Msgf("Sweep round %d retrying %d stuck deposit blocks", sweepMsg.Round, len(sweepMsg.Retries))
+	return s.retry(sweepMsg.Retries)
+}
+
+type sweepCandidate struct {
+	key          store.PropKey
+	resourceID   [32]byte
+	depositBlock uint64
+}
+
+func (s *PropSweeper) stuckProposals(round int64, now time.Time) ([]SweepRetry, error) {
+	candidates := make([]sweepCandidate, 0)
+	for _, status := range []store.PropStatus{store.PendingProp, store.FailedProp} {
+		err := s.propStorer.PropsByStatus(status, func(key store.PropKey, record *store.PropRecord) error {
+			if !s.isStuck(record, now) {
+				return nil
+			}
+			if _, ok := s.checkers[key.Destination]; !ok {
+				return nil
+			}
+
+			var resourceID [32]byte
+			rBytes, err := hex.DecodeString(record.ResourceID)
+			if err != nil || len(rBytes) != len(resourceID) || record.DepositBlock == 0 {
+				log.Warn().Msgf("Unable to sweep proposal %+v without a known deposit location", key)
+				return nil
+			}
+			copy(resourceID[:], rBytes)
+
+			candidates = append(candidates, sweepCandidate{
+				key:          key,
+				resourceID:   resourceID,
+				depositBlock: record.DepositBlock,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	executed := s.executedProposals(candidates)
+	retries := make([]SweepRetry, 0)
+	retryIndexes := make(map[string]int)
+	for _, candidate := range candidates {
+		isExecuted, checked := executed[candidate.key]
+		if !checked {
+			continue
+		}
+		if isExecuted {
+			err := s.propStorer.StorePropExecution(candidate.key.Source, candidate.key.Destination, candidate.key.DepositNonce, "")
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		key := candidate.key
+		retryKey := fmt.Sprintf("%d-%d-%d-%x", key.Source, key.Destination, candidate.depositBlock, candidate.resourceID)
+		i, ok := retryIndexes[retryKey]
+		if !ok {
+			retries = append(retries, SweepRetry{
+				MessageID: fmt.Sprintf("%ssweep-%d-%d-%d-%d", retry.RetryMessageIDPrefix, round, key.Source, key.Destination, key.DepositNonce),
+				Retry: retry.RetryMessageData{
+					SourceDomainID:      key.Source,
+					DestinationDomainID: key.Destination,
+					BlockHeight:         new(big.Int).SetUint64(candidate.depositBlock),
+					ResourceID:          candidate.resourceID,
+				},
+			})
+			i = len(retries) - 1
+			retryIndexes[retryKey] = i
+		}
+		retries[i].Props = append(retries[i].Props, key)
+	}
+	return retries, nil
+}
+
+// executedProposals reads execution statuses of candidates with a single batched read per destination.
+// Candidates of destinations whose statuses could not be read are left out.
+func (s *PropSweeper) executedProposals(candidates []sweepCandidate) map[store.PropKey]bool {
+	proposals := make(map[uint8][]*transfer.TransferProposal)
+	for _, candidate := range candidates {
+		proposals[candidate.key.Destination] = append(proposals[candidate.key.Destination], &transfer.TransferProposal{
+			Source:      candidate.key.Source,
+			Destination: candidate.key.Destination,
+			Data: transfer.TransferProposalData{
+				DepositNonce: candidate.key.DepositNonce,
+				ResourceId:   candidate.resourceID,
+			},
+		})
+	}
+
+	executed := make(map[store.PropKey]bool)
+	for destination, props := range proposals {
+		statuses, err := s.checkers[destination].AreProposalsExecuted(props)
+		if err == nil && len(statuses) != len(props) {
+			err = fmt.Errorf("received %d execution statuses for %d proposals", len(statuses), len(props))
+		}
+		if err != nil {
+			log.Err(err).Msgf("Failed checking if proposals to domain %d are executed", destination)
+			continue
+		}
+
+		for i, prop := range props {
+			executed[store.PropKey{Source: prop.Source, Destination: prop.Destination, DepositNonce: prop.Data.DepositNonce}] = statuses[i]
+		}
+	}
+	return executed
+}
+
+// isStuck returns true if the proposal was not updated for the configured period
+// and the exponential back off, capped at the configured maximum, since the last sweep has passed
+func (s *PropSweeper) isStuck(record *store.PropRecord, now time.Time) bool {
+	if now.Sub(record.LastUpdated) < s.config.StuckAfter {
+		return false
+	}
+	if record.Sweeps >= s.config.MaxAttempts {
+		return false
+	}
+	if record.Sweeps == 0 {
+		return true
+	}
+
+	backOff := s.config.MaxBackOff
+	if record.Sweeps <= maxBackOffShift {
+		backOff = s.config.BackOff * time.Duration(uint64(1)<<(record.Sweeps-1))
+	}
+	if backOff > s.config.MaxBackOff || backOff <= 0 {
+		backOff = s.config.MaxBackOff
+	}
+	return !now.Before(record.LastSwept.Add(backOff))
+}
+
+func (s *PropSweeper) retry(retries []SweepRetry) error {
+	for _, r := range retries {
+		msg := message.NewMessage(
+			r.Retry.SourceDomainID,
+			r.Retry.SourceDomainID,
+			r.Retry,
+			r.MessageID,
+			retry.RetryMessageType,
+			time.Now(),
+		)
+		err := s.msgQueue.Enqueue(r.Retry.BlockHeight, map[uint8][]*message.Message{
+			r.Retry.SourceDomainID: {msg},
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, key := range r.Props {
+			err := s.propStorer.StorePropSweep(key.Source, key.Destination, key.DepositNonce)
+			if err != nil {
+				log.Err(err).Str("messageID", r.MessageID).Msgf("Failed storing sweep of proposal %+v", key)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *PropSweeper) round(now time.Time) int64 {
+	return now.UnixNano() / int64(s.config.Interval)
+}
+
+// peers returns the configured topology peers so all relayers elect leaders
+// from the same set regardless of the peers they have seen
+func (s *PropSweeper) peers() (peer.IDSlice, error) {
+	topology, err := s.topologyStore.Topology()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load topology: %w", err)
+	}
+
+	peers := make(peer.IDSlice, 0, len(topology.Peers))
+	for _, p := range topology.Peers {
+		peers = append(peers, p.ID)
+	}
+	return peers, nil
+}
+
+func (s *PropSweeper) leader(ctx context.Context, round int64, peers peer.IDSlice) (peer.ID, error) {
+	return elector.NewCoordinatorElector(fmt.Sprintf("%s-%d", SweepSessionID, round)).Coordinator(ctx, peers)
+}
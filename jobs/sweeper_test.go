@@ -0,0 +1,312 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package jobs_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/comm/elector"
+	mock_comm "github.com/ChainSafe/sygma-relayer/comm/mock"
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/jobs"
+	mock_jobs "github.com/ChainSafe/sygma-relayer/jobs/mock"
+	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/ChainSafe/sygma-relayer/store/lvldb"
+	"github.com/ChainSafe/sygma-relayer/topology"
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+type PropSweeperTestSuite struct {
+	suite.Suite
+	db                *lvldb.LVLDB
+	propStore         *store.PropStore
+	hosts             []host.Host
+	mockCommunication *mock_comm.MockCommunication
+	mockChecker       *mock_jobs.MockExecutionChecker
+	mockMessageQueue  *mock_jobs.MockMessageQueue
+	mockTopologyStore *mock_jobs.MockTopologyStorer
+	topologyPeers     peer.IDSlice
+	config            relayer.SweeperConfig
+	sweeper           *jobs.PropSweeper
+}
+
+func TestRunPropSweeperTestSuite(t *testing.T) {
+	suite.Run(t, new(PropSweeperTestSuite))
+}
+
+func (s *PropSweeperTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	s.db = db
+	s.propStore = store.NewPropStore(db)
+
+	s.hosts = []host.Host{}
+	for i := 0; i < 3; i++ {
+		h, err := libp2p.New(libp2p.DisableRelay())
+		s.Nil(err)
+		s.hosts = append(s.hosts, h)
+	}
+	for _, h := range s.hosts {
+		for _, p := range s.hosts {
+			h.Peerstore().AddAddr(p.ID(), p.Addrs()[0], peerstore.PermanentAddrTTL)
+		}
+	}
+	networkTopology := &topology.NetworkTopology{}
+	s.topologyPeers = peer.IDSlice{}
+	for _, h := range s.hosts {
+		networkTopology.Peers = append(networkTopology.Peers, &peer.AddrInfo{ID: h.ID(), Addrs: h.Addrs()})
+		s.topologyPeers = append(s.topologyPeers, h.ID())
+	}
+
+	s.mockCommunication = mock_comm.NewMockCommunication(ctrl)
+	s.mockChecker = mock_jobs.NewMockExecutionChecker(ctrl)
+	s.mockMessageQueue = mock_jobs.NewMockMessageQueue(ctrl)
+	s.mockTopologyStore = mock_jobs.NewMockTopologyStorer(ctrl)
+	s.mockTopologyStore.EXPECT().Topology().Return(networkTopology, nil).AnyTimes()
+	s.config = relayer.SweeperConfig{
+		Enabled:     true,
+		Interval:    time.Minute,
+		StuckAfter:  time.Minute,
+		BackOff:     time.Hour,
+		MaxBackOff:  4 * time.Hour,
+		MaxAttempts: 2,
+	}
+	s.sweeper = jobs.NewPropSweeper(
+		s.hosts[0],
+		s.mockCommunication,
+		s.mockTopologyStore,
+		s.propStore,
+		map[uint8]jobs.ExecutionChecker{2: s.mockChecker},
+		s.mockMessageQueue,
+		s.config)
+}
+
+func (s *PropSweeperTestSuite) TearDownTest() {
+	_ = s.db.Close()
+	for _, h := range s.hosts {
+		_ = h.Close()
+	}
+}
+
+// roundTime returns a time after now in a sweep round led by the provided peer
+func (s *PropSweeperTestSuite) roundTime(after time.Duration, leader peer.ID) time.Time {
+	now := time.Now().Add(after)
+	for {
+		if s.roundLeader(now, s.topologyPeers) == leader {
+			return now
+		}
+		now = now.Add(s.config.Interval)
+	}
+}
+
+func (s *PropSweeperTestSuite) roundLeader(now time.Time, peers peer.IDSlice) peer.ID {
+	round := now.UnixNano() / int64(s.config.Interval)
+	leader, err := elector.NewCoordinatorElector(fmt.Sprintf("%s-%d", jobs.SweepSessionID, round)).Coordinator(
+		context.Background(), peers)
+	s.Nil(err)
+	return leader
+}
+
+func (s *PropSweeperTestSuite) storeStuckProp(nonce uint64, status store.PropStatus, depositBlock uint64) {
+	s.Nil(s.propStore.StorePropDeposit(1, 2, nonce, [32]byte{1}, depositBlock))
+	s.Nil(s.propStore.StorePropStatus(1, 2, nonce, status))
+}
+
+func (s *PropSweeperTestSuite) Test_Sweep_NotLeader() {
+	s.storeStuckProp(1, store.PendingProp, 100)
+
+	err := s.sweeper.Sweep(context.Background(), s.roundTime(time.Hour, s.hosts[1].ID()))
+
+	s.Nil(err)
+}
+
+func (s *PropSweeperTestSuite) Test_Sweep_RetriesStuckProposals() {
+	s.storeStuckProp(1, store.PendingProp, 100)
+	s.storeStuckProp(2, store.FailedProp, 100)
+	s.storeStuckProp(3, store.FailedProp, 101)
+	s.Nil(s.propStore.StorePropStatus(1, 3, 4, store.FailedProp))
+	now := s.roundTime(time.Hour, s.hosts[0].ID())
+	round := now.UnixNano() / int64(s.config.Interval)
+
+	s.mockChecker.EXPECT().AreProposalsExecuted(gomock.Any()).DoAndReturn(func(proposals []*transfer.TransferProposal) ([]bool, error) {
+		s.Equal(len(proposals), 3)
+		return []bool{false, false, true}, nil
+	})
+	s.mockCommunication.EXPECT().Broadcast(gomock.Any(), gomock.Any(), comm.SweepMsg, jobs.SweepSessionID).DoAndReturn(
+		func(peers peer.IDSlice, payload []byte, msgType comm.MessageType, sessionID string) error {
+			sweepMsg := &jobs.SweepMessage{}
+			s.Nil(json.Unmarshal(payload, sweepMsg))
+			s.Equal(sweepMsg.Round, round)
+			s.Equal(len(sweepMsg.Retries), 1)
+			s.Equal(sweepMsg.Retries[0].Props, []store.PropKey{
+				{Source: 1, Destination: 2, DepositNonce: 1},
+				{Source: 1, Destination: 2, DepositNonce: 2},
+			})
+			return nil
+		})
+	expectedMessageID := fmt.Sprintf("retry-sweep-%d-1-2-1", round)
+	s.mockMessageQueue.EXPECT().Enqueue(big.NewInt(100), gomock.Any()).DoAndReturn(
+		func(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+			msgs := domainMessages[1]
+			s.Equal(len(msgs), 1)
+			s.Equal(msgs[0].ID, expectedMessageID)
+			s.Equal(msgs[0].Type, retry.RetryMessageType)
+			s.Equal(msgs[0].Data, retry.RetryMessageData{
+				SourceDomainID:      1,
+				DestinationDomainID: 2,
+				BlockHeight:         big.NewInt(100),
+				ResourceID:          [32]byte{1},
+			})
+			return nil
+		})
+
+	err := s.sweeper.Sweep(context.Background(), now)
+
+	s.Nil(err)
+	record, err := s.propStore.PropRecord(1, 2, 1)
+	s.Nil(err)
+	s.Equal(record.Sweeps, uint64(1))
+	record, err = s.propStore.PropRecord(1, 2, 3)
+	s.Nil(err)
+	s.Equal(record.Status, store.ExecutedProp)
+}
+
+func (s *PropSweeperTestSuite) Test_Sweep_BackOffNotPassed() {
+	s.storeStuckProp(1, store.PendingProp, 100)
+	s.Nil(s.propStore.StorePropSweep(1, 2, 1))
+
+	err := s.sweeper.Sweep(context.Background(), s.roundTime(30*time.Minute, s.hosts[0].ID()))
+
+	s.Nil(err)
+}
+
+func (s *PropSweeperTestSuite) Test_Sweep_LeaderElectedFromTopologyPeers() {
+	stranger, err := libp2p.New(libp2p.DisableRelay())
+	s.Nil(err)
+	defer stranger.Close()
+	s.hosts[0].Peerstore().AddAddr(stranger.ID(), stranger.Addrs()[0], peerstore.PermanentAddrTTL)
+	s.storeStuckProp(1, store.PendingProp, 100)
+	now := time.Now().Add(time.Hour)
+	for s.roundLeader(now, s.topologyPeers) != s.hosts[0].ID() || s.roundLeader(now, s.hosts[0].Peerstore().Peers()) != stranger.ID() {
+		now = now.Add(s.config.Interval)
+	}
+
+	s.mockChecker.EXPECT().AreProposalsExecuted(gomock.Any()).Return([]bool{false}, nil)
+	s.mockCommunication.EXPECT().Broadcast(s.topologyPeers, gomock.Any(), comm.SweepMsg, jobs.SweepSessionID).Return(nil)
+	s.mockMessageQueue.EXPECT().Enqueue(big.NewInt(100), gomock.Any()).Return(nil)
+
+	err = s.sweeper.Sweep(context.Background(), now)
+
+	s.Nil(err)
+}
+
+func (s *PropSweeperTestSuite) Test_Sweep_BackOffCapped() {
+	s.config.MaxAttempts = 100
+	s.sweeper = jobs.NewPropSweeper(
+		s.hosts[0],
+		s.mockCommunication,
+		s.mockTopologyStore,
+		s.propStore,
+		map[uint8]jobs.ExecutionChecker{2: s.mockChecker},
+		s.mockMessageQueue,
+		s.config)
+	s.storeStuckProp(1, store.PendingProp, 100)
+	for i := 0; i < 70; i++ {
+		s.Nil(s.propStore.StorePropSweep(1, 2, 1))
+	}
+
+	s.mockChecker.EXPECT().AreProposalsExecuted(gomock.Any()).Return([]bool{false}, nil)
+	s.mockCommunication.EXPECT().Broadcast(gomock.Any(), gomock.Any(), comm.SweepMsg, jobs.SweepSessionID).Return(nil)
+	s.mockMessageQueue.EXPECT().Enqueue(big.NewInt(100), gomock.Any()).Return(nil)
+
+	err := s.sweeper.Sweep(context.Background(), s.roundTime(s.config.MaxBackOff, s.hosts[0].ID()))
+
+	s.Nil(err)
+}
+
+func (s *PropSweeperTestSuite) Test_Sweep_MaxAttemptsReached() {
+	s.storeStuckProp(1, store.PendingProp, 100)
+	s.Nil(s.propStore.StorePropSweep(1, 2, 1))
+	s.Nil(s.propStore.StorePropSweep(1, 2, 1))
+
+	err := s.sweeper.Sweep(context.Background(), s.roundTime(24*time.Hour, s.hosts[0].ID()))
+
+	s.Nil(err)
+}
+
+func (s *PropSweeperTestSuite) Test_SweepNow_RetriesWithoutLeaderOrBroadcast() {
+	s.storeStuckProp(1, store.PendingProp, 100)
+	now := s.roundTime(time.Hour, s.hosts[1].ID())
+
+	s.mockChecker.EXPECT().AreProposalsExecuted(gomock.Any()).Return([]bool{false}, nil)
+	s.mockMessageQueue.EXPECT().Enqueue(big.NewInt(100), gomock.Any()).Return(nil)
+
+	retries, err := s.sweeper.SweepNow(now)
+
+	s.Nil(err)
+	s.Equal(retries, 1)
+	record, err := s.propStore.PropRecord(1, 2, 1)
+	s.Nil(err)
+	s.Equal(record.Sweeps, uint64(1))
+}
+
+func (s *PropSweeperTestSuite) Test_HandleSweepMessage_NotFromLeader() {
+	now := s.roundTime(0, s.hosts[1].ID())
+	payload, _ := json.Marshal(jobs.SweepMessage{
+		Round: now.UnixNano() / int64(s.config.Interval),
+	})
+
+	err := s.sweeper.HandleSweepMessage(context.Background(), &comm.WrappedMessage{
+		From:    s.hosts[2].ID(),
+		Payload: payload,
+	}, now)
+
+	s.NotNil(err)
+}
+
+func (s *PropSweeperTestSuite) Test_HandleSweepMessage_ValidMessage() {
+	s.storeStuckProp(1, store.PendingProp, 100)
+	now := s.roundTime(0, s.hosts[1].ID())
+	payload, _ := json.Marshal(jobs.SweepMessage{
+		Round: now.UnixNano() / int64(s.config.Interval),
+		Retries: []jobs.SweepRetry{
+			{
+				MessageID: "retry-sweep-1-1-2-1",
+				Retry: retry.RetryMessageData{
+					SourceDomainID:      1,
+					DestinationDomainID: 2,
+					BlockHeight:         big.NewInt(100),
+					ResourceID:          [32]byte{1},
+				},
+				Props: []store.PropKey{{Source: 1, Destination: 2, DepositNonce: 1}},
+			},
+		},
+	})
+	s.mockMessageQueue.EXPECT().Enqueue(big.NewInt(100), gomock.Any()).Return(nil)
+
+	err := s.sweeper.HandleSweepMessage(context.Background(), &comm.WrappedMessage{
+		From:    s.hosts[1].ID(),
+		Payload: payload,
+	}, now)
+
+	s.Nil(err)
+	record, err := s.propStore.PropRecord(1, 2, 1)
+	s.Nil(err)
+	s.Equal(record.Sweeps, uint64(1))
+}
@@ -0,0 +1,93 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metrics
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	api "go.opentelemetry.io/otel/metric"
+)
+
+type accountKey struct {
+	domainID uint8
+	account  string
+}
+
+type BalanceMetrics struct {
+	senderBalanceGauge api.Float64ObservableGauge
+	senderRunwayGauge  api.Float64ObservableGauge
+	balances           map[accountKey]float64
+	runways            map[accountKey]float64
+	lock               sync.Mutex
+}
+
+// NewBalanceMetrics initializes metrics related to balances of submitting accounts
+func NewBalanceMetrics(ctx context.Context, meter metric.Meter, opts metric.MeasurementOption) (*BalanceMetrics, error) {
+	m := &BalanceMetrics{
+		balances: make(map[accountKey]float64),
+		runways:  make(map[accountKey]float64),
+	}
+
+	senderBalanceGauge, err := meter.Float64ObservableGauge(
+		"relayer.SenderBalance",
+		api.WithDescription("Balance of the submitting account per domain in the smallest unit of the native token"),
+		api.WithFloat64Callback(func(ctx context.Context, result api.Float64Observer) error {
+			m.observe(m.balances, result, opts)
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.senderBalanceGauge = senderBalanceGauge
+
+	senderRunwayGauge, err := meter.Float64ObservableGauge(
+		"relayer.SenderRunwayDays",
+		api.WithDescription("Estimated days until the submitting account of the domain runs out of funds at the recent spend rate"),
+		api.WithFloat64Callback(func(ctx context.Context, result api.Float64Observer) error {
+			m.observe(m.runways, result, opts)
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.senderRunwayGauge = senderRunwayGauge
+
+	return m, nil
+}
+
+func (m *BalanceMetrics) TrackSenderBalance(domainID uint8, account string, balance *big.Int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	value, _ := new(big.Float).SetInt(balance).Float64()
+	m.balances[accountKey{domainID: domainID, account: account}] = value
+}
+
+func (m *BalanceMetrics) TrackSenderRunway(domainID uint8, account string, days float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.runways[accountKey{domainID: domainID, account: account}] = days
+}
+
+func (m *BalanceMetrics) observe(values map[accountKey]float64, result api.Float64Observer, opts api.MeasurementOption) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for key, value := range values {
+		result.Observe(
+			value,
+			opts,
+			api.WithAttributes(
+				attribute.Int64("domainID", int64(key.domainID)),
+				attribute.String("account", key.account),
+			),
+		)
+	}
+}
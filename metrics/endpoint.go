@@ -0,0 +1,93 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	api "go.opentelemetry.io/otel/metric"
+)
+
+type endpointKey struct {
+	domainID uint8
+	endpoint string
+}
+
+type EndpointMetrics struct {
+	opts                   api.MeasurementOption
+	endpointRequestCounter api.Int64Counter
+	endpointHealthGauge    api.Int64ObservableGauge
+	endpointHealth         map[endpointKey]int64
+	lock                   sync.Mutex
+}
+
+// NewEndpointMetrics initializes metrics related to RPC endpoints of domains
+func NewEndpointMetrics(ctx context.Context, meter metric.Meter, opts metric.MeasurementOption) (*EndpointMetrics, error) {
+	m := &EndpointMetrics{
+		opts:           opts,
+		endpointHealth: make(map[endpointKey]int64),
+	}
+
+	endpointRequestCounter, err := meter.Int64Counter(
+		"relayer.EndpointRequests",
+		api.WithDescription("Number of requests sent to RPC endpoints per domain, endpoint and result"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.endpointRequestCounter = endpointRequestCounter
+
+	endpointHealthGauge, err := meter.Int64ObservableGauge(
+		"relayer.EndpointHealthy",
+		api.WithDescription("1 if the RPC endpoint of the domain is healthy, 0 otherwise"),
+		api.WithInt64Callback(func(ctx context.Context, result api.Int64Observer) error {
+			m.lock.Lock()
+			defer m.lock.Unlock()
+			for key, healthy := range m.endpointHealth {
+				result.Observe(
+					healthy,
+					opts,
+					api.WithAttributes(
+						attribute.Int64("domainID", int64(key.domainID)),
+						attribute.String("endpoint", key.endpoint),
+					),
+				)
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.endpointHealthGauge = endpointHealthGauge
+
+	return m, nil
+}
+
+func (m *EndpointMetrics) TrackEndpointRequest(domainID uint8, endpoint string, success bool) {
+	m.endpointRequestCounter.Add(
+		context.Background(),
+		1,
+		m.opts,
+		api.WithAttributes(
+			attribute.Int64("domainID", int64(domainID)),
+			attribute.String("endpoint", endpoint),
+			attribute.Bool("success", success),
+		),
+	)
+}
+
+func (m *EndpointMetrics) TrackEndpointHealth(domainID uint8, endpoint string, healthy bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	value := int64(0)
+	if healthy {
+		value = 1
+	}
+	m.endpointHealth[endpointKey{domainID: domainID, endpoint: endpoint}] = value
+}
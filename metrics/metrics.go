@@ -15,6 +15,10 @@ type SygmaMetrics struct {
 	*observability.RelayerMetrics
 	*MpcMetrics
 	*HostMetrics
+	*PolicyMetrics
+	*EndpointMetrics
+	*ReorgMetrics
+	*BalanceMetrics
 }
 
 // NewSygmaMetrics creates an instance of metrics
@@ -36,9 +40,33 @@ func NewSygmaMetrics(ctx context.Context, meter api.Meter, env, relayerID, versi
 		return nil, err
 	}
 
+	policyMetrics, err := NewPolicyMetrics(ctx, meter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	endpointMetrics, err := NewEndpointMetrics(ctx, meter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	reorgMetrics, err := NewReorgMetrics(ctx, meter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	balanceMetrics, err := NewBalanceMetrics(ctx, meter, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	return &SygmaMetrics{
-		RelayerMetrics: relayerMetrics,
-		MpcMetrics:     mpcMetrics,
-		HostMetrics:    hostMetrics,
+		RelayerMetrics:  relayerMetrics,
+		MpcMetrics:      mpcMetrics,
+		HostMetrics:     hostMetrics,
+		PolicyMetrics:   policyMetrics,
+		EndpointMetrics: endpointMetrics,
+		ReorgMetrics:    reorgMetrics,
+		BalanceMetrics:  balanceMetrics,
 	}, nil
 }
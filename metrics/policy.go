@@ -0,0 +1,46 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	api "go.opentelemetry.io/otel/metric"
+)
+
+type PolicyMetrics struct {
+	opts                    api.MeasurementOption
+	policyRejectionsCounter api.Int64Counter
+}
+
+// NewPolicyMetrics initializes metrics related to the signing policy
+func NewPolicyMetrics(ctx context.Context, meter metric.Meter, opts metric.MeasurementOption) (*PolicyMetrics, error) {
+	policyRejectionsCounter, err := meter.Int64Counter(
+		"relayer.PolicyRejections",
+		api.WithDescription("Number of transfers the signing policy refused to sign"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PolicyMetrics{
+		opts:                    opts,
+		policyRejectionsCounter: policyRejectionsCounter,
+	}, nil
+}
+
+func (m *PolicyMetrics) TrackPolicyRejection(source, destination uint8, rule string) {
+	m.policyRejectionsCounter.Add(
+		context.Background(),
+		1,
+		m.opts,
+		api.WithAttributes(
+			attribute.Int64("source", int64(source)),
+			attribute.Int64("destination", int64(destination)),
+			attribute.String("rule", rule),
+		),
+	)
+}
@@ -0,0 +1,71 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	api "go.opentelemetry.io/otel/metric"
+)
+
+type ReorgMetrics struct {
+	opts                   api.MeasurementOption
+	reorgCounter           api.Int64Counter
+	reorgDepthHistogram    api.Int64Histogram
+	reorgedDepositsCounter api.Int64Counter
+}
+
+// NewReorgMetrics initializes metrics related to reorgs of source domains
+func NewReorgMetrics(ctx context.Context, meter metric.Meter, opts metric.MeasurementOption) (*ReorgMetrics, error) {
+	reorgCounter, err := meter.Int64Counter(
+		"relayer.Reorgs",
+		api.WithDescription("Number of reorgs of already processed blocks per domain"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	reorgDepthHistogram, err := meter.Int64Histogram(
+		"relayer.ReorgDepth",
+		api.WithDescription("Number of re-scanned blocks per reorg"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	reorgedDepositsCounter, err := meter.Int64Counter(
+		"relayer.ReorgedDeposits",
+		api.WithDescription("Number of relayed deposits that vanished in a reorg per route"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReorgMetrics{
+		opts:                   opts,
+		reorgCounter:           reorgCounter,
+		reorgDepthHistogram:    reorgDepthHistogram,
+		reorgedDepositsCounter: reorgedDepositsCounter,
+	}, nil
+}
+
+func (m *ReorgMetrics) TrackReorg(domainID uint8, depth uint64) {
+	attributes := api.WithAttributes(attribute.Int64("domainID", int64(domainID)))
+	m.reorgCounter.Add(context.Background(), 1, m.opts, attributes)
+	m.reorgDepthHistogram.Record(context.Background(), int64(depth), m.opts, attributes)
+}
+
+func (m *ReorgMetrics) TrackReorgedDeposit(source, destination uint8) {
+	m.reorgedDepositsCounter.Add(
+		context.Background(),
+		1,
+		m.opts,
+		api.WithAttributes(
+			attribute.Int64("source", int64(source)),
+			attribute.Int64("destination", int64(destination)),
+		),
+	)
+}
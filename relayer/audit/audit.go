@@ -0,0 +1,43 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package audit
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// Log is an append only trail of security relevant relayer decisions
+// and operator actions written as JSON lines.
+type Log struct {
+	logger zerolog.Logger
+	lock   sync.Mutex
+}
+
+// NewLog opens the audit log file in append mode, creating it if it does not exist
+func NewLog(path string) (*Log, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLogWithWriter(file), nil
+}
+
+func NewLogWithWriter(w io.Writer) *Log {
+	return &Log{
+		logger: zerolog.New(w).With().Timestamp().Logger(),
+	}
+}
+
+// Record appends an entry describing the action taken by the component
+func (l *Log) Record(component string, action string, details map[string]interface{}) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.logger.Log().Str("component", component).Str("action", action).Fields(details).Send()
+}
@@ -0,0 +1,51 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package audit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/audit"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditLogTestSuite struct {
+	suite.Suite
+}
+
+func TestRunAuditLogTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditLogTestSuite))
+}
+
+func (s *AuditLogTestSuite) Test_Record_WritesJSONLine() {
+	buf := &bytes.Buffer{}
+	log := audit.NewLogWithWriter(buf)
+
+	log.Record("policy", "reject", map[string]interface{}{"source": 1})
+
+	entry := make(map[string]interface{})
+	s.Nil(json.Unmarshal(buf.Bytes(), &entry))
+	s.Equal(entry["component"], "policy")
+	s.Equal(entry["action"], "reject")
+	s.Equal(entry["source"], float64(1))
+	s.NotNil(entry["time"])
+}
+
+func (s *AuditLogTestSuite) Test_NewLog_AppendsToFile() {
+	path := s.T().TempDir() + "/audit.log"
+	log, err := audit.NewLog(path)
+	s.Nil(err)
+	log.Record("policy", "reject", nil)
+
+	log, err = audit.NewLog(path)
+	s.Nil(err)
+	log.Record("policy", "reject", nil)
+
+	content, err := os.ReadFile(path)
+	s.Nil(err)
+	s.Equal(bytes.Count(content, []byte("\n")), 2)
+}
@@ -0,0 +1,180 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dedup
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/relayer"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+)
+
+type SeenStorer interface {
+	MarkSeen(source, destination uint8, depositNonce uint64, resourceID [32]byte) error
+	IsSeen(source, destination uint8, depositNonce uint64, resourceID [32]byte) (bool, error)
+}
+
+type PropStorer interface {
+	PropStatus(source, destination uint8, depositNonce uint64) (store.PropStatus, error)
+	StorePropDeposit(source, destination uint8, depositNonce uint64, resourceID [32]byte, depositBlock uint64) error
+}
+
+type depositKey struct {
+	source       uint8
+	destination  uint8
+	depositNonce uint64
+	resourceID   [32]byte
+}
+
+func (k depositKey) String() string {
+	return fmt.Sprintf("%d-%d-%d-%x", k.source, k.destination, k.depositNonce, k.resourceID)
+}
+
+type trackedDeposit struct {
+	key          depositKey
+	depositBlock uint64
+}
+
+// DeduplicatingChain wraps a relayed chain and drops transfer messages for deposits that are
+// already being relayed or were relayed before. Deposits are identified by source, destination,
+// deposit nonce and resource ID so copies coming from deposit handlers, retries and re-scans collapse.
+//
+// An explicit retry overrides a previously relayed deposit unless the proposal
+// is recorded as executed or pending.
+type DeduplicatingChain struct {
+	relayer.RelayedChain
+	seenStorer SeenStorer
+	propStorer PropStorer
+
+	inFlight  map[depositKey]bool
+	deposits  map[*proposal.Proposal]trackedDeposit
+	stateLock sync.Mutex
+}
+
+func NewDeduplicatingChain(chain relayer.RelayedChain, seenStorer SeenStorer, propStorer PropStorer) *DeduplicatingChain {
+	return &DeduplicatingChain{
+		RelayedChain: chain,
+		seenStorer:   seenStorer,
+		propStorer:   propStorer,
+		inFlight:     make(map[depositKey]bool),
+		deposits:     make(map[*proposal.Proposal]trackedDeposit),
+	}
+}
+
+// ReceiveMessage forwards the message to the wrapped chain if the deposit
+// was not yet relayed. Duplicates are dropped by returning an empty proposal.
+func (c *DeduplicatingChain) ReceiveMessage(m *message.Message) (*proposal.Proposal, error) {
+	data, ok := m.Data.(transfer.TransferMessageData)
+	if m.Type != transfer.TransferMessageType || !ok {
+		return c.RelayedChain.ReceiveMessage(m)
+	}
+
+	key := depositKey{
+		source:       m.Source,
+		destination:  m.Destination,
+		depositNonce: data.DepositNonce,
+		resourceID:   data.ResourceId,
+	}
+	c.stateLock.Lock()
+	isDuplicate, err := c.isDuplicate(key, m)
+	if err != nil || isDuplicate {
+		c.stateLock.Unlock()
+		if isDuplicate {
+			log.Info().Str("messageID", m.ID).Uint8("domainID", c.DomainID()).Msgf("Dropping duplicate deposit %s", key)
+		}
+		return nil, err
+	}
+	c.inFlight[key] = true
+	c.stateLock.Unlock()
+
+	prop, err := c.RelayedChain.ReceiveMessage(m)
+
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+	if err != nil || prop == nil {
+		delete(c.inFlight, key)
+		return prop, err
+	}
+
+	c.deposits[prop] = trackedDeposit{key: key, depositBlock: data.DepositBlock}
+	return prop, nil
+}
+
+// Write submits proposals to the wrapped chain and records
+// successfully written deposits as seen together with their location
+// on the source domain.
+func (c *DeduplicatingChain) Write(props []*proposal.Proposal) error {
+	err := c.RelayedChain.Write(props)
+
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+	for _, prop := range props {
+		deposit, ok := c.deposits[prop]
+		if !ok {
+			continue
+		}
+
+		key := deposit.key
+		delete(c.deposits, prop)
+		delete(c.inFlight, key)
+		if err != nil {
+			continue
+		}
+
+		seenErr := c.seenStorer.MarkSeen(key.source, key.destination, key.depositNonce, key.resourceID)
+		if seenErr != nil {
+			log.Err(seenErr).Uint8("domainID", c.DomainID()).Msgf("Failed marking deposit %s as seen", key)
+		}
+		depositErr := c.propStorer.StorePropDeposit(key.source, key.destination, key.depositNonce, key.resourceID, deposit.depositBlock)
+		if depositErr != nil {
+			log.Err(depositErr).Uint8("domainID", c.DomainID()).Msgf("Failed storing deposit %s", key)
+		}
+	}
+	return err
+}
+
+// Release drops proposals that wrapping chains refused to write without recording
+// their deposits as seen, so later copies of the deposits are relayed again.
+func (c *DeduplicatingChain) Release(props []*proposal.Proposal) {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+	for _, prop := range props {
+		deposit, ok := c.deposits[prop]
+		if !ok {
+			continue
+		}
+
+		delete(c.deposits, prop)
+		delete(c.inFlight, deposit.key)
+	}
+}
+
+func (c *DeduplicatingChain) isDuplicate(key depositKey, m *message.Message) (bool, error) {
+	if c.inFlight[key] {
+		return true, nil
+	}
+
+	isSeen, err := c.seenStorer.IsSeen(key.source, key.destination, key.depositNonce, key.resourceID)
+	if err != nil {
+		return false, err
+	}
+	if !isSeen {
+		return false, nil
+	}
+	if !retry.IsRetry(m) {
+		return true, nil
+	}
+
+	status, err := c.propStorer.PropStatus(key.source, key.destination, key.depositNonce)
+	if err != nil {
+		return false, err
+	}
+	return status == store.ExecutedProp || status == store.PendingProp, nil
+}
@@ -0,0 +1,192 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dedup_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/dedup"
+	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/ChainSafe/sygma-relayer/store/lvldb"
+	"github.com/stretchr/testify/suite"
+	mock_relayer "github.com/sygmaprotocol/sygma-core/mock"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+	"go.uber.org/mock/gomock"
+)
+
+type DeduplicatingChainTestSuite struct {
+	suite.Suite
+	db        *lvldb.LVLDB
+	propStore *store.PropStore
+	seenStore *store.SeenStore
+	mockChain *mock_relayer.MockRelayedChain
+	chain     *dedup.DeduplicatingChain
+}
+
+func TestRunDeduplicatingChainTestSuite(t *testing.T) {
+	suite.Run(t, new(DeduplicatingChainTestSuite))
+}
+
+func (s *DeduplicatingChainTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	s.db = db
+	s.propStore = store.NewPropStore(db)
+	s.seenStore = store.NewSeenStore(db)
+	s.mockChain = mock_relayer.NewMockRelayedChain(ctrl)
+	s.mockChain.EXPECT().DomainID().Return(uint8(2)).AnyTimes()
+	s.chain = dedup.NewDeduplicatingChain(s.mockChain, s.seenStore, s.propStore)
+}
+
+func (s *DeduplicatingChainTestSuite) TearDownTest() {
+	_ = s.db.Close()
+}
+
+func transferMessage(id string) *message.Message {
+	return message.NewMessage(1, 2, transfer.TransferMessageData{
+		DepositNonce: 3,
+		ResourceId:   [32]byte{1},
+	}, id, transfer.TransferMessageType, time.Time{})
+}
+
+func (s *DeduplicatingChainTestSuite) expectProposal(m *message.Message) *proposal.Proposal {
+	prop := proposal.NewProposal(m.Source, m.Destination, m.Data, m.ID, transfer.TransferProposalType)
+	s.mockChain.EXPECT().ReceiveMessage(m).Return(prop, nil)
+	return prop
+}
+
+func (s *DeduplicatingChainTestSuite) Test_ReceiveMessage_NonTransferMessagePassedThrough() {
+	m := message.NewMessage(1, 2, retry.RetryMessageData{}, "retry-1-2", retry.RetryMessageType, time.Time{})
+	s.mockChain.EXPECT().ReceiveMessage(m).Return(nil, nil)
+
+	prop, err := s.chain.ReceiveMessage(m)
+
+	s.Nil(err)
+	s.Nil(prop)
+}
+
+func (s *DeduplicatingChainTestSuite) Test_ReceiveMessage_InFlightDuplicateDropped() {
+	m := transferMessage("1-2-0-5")
+	prop := s.expectProposal(m)
+
+	p1, err := s.chain.ReceiveMessage(m)
+	s.Nil(err)
+	s.Equal(p1, prop)
+
+	p2, err := s.chain.ReceiveMessage(transferMessage("1-2-5-10"))
+	s.Nil(err)
+	s.Nil(p2)
+}
+
+func (s *DeduplicatingChainTestSuite) Test_ReceiveMessage_SeenDuplicateDropped() {
+	m := transferMessage("1-2-0-5")
+	prop := s.expectProposal(m)
+	s.mockChain.EXPECT().Write([]*proposal.Proposal{prop}).Return(nil)
+	_, err := s.chain.ReceiveMessage(m)
+	s.Nil(err)
+	err = s.chain.Write([]*proposal.Proposal{prop})
+	s.Nil(err)
+
+	p, err := dedup.NewDeduplicatingChain(s.mockChain, s.seenStore, s.propStore).ReceiveMessage(transferMessage("1-2-0-5"))
+
+	s.Nil(err)
+	s.Nil(p)
+}
+
+func (s *DeduplicatingChainTestSuite) Test_Write_StoresDepositLocation() {
+	m := message.NewMessage(1, 2, transfer.TransferMessageData{
+		DepositNonce: 3,
+		ResourceId:   [32]byte{1},
+		DepositBlock: 100,
+	}, "1-2-95-100", transfer.TransferMessageType, time.Time{})
+	prop := s.expectProposal(m)
+	s.mockChain.EXPECT().Write([]*proposal.Proposal{prop}).Return(nil)
+	_, err := s.chain.ReceiveMessage(m)
+	s.Nil(err)
+
+	err = s.chain.Write([]*proposal.Proposal{prop})
+
+	s.Nil(err)
+	record, err := s.propStore.PropRecord(1, 2, 3)
+	s.Nil(err)
+	s.Equal(record.DepositBlock, uint64(100))
+	s.Equal(record.ResourceID, "0100000000000000000000000000000000000000000000000000000000000000")
+}
+
+func (s *DeduplicatingChainTestSuite) Test_ReceiveMessage_FailedWriteNotMarkedSeen() {
+	m := transferMessage("1-2-0-5")
+	prop := s.expectProposal(m)
+	s.mockChain.EXPECT().Write([]*proposal.Proposal{prop}).Return(fmt.Errorf("error"))
+	_, err := s.chain.ReceiveMessage(m)
+	s.Nil(err)
+	err = s.chain.Write([]*proposal.Proposal{prop})
+	s.NotNil(err)
+
+	replayed := transferMessage("1-2-0-5")
+	replayedProp := s.expectProposal(replayed)
+	p, err := s.chain.ReceiveMessage(replayed)
+
+	s.Nil(err)
+	s.Equal(p, replayedProp)
+}
+
+func (s *DeduplicatingChainTestSuite) Test_ReceiveMessage_RetryOverridesFailure() {
+	m := transferMessage("1-2-0-5")
+	prop := s.expectProposal(m)
+	s.mockChain.EXPECT().Write([]*proposal.Proposal{prop}).Return(nil)
+	_, err := s.chain.ReceiveMessage(m)
+	s.Nil(err)
+	err = s.chain.Write([]*proposal.Proposal{prop})
+	s.Nil(err)
+	err = s.propStore.StorePropStatus(1, 2, 3, store.FailedProp)
+	s.Nil(err)
+
+	retried := transferMessage("retry-1-2")
+	retriedProp := s.expectProposal(retried)
+	p, err := s.chain.ReceiveMessage(retried)
+
+	s.Nil(err)
+	s.Equal(p, retriedProp)
+}
+
+func (s *DeduplicatingChainTestSuite) Test_ReceiveMessage_RetryOfExecutedDropped() {
+	m := transferMessage("1-2-0-5")
+	prop := s.expectProposal(m)
+	s.mockChain.EXPECT().Write([]*proposal.Proposal{prop}).Return(nil)
+	_, err := s.chain.ReceiveMessage(m)
+	s.Nil(err)
+	err = s.chain.Write([]*proposal.Proposal{prop})
+	s.Nil(err)
+	err = s.propStore.StorePropStatus(1, 2, 3, store.ExecutedProp)
+	s.Nil(err)
+
+	p, err := s.chain.ReceiveMessage(transferMessage("retry-1-2"))
+
+	s.Nil(err)
+	s.Nil(p)
+}
+
+func (s *DeduplicatingChainTestSuite) Test_Release_ReleasedDepositRelayedAgain() {
+	m := transferMessage("1-2-0-5")
+	prop := s.expectProposal(m)
+	_, err := s.chain.ReceiveMessage(m)
+	s.Nil(err)
+	s.chain.Release([]*proposal.Proposal{prop})
+
+	replayed := transferMessage("1-2-0-5")
+	replayedProp := s.expectProposal(replayed)
+	p, err := s.chain.ReceiveMessage(replayed)
+
+	s.Nil(err)
+	s.Equal(p, replayedProp)
+	isSeen, err := s.seenStore.IsSeen(1, 2, 3, [32]byte{1})
+	s.Nil(err)
+	s.False(isSeen)
+}
@@ -0,0 +1,157 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package funding
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog/log"
+)
+
+const FundingSessionID = "funding"
+
+// FundingMessage is broadcasted periodically by every relayer with domains
+// its submitting accounts cannot fund execution transactions on
+type FundingMessage struct {
+	Underfunded []uint8 `json:"underfunded"`
+}
+
+type fundingStatus struct {
+	underfunded map[uint8]bool
+	updatedAt   time.Time
+}
+
+// Tracker keeps funding statuses of the relayer and its peers. Statuses that are
+// not refreshed within the status TTL expire and the peer is considered funded again.
+type Tracker struct {
+	host      host.Host
+	comm      comm.Communication
+	statusTTL time.Duration
+
+	lock     sync.Mutex
+	statuses map[peer.ID]fundingStatus
+}
+
+func NewTracker(host host.Host, communication comm.Communication, statusTTL time.Duration) *Tracker {
+	return &Tracker{
+		host:      host,
+		comm:      communication,
+		statusTTL: statusTTL,
+		statuses:  make(map[peer.ID]fundingStatus),
+	}
+}
+
+// Start stores funding statuses broadcasted by peers until the context is cancelled
+func (t *Tracker) Start(ctx context.Context) {
+	msgChan := make(chan *comm.WrappedMessage)
+	subID := t.comm.Subscribe(FundingSessionID, comm.FundingMsg, msgChan)
+	defer t.comm.UnSubscribe(subID)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-msgChan:
+			fundingMsg := &FundingMessage{}
+			err := json.Unmarshal(msg.Payload, fundingMsg)
+			if err != nil {
+				log.Err(err).Str("peerID", msg.From.String()).Msg("Failed handling funding message")
+				continue
+			}
+
+			t.setStatus(msg.From, fundingMsg.Underfunded)
+		}
+	}
+}
+
+// Update stores the funding status of the relayer and broadcasts it to peers
+func (t *Tracker) Update(underfunded []uint8) {
+	t.setStatus(t.host.ID(), underfunded)
+
+	payload, err := json.Marshal(FundingMessage{Underfunded: underfunded})
+	if err != nil {
+		log.Err(err).Msg("Failed encoding funding message")
+		return
+	}
+	err = t.comm.Broadcast(t.host.Peerstore().Peers(), payload, comm.FundingMsg, FundingSessionID)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed broadcasting funding status to all relayers")
+	}
+}
+
+// FundedPeers returns peers that can fund execution transactions on the domain.
+// All peers are returned if none of them is funded.
+func (t *Tracker) FundedPeers(domainID uint8, peers []peer.ID) []peer.ID {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	funded := make([]peer.ID, 0, len(peers))
+	for _, p := range peers {
+		status, ok := t.statuses[p]
+		if ok && time.Since(status.updatedAt) < t.statusTTL && status.underfunded[domainID] {
+			continue
+		}
+		funded = append(funded, p)
+	}
+	if len(funded) == 0 {
+		return peers
+	}
+	return funded
+}
+
+// PreferFunded returns the tss process that prefers coordinators funded on the domain,
+// because the coordinator submits the execution transaction. Funding statuses can differ
+// between relayers, so they are only used when the coordinator is re-elected on retry.
+func (t *Tracker) PreferFunded(process tss.TssProcess, domainID uint8) tss.TssProcess {
+	fundedProcess := &fundedProcess{
+		TssProcess: process,
+		tracker:    t,
+		domainID:   domainID,
+	}
+	verifiableProcess, ok := process.(tss.VerifiableProcess)
+	if !ok {
+		return fundedProcess
+	}
+	return &verifiableFundedProcess{
+		fundedProcess:     fundedProcess,
+		VerifiableProcess: verifiableProcess,
+	}
+}
+
+func (t *Tracker) setStatus(peerID peer.ID, underfunded []uint8) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	domains := make(map[uint8]bool)
+	for _, domainID := range underfunded {
+		domains[domainID] = true
+	}
+	t.statuses[peerID] = fundingStatus{
+		underfunded: domains,
+		updatedAt:   time.Now(),
+	}
+}
+
+type fundedProcess struct {
+	tss.TssProcess
+	tracker  *Tracker
+	domainID uint8
+}
+
+func (p *fundedProcess) PreferredCoordinators() []peer.ID {
+	return p.tracker.FundedPeers(p.domainID, p.TssProcess.ValidCoordinators())
+}
+
+// verifiableFundedProcess keeps message hashes of wrapped verifiable processes
+// so participants still verify what the coordinator signs
+type verifiableFundedProcess struct {
+	*fundedProcess
+	tss.VerifiableProcess
+}
@@ -0,0 +1,157 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package funding_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	mock_comm "github.com/ChainSafe/sygma-relayer/comm/mock"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/relayer/funding"
+	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/signing"
+	mock_tss "github.com/ChainSafe/sygma-relayer/tss/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrackerTestSuite struct {
+	suite.Suite
+	hosts             []host.Host
+	peers             []peer.ID
+	mockCommunication *mock_comm.MockCommunication
+	mockProcess       *mock_tss.MockTssProcess
+	tracker           *funding.Tracker
+}
+
+func TestRunTrackerTestSuite(t *testing.T) {
+	suite.Run(t, new(TrackerTestSuite))
+}
+
+func (s *TrackerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.hosts = []host.Host{}
+	s.peers = []peer.ID{}
+	for i := 0; i < 3; i++ {
+		h, err := libp2p.New(libp2p.DisableRelay())
+		s.Nil(err)
+		s.hosts = append(s.hosts, h)
+		s.peers = append(s.peers, h.ID())
+	}
+	for _, p := range s.hosts {
+		s.hosts[0].Peerstore().AddAddr(p.ID(), p.Addrs()[0], peerstore.PermanentAddrTTL)
+	}
+
+	s.mockCommunication = mock_comm.NewMockCommunication(ctrl)
+	s.mockProcess = mock_tss.NewMockTssProcess(ctrl)
+	s.tracker = funding.NewTracker(s.hosts[0], s.mockCommunication, time.Minute)
+}
+
+func (s *TrackerTestSuite) Test_Update_BroadcastsStatus() {
+	payload, _ := json.Marshal(funding.FundingMessage{Underfunded: []uint8{1}})
+	s.mockCommunication.EXPECT().Broadcast(gomock.Any(), payload, comm.FundingMsg, funding.FundingSessionID).Return(nil)
+
+	s.tracker.Update([]uint8{1})
+
+	s.Equal(s.tracker.FundedPeers(1, s.peers), s.peers[1:])
+	s.Equal(s.tracker.FundedPeers(2, s.peers), s.peers)
+}
+
+func (s *TrackerTestSuite) Test_FundedPeers_AllUnderfunded() {
+	s.mockCommunication.EXPECT().Broadcast(gomock.Any(), gomock.Any(), comm.FundingMsg, funding.FundingSessionID).Return(nil)
+
+	s.tracker.Update([]uint8{1})
+
+	s.Equal(s.tracker.FundedPeers(1, s.peers[:1]), s.peers[:1])
+}
+
+func (s *TrackerTestSuite) Test_FundedPeers_ExpiredStatus() {
+	s.tracker = funding.NewTracker(s.hosts[0], s.mockCommunication, 0)
+	s.mockCommunication.EXPECT().Broadcast(gomock.Any(), gomock.Any(), comm.FundingMsg, funding.FundingSessionID).Return(nil)
+
+	s.tracker.Update([]uint8{1})
+
+	s.Equal(s.tracker.FundedPeers(1, s.peers), s.peers)
+}
+
+func (s *TrackerTestSuite) Test_Start_StoresPeerStatus() {
+	msgChan := make(chan chan<- *comm.WrappedMessage, 1)
+	s.mockCommunication.EXPECT().Subscribe(funding.FundingSessionID, comm.FundingMsg, gomock.Any()).DoAndReturn(
+		func(sessionID string, msgType comm.MessageType, channel chan *comm.WrappedMessage) comm.SubscriptionID {
+			msgChan <- channel
+			return comm.SubscriptionID("subID")
+		})
+	s.mockCommunication.EXPECT().UnSubscribe(comm.SubscriptionID("subID"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.tracker.Start(ctx)
+		close(done)
+	}()
+
+	payload, _ := json.Marshal(funding.FundingMessage{Underfunded: []uint8{1}})
+	channel := <-msgChan
+	channel <- &comm.WrappedMessage{
+		MessageType: comm.FundingMsg,
+		SessionID:   funding.FundingSessionID,
+		Payload:     payload,
+		From:        s.peers[1],
+	}
+	cancel()
+	<-done
+
+	s.Equal(s.tracker.FundedPeers(1, s.peers), []peer.ID{s.peers[0], s.peers[2]})
+}
+
+func (s *TrackerTestSuite) Test_PreferFunded_PrefersFundedCoordinators() {
+	s.mockCommunication.EXPECT().Broadcast(gomock.Any(), gomock.Any(), comm.FundingMsg, funding.FundingSessionID).Return(nil)
+	s.mockProcess.EXPECT().ValidCoordinators().Return(s.peers).Times(3)
+	s.mockProcess.EXPECT().SessionID().Return("session")
+	s.tracker.Update([]uint8{1})
+
+	process := s.tracker.PreferFunded(s.mockProcess, 1)
+	preferredProcess, ok := process.(tss.PreferredCoordinatorsProcess)
+
+	s.True(ok)
+	s.Equal(process.ValidCoordinators(), s.peers)
+	s.Equal(preferredProcess.PreferredCoordinators(), s.peers[1:])
+	s.Equal(process.SessionID(), "session")
+	s.Equal(s.tracker.PreferFunded(s.mockProcess, 2).(tss.PreferredCoordinatorsProcess).PreferredCoordinators(), s.peers)
+}
+
+func (s *TrackerTestSuite) Test_PreferFunded_KeepsSigningMessageHash() {
+	signing, err := signing.NewSigning(
+		big.NewInt(123),
+		"messageID",
+		"session",
+		s.hosts[0],
+		s.mockCommunication,
+		keyshare.NewECDSAKeyshareStore("../../example/cfg/keyshares/0.keyshare"))
+	s.Nil(err)
+
+	process := s.tracker.PreferFunded(signing, 1)
+	verifiableProcess, ok := process.(tss.VerifiableProcess)
+
+	s.True(ok)
+	s.Equal(verifiableProcess.MessageHash(), signing.MessageHash())
+	_, ok = process.(tss.PreferredCoordinatorsProcess)
+	s.True(ok)
+}
+
+func (s *TrackerTestSuite) Test_PreferFunded_NotVerifiable() {
+	process := s.tracker.PreferFunded(s.mockProcess, 1)
+
+	_, ok := process.(tss.VerifiableProcess)
+	s.False(ok)
+}
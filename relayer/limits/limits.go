@@ -0,0 +1,288 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package limits
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/rs/zerolog/log"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+var (
+	VOLUME_PREFIX     = "limits:volume:resource:%x:"
+	VOLUME_ROUTE_KEY  = "source:%d:destination:%d:depositNonce:%d"
+	VOLUME_KEY        = VOLUME_PREFIX + VOLUME_ROUTE_KEY
+	HALT_PREFIX       = "limits:halt:"
+	RESOURCE_HALT_KEY = "limits:halt:resource:%x"
+	ROUTE_HALT_KEY    = "limits:halt:route:source:%d:destination:%d:resource:%x"
+)
+
+var (
+	ErrHalted              = errors.New("transfers halted by volume limit")
+	ErrVolumeLimitExceeded = errors.New("volume limit exceeded")
+)
+
+type LimitsDB interface {
+	GetByKey(key []byte) ([]byte, error)
+	SetByKey(key []byte, value []byte) error
+	WriteBatch(batch *leveldb.Batch) error
+	IterateByPrefix(prefix []byte, fn func(key []byte, value []byte) error) error
+}
+
+// Halt is a circuit breaker tripped by exceeding a volume limit.
+// Route halts have source and destination set.
+type Halt struct {
+	Key         string    `json:"-"`
+	ResourceID  [32]byte  `json:"resourceID"`
+	Route       bool      `json:"route"`
+	Source      uint8     `json:"source,omitempty"`
+	Destination uint8     `json:"destination,omitempty"`
+	Reason      string    `json:"reason"`
+	HaltedAt    time.Time `json:"haltedAt"`
+}
+
+type volume struct {
+	Amount string    `json:"amount"`
+	Time   time.Time `json:"time"`
+}
+
+type route struct {
+	source      uint8
+	destination uint8
+	resourceID  [32]byte
+}
+
+// VolumeLimiter enforces rolling per-resource and per-route volume caps on
+// transfers before they are signed. Exceeding a cap halts the resource or the
+// route until it is manually released.
+type VolumeLimiter struct {
+	db             LimitsDB
+	window         time.Duration
+	resourceLimits map[[32]byte]*big.Int
+	routeLimits    map[route]*big.Int
+	lock           sync.Mutex
+}
+
+func NewVolumeLimiter(db LimitsDB, config relayer.VolumeLimitsConfig) *VolumeLimiter {
+	resourceLimits := make(map[[32]byte]*big.Int)
+	for _, l := range config.Resources {
+		resourceLimits[l.ResourceID] = l.Limit
+	}
+	routeLimits := make(map[route]*big.Int)
+	for _, l := range config.Routes {
+		routeLimits[route{source: l.Source, destination: l.Destination, resourceID: l.ResourceID}] = l.Limit
+	}
+
+	return &VolumeLimiter{
+		db:             db,
+		window:         config.Window,
+		resourceLimits: resourceLimits,
+		routeLimits:    routeLimits,
+	}
+}
+
+// Allow records the transfer amount in the rolling window and returns an error if the
+// resource or route is halted or the transfer would exceed the configured caps.
+// Transfers that were already allowed are allowed again without being counted twice.
+func (l *VolumeLimiter) Allow(source, destination uint8, depositNonce uint64, resourceID [32]byte, amount *big.Int) error {
+	resourceLimit, hasResourceLimit := l.resourceLimits[resourceID]
+	routeLimit, hasRouteLimit := l.routeLimits[route{source: source, destination: destination, resourceID: resourceID}]
+	if !hasResourceLimit && !hasRouteLimit {
+		return nil
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	resourceHaltKey := fmt.Sprintf(RESOURCE_HALT_KEY, resourceID)
+	routeHaltKey := fmt.Sprintf(ROUTE_HALT_KEY, source, destination, resourceID)
+	for _, key := range []string{resourceHaltKey, routeHaltKey} {
+		isHalted, err := l.exists(key)
+		if err != nil {
+			return err
+		}
+		if isHalted {
+			return fmt.Errorf("%w: %s", ErrHalted, key)
+		}
+	}
+
+	volumeKey := []byte(fmt.Sprintf(VOLUME_KEY, resourceID, source, destination, depositNonce))
+	isCounted, err := l.exists(string(volumeKey))
+	if err != nil {
+		return err
+	}
+	if isCounted {
+		return nil
+	}
+
+	now := time.Now()
+	resourceVolume, routeVolume, err := l.volumes(resourceID, source, destination, now)
+	if err != nil {
+		return err
+	}
+	if hasResourceLimit && new(big.Int).Add(resourceVolume, amount).Cmp(resourceLimit) > 0 {
+		reason := fmt.Sprintf("resource volume %s with transfer %d-%d-%d of %s exceeds limit %s", resourceVolume, source, destination, depositNonce, amount, resourceLimit)
+		return l.halt(resourceHaltKey, Halt{ResourceID: resourceID, Reason: reason, HaltedAt: now})
+	}
+	if hasRouteLimit && new(big.Int).Add(routeVolume, amount).Cmp(routeLimit) > 0 {
+		reason := fmt.Sprintf("route volume %s with transfer %d-%d-%d of %s exceeds limit %s", routeVolume, source, destination, depositNonce, amount, routeLimit)
+		return l.halt(routeHaltKey, Halt{ResourceID: resourceID, Route: true, Source: source, Destination: destination, Reason: reason, HaltedAt: now})
+	}
+
+	value, err := json.Marshal(volume{Amount: amount.String(), Time: now})
+	if err != nil {
+		return err
+	}
+	return l.db.SetByKey(volumeKey, value)
+}
+
+// Halts returns all resources and routes halted by volume limits
+func (l *VolumeLimiter) Halts() ([]Halt, error) {
+	halts := make([]Halt, 0)
+	err := l.db.IterateByPrefix([]byte(HALT_PREFIX), func(key []byte, value []byte) error {
+		halt := Halt{}
+		err := json.Unmarshal(value, &halt)
+		if err != nil {
+			return err
+		}
+
+		halt.Key = string(key)
+		halts = append(halts, halt)
+		return nil
+	})
+	return halts, err
+}
+
+// ReleaseResource releases the halted resource and resets its rolling volume
+func (l *VolumeLimiter) ReleaseResource(resourceID [32]byte) error {
+	return l.release(fmt.Sprintf(RESOURCE_HALT_KEY, resourceID), resourceID, func(string) bool {
+		return true
+	})
+}
+
+// ReleaseRoute releases the halted route and resets its rolling volume
+func (l *VolumeLimiter) ReleaseRoute(source, destination uint8, resourceID [32]byte) error {
+	routePrefix := fmt.Sprintf("source:%d:destination:%d:", source, destination)
+	return l.release(fmt.Sprintf(ROUTE_HALT_KEY, source, destination, resourceID), resourceID, func(routeKey string) bool {
+		return strings.HasPrefix(routeKey, routePrefix)
+	})
+}
+
+func (l *VolumeLimiter) release(haltKey string, resourceID [32]byte, matches func(routeKey string) bool) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	isHalted, err := l.exists(haltKey)
+	if err != nil {
+		return err
+	}
+	if !isHalted {
+		return fmt.Errorf("%s is not halted", haltKey)
+	}
+
+	batch := new(leveldb.Batch)
+	batch.Delete([]byte(haltKey))
+	prefix := fmt.Sprintf(VOLUME_PREFIX, resourceID)
+	err = l.db.IterateByPrefix([]byte(prefix), func(key []byte, _ []byte) error {
+		if matches(strings.TrimPrefix(string(key), prefix)) {
+			batch.Delete(key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Warn().Msgf("Releasing volume limit halt %s", haltKey)
+	return l.db.WriteBatch(batch)
+}
+
+// volumes returns the resource and route volume inside the rolling window
+// and deletes volumes that fell out of it
+func (l *VolumeLimiter) volumes(resourceID [32]byte, source, destination uint8, now time.Time) (*big.Int, *big.Int, error) {
+	resourceVolume := big.NewInt(0)
+	routeVolume := big.NewInt(0)
+	expired := new(leveldb.Batch)
+	prefix := fmt.Sprintf(VOLUME_PREFIX, resourceID)
+	err := l.db.IterateByPrefix([]byte(prefix), func(key []byte, value []byte) error {
+		v := volume{}
+		err := json.Unmarshal(value, &v)
+		if err != nil {
+			return err
+		}
+		if now.Sub(v.Time) > l.window {
+			expired.Delete(key)
+			return nil
+		}
+
+		amount, ok := new(big.Int).SetString(v.Amount, 10)
+		if !ok {
+			return fmt.Errorf("invalid volume amount %s", v.Amount)
+		}
+		resourceVolume.Add(resourceVolume, amount)
+
+		var s, d uint8
+		var nonce uint64
+		_, err = fmt.Sscanf(strings.TrimPrefix(string(key), prefix), VOLUME_ROUTE_KEY, &s, &d, &nonce)
+		if err != nil {
+			return fmt.Errorf("invalid volume key %s: %w", string(key), err)
+		}
+		if s == source && d == destination {
+			routeVolume.Add(routeVolume, amount)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if expired.Len() > 0 {
+		err = l.db.WriteBatch(expired)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return resourceVolume, routeVolume, nil
+}
+
+func (l *VolumeLimiter) halt(key string, halt Halt) error {
+	value, err := json.Marshal(halt)
+	if err != nil {
+		return err
+	}
+	err = l.db.SetByKey([]byte(key), value)
+	if err != nil {
+		return err
+	}
+
+	log.Error().Msgf("Halting transfers because %s", halt.Reason)
+	return fmt.Errorf("%w: %s", ErrVolumeLimitExceeded, halt.Reason)
+}
+
+func (l *VolumeLimiter) exists(key string) (bool, error) {
+	_, err := l.db.GetByKey([]byte(key))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// FungibleAmount decodes the transfer amount from fungible proposal data
+// where the amount is encoded as the first 32 bytes
+func FungibleAmount(data []byte) *big.Int {
+	if len(data) < 32 {
+		return big.NewInt(0)
+	}
+	return new(big.Int).SetBytes(data[:32])
+}
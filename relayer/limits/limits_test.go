@@ -0,0 +1,166 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package limits_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/relayer/limits"
+	"github.com/ChainSafe/sygma-relayer/store/lvldb"
+	"github.com/stretchr/testify/suite"
+)
+
+type VolumeLimiterTestSuite struct {
+	suite.Suite
+	db         *lvldb.LVLDB
+	resourceID [32]byte
+	limiter    *limits.VolumeLimiter
+}
+
+func TestRunVolumeLimiterTestSuite(t *testing.T) {
+	suite.Run(t, new(VolumeLimiterTestSuite))
+}
+
+func (s *VolumeLimiterTestSuite) SetupTest() {
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	s.db = db
+	s.resourceID = [32]byte{1}
+	s.limiter = limits.NewVolumeLimiter(db, relayer.VolumeLimitsConfig{
+		Window: time.Hour,
+		Resources: []relayer.ResourceLimit{
+			{ResourceID: s.resourceID, Limit: big.NewInt(100)},
+		},
+		Routes: []relayer.RouteLimit{
+			{Source: 1, Destination: 2, ResourceID: s.resourceID, Limit: big.NewInt(50)},
+		},
+	})
+}
+
+func (s *VolumeLimiterTestSuite) TearDownTest() {
+	_ = s.db.Close()
+}
+
+func (s *VolumeLimiterTestSuite) Test_Allow_UnlimitedResource() {
+	err := s.limiter.Allow(1, 2, 1, [32]byte{2}, big.NewInt(1000))
+
+	s.Nil(err)
+}
+
+func (s *VolumeLimiterTestSuite) Test_Allow_WithinLimits() {
+	err := s.limiter.Allow(1, 2, 1, s.resourceID, big.NewInt(30))
+	s.Nil(err)
+	err = s.limiter.Allow(1, 3, 1, s.resourceID, big.NewInt(60))
+	s.Nil(err)
+
+	halts, err := s.limiter.Halts()
+	s.Nil(err)
+	s.Equal(len(halts), 0)
+}
+
+func (s *VolumeLimiterTestSuite) Test_Allow_RepeatedTransferCountedOnce() {
+	err := s.limiter.Allow(1, 2, 1, s.resourceID, big.NewInt(30))
+	s.Nil(err)
+	err = s.limiter.Allow(1, 2, 1, s.resourceID, big.NewInt(30))
+	s.Nil(err)
+	err = s.limiter.Allow(1, 2, 2, s.resourceID, big.NewInt(20))
+	s.Nil(err)
+}
+
+func (s *VolumeLimiterTestSuite) Test_Allow_RouteLimitExceeded() {
+	err := s.limiter.Allow(1, 2, 1, s.resourceID, big.NewInt(30))
+	s.Nil(err)
+
+	err = s.limiter.Allow(1, 2, 2, s.resourceID, big.NewInt(30))
+
+	s.True(errors.Is(err, limits.ErrVolumeLimitExceeded))
+	halts, err := s.limiter.Halts()
+	s.Nil(err)
+	s.Equal(len(halts), 1)
+	s.True(halts[0].Route)
+	s.Equal(halts[0].Source, uint8(1))
+	s.Equal(halts[0].Destination, uint8(2))
+	err = s.limiter.Allow(1, 2, 3, s.resourceID, big.NewInt(1))
+	s.True(errors.Is(err, limits.ErrHalted))
+	err = s.limiter.Allow(1, 3, 1, s.resourceID, big.NewInt(1))
+	s.Nil(err)
+}
+
+func (s *VolumeLimiterTestSuite) Test_Allow_ResourceLimitExceeded() {
+	err := s.limiter.Allow(1, 3, 1, s.resourceID, big.NewInt(90))
+	s.Nil(err)
+
+	err = s.limiter.Allow(4, 3, 1, s.resourceID, big.NewInt(20))
+
+	s.True(errors.Is(err, limits.ErrVolumeLimitExceeded))
+	err = s.limiter.Allow(1, 2, 1, s.resourceID, big.NewInt(1))
+	s.True(errors.Is(err, limits.ErrHalted))
+}
+
+func (s *VolumeLimiterTestSuite) Test_Allow_ExpiredVolumeNotCounted() {
+	limiter := limits.NewVolumeLimiter(s.db, relayer.VolumeLimitsConfig{
+		Window: time.Nanosecond,
+		Resources: []relayer.ResourceLimit{
+			{ResourceID: s.resourceID, Limit: big.NewInt(100)},
+		},
+	})
+	err := limiter.Allow(1, 2, 1, s.resourceID, big.NewInt(90))
+	s.Nil(err)
+	time.Sleep(time.Millisecond)
+
+	err = limiter.Allow(1, 2, 2, s.resourceID, big.NewInt(90))
+
+	s.Nil(err)
+}
+
+func (s *VolumeLimiterTestSuite) Test_ReleaseResource_NotHalted() {
+	err := s.limiter.ReleaseResource(s.resourceID)
+
+	s.NotNil(err)
+}
+
+func (s *VolumeLimiterTestSuite) Test_ReleaseResource_ResetsVolume() {
+	err := s.limiter.Allow(1, 3, 1, s.resourceID, big.NewInt(90))
+	s.Nil(err)
+	err = s.limiter.Allow(1, 3, 2, s.resourceID, big.NewInt(20))
+	s.NotNil(err)
+
+	err = s.limiter.ReleaseResource(s.resourceID)
+
+	s.Nil(err)
+	halts, err := s.limiter.Halts()
+	s.Nil(err)
+	s.Equal(len(halts), 0)
+	err = s.limiter.Allow(1, 3, 2, s.resourceID, big.NewInt(20))
+	s.Nil(err)
+}
+
+func (s *VolumeLimiterTestSuite) Test_ReleaseRoute_ResetsRouteVolume() {
+	err := s.limiter.Allow(1, 3, 1, s.resourceID, big.NewInt(40))
+	s.Nil(err)
+	err = s.limiter.Allow(1, 2, 1, s.resourceID, big.NewInt(40))
+	s.Nil(err)
+	err = s.limiter.Allow(1, 2, 2, s.resourceID, big.NewInt(20))
+	s.NotNil(err)
+
+	err = s.limiter.ReleaseRoute(1, 2, s.resourceID)
+
+	s.Nil(err)
+	err = s.limiter.Allow(1, 2, 2, s.resourceID, big.NewInt(50))
+	s.Nil(err)
+	err = s.limiter.Allow(1, 3, 2, s.resourceID, big.NewInt(20))
+	s.True(errors.Is(err, limits.ErrVolumeLimitExceeded))
+}
+
+func (s *VolumeLimiterTestSuite) Test_FungibleAmount() {
+	data := make([]byte, 64)
+	data[31] = 100
+
+	s.Equal(limits.FungibleAmount(data), big.NewInt(100))
+	s.Equal(limits.FungibleAmount([]byte{1}), big.NewInt(0))
+}
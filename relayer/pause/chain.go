@@ -0,0 +1,103 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pause
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/relayer"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+)
+
+var pauseCheckInterval = 5 * time.Second
+
+type PauseChecker interface {
+	RoutePaused(source, destination uint8) bool
+	ResourcePaused(resourceID [32]byte) bool
+}
+
+type PropFailureStorer interface {
+	StorePropFailure(source, destination uint8, depositNonce uint64, failure error) error
+}
+
+// ProposalReleaser is implemented by wrapped chains that track received proposals
+// until they are written and need to be notified of refused proposals
+type ProposalReleaser interface {
+	Release(props []*proposal.Proposal)
+}
+
+// PausingChain wraps a relayed chain and honours the pause state before proposals are written.
+// Proposals to a paused domain or over a paused route are held back until they are resumed.
+// Proposals of paused resources are refused so the relayer does not sign them and are
+// stored as failed so they can be retried after the resource is resumed. Refused proposals
+// are released from the wrapped chain if it implements ProposalReleaser.
+type PausingChain struct {
+	relayer.RelayedChain
+	pauser     PauseChecker
+	propStorer PropFailureStorer
+	releaser   ProposalReleaser
+}
+
+func NewPausingChain(chain relayer.RelayedChain, pauser PauseChecker, propStorer PropFailureStorer) *PausingChain {
+	releaser, _ := chain.(ProposalReleaser)
+	return &PausingChain{
+		RelayedChain: chain,
+		pauser:       pauser,
+		propStorer:   propStorer,
+		releaser:     releaser,
+	}
+}
+
+// Write blocks while the proposals route is paused and writes proposals of resources that are not paused
+func (c *PausingChain) Write(props []*proposal.Proposal) error {
+	if c.routePaused(props) {
+		log.Info().Uint8("domainID", c.DomainID()).Msgf("Holding %d proposals while paused", len(props))
+		ticker := time.NewTicker(pauseCheckInterval)
+		for range ticker.C {
+			if !c.routePaused(props) {
+				break
+			}
+		}
+		ticker.Stop()
+		log.Info().Uint8("domainID", c.DomainID()).Msgf("Resumed writing %d proposals", len(props))
+	}
+
+	allowedProps := make([]*proposal.Proposal, 0, len(props))
+	refusedProps := make([]*proposal.Proposal, 0)
+	for _, prop := range props {
+		data, ok := prop.Data.(transfer.TransferProposalData)
+		if !ok || !c.pauser.ResourcePaused(data.ResourceId) {
+			allowedProps = append(allowedProps, prop)
+			continue
+		}
+
+		refusedProps = append(refusedProps, prop)
+		log.Warn().Str("messageID", prop.MessageID).Uint8("domainID", c.DomainID()).Msgf(
+			"Refusing to sign deposit %d-%d-%d of paused resource %x", prop.Source, prop.Destination, data.DepositNonce, data.ResourceId)
+		err := c.propStorer.StorePropFailure(prop.Source, prop.Destination, data.DepositNonce, fmt.Errorf("resource %x paused", data.ResourceId))
+		if err != nil {
+			log.Err(err).Str("messageID", prop.MessageID).Msgf("Failed storing failure of paused proposal")
+		}
+	}
+	if c.releaser != nil && len(refusedProps) > 0 {
+		c.releaser.Release(refusedProps)
+	}
+	if len(allowedProps) == 0 {
+		return nil
+	}
+
+	return c.RelayedChain.Write(allowedProps)
+}
+
+func (c *PausingChain) routePaused(props []*proposal.Proposal) bool {
+	for _, prop := range props {
+		if c.pauser.RoutePaused(prop.Source, prop.Destination) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pause
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	AuditComponent = "pause"
+	PauseSessionID = "pause"
+
+	PauseAction  = "pause"
+	ResumeAction = "resume"
+
+	// OperatorOrigin marks pauses set by the local operator
+	OperatorOrigin = "operator"
+)
+
+type Auditor interface {
+	Record(component string, action string, details map[string]interface{})
+}
+
+// PauseMessage is broadcasted to peers when the pause state is changed by the operator
+type PauseMessage struct {
+	Action string `json:"action"`
+	Target Target `json:"target"`
+}
+
+// Controller changes the pause state on request of the operator. If broadcasting
+// is enabled, changes are broadcasted to peers and changes received from peers are applied.
+type Controller struct {
+	pauser    *Pauser
+	host      host.Host
+	comm      comm.Communication
+	auditor   Auditor
+	broadcast bool
+}
+
+func NewController(pauser *Pauser, host host.Host, communication comm.Communication, auditor Auditor, broadcast bool) *Controller {
+	return &Controller{
+		pauser:    pauser,
+		host:      host,
+		comm:      communication,
+		auditor:   auditor,
+		broadcast: broadcast,
+	}
+}
+
+// Start applies pause state changes broadcasted by peers until the context is cancelled
+func (c *Controller) Start(ctx context.Context) {
+	if !c.broadcast {
+		return
+	}
+
+	msgChan := make(chan *comm.WrappedMessage)
+	subID := c.comm.Subscribe(PauseSessionID, comm.PauseMsg, msgChan)
+	defer c.comm.UnSubscribe(subID)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-msgChan:
+			err := c.HandlePauseMessage(msg)
+			if err != nil {
+				log.Err(err).Str("peerID", msg.From.String()).Msg("Failed handling pause message")
+			}
+		}
+	}
+}
+
+func (c *Controller) Pause(target Target) error {
+	err := c.pauser.Pause(target, OperatorOrigin)
+	if err != nil {
+		return err
+	}
+
+	c.broadcastChange(PauseAction, target)
+	return nil
+}
+
+func (c *Controller) Resume(target Target) error {
+	err := c.pauser.Resume(target)
+	if err != nil {
+		return err
+	}
+
+	c.broadcastChange(ResumeAction, target)
+	return nil
+}
+
+func (c *Controller) State() []Pause {
+	return c.pauser.State()
+}
+
+// HandlePauseMessage applies the pause state change of a peer and records it in the audit log
+func (c *Controller) HandlePauseMessage(msg *comm.WrappedMessage) error {
+	pauseMsg := &PauseMessage{}
+	err := json.Unmarshal(msg.Payload, pauseMsg)
+	if err != nil {
+		return err
+	}
+
+	switch pauseMsg.Action {
+	case PauseAction:
+		err = c.pauser.Pause(pauseMsg.Target, msg.From.String())
+	case ResumeAction:
+		err = c.pauser.Resume(pauseMsg.Target)
+	default:
+		err = fmt.Errorf("unknown pause action %s", pauseMsg.Action)
+	}
+	details := map[string]interface{}{
+		"peerID": msg.From.String(),
+		"target": pauseMsg.Target,
+	}
+	if err != nil {
+		details["error"] = err.Error()
+	}
+	c.auditor.Record(AuditComponent, pauseMsg.Action, details)
+	return err
+}
+
+func (c *Controller) broadcastChange(action string, target Target) {
+	if !c.broadcast {
+		return
+	}
+
+	payload, err := json.Marshal(PauseMessage{
+		Action: action,
+		Target: target,
+	})
+	if err != nil {
+		log.Err(err).Msg("Failed encoding pause message")
+		return
+	}
+	err = c.comm.Broadcast(c.host.Peerstore().Peers(), payload, comm.PauseMsg, PauseSessionID)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed broadcasting %s of %+v to all relayers", action, target)
+	}
+}
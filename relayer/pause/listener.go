@@ -0,0 +1,68 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pause
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type DomainPauseChecker interface {
+	DomainPaused(domainID uint8) bool
+}
+
+// ListenerGate is an event handler that stops the listener of a paused domain
+// from advancing. It has to be the first event handler of the listener.
+type ListenerGate struct {
+	pauser   DomainPauseChecker
+	domainID uint8
+}
+
+func NewListenerGate(pauser DomainPauseChecker, domainID uint8) *ListenerGate {
+	return &ListenerGate{
+		pauser:   pauser,
+		domainID: domainID,
+	}
+}
+
+// HandleEvents blocks while the domain is paused
+func (g *ListenerGate) HandleEvents(startBlock *big.Int, endBlock *big.Int) error {
+	g.wait(startBlock)
+	return nil
+}
+
+func (g *ListenerGate) wait(startBlock *big.Int) {
+	if !g.pauser.DomainPaused(g.domainID) {
+		return
+	}
+
+	log.Info().Uint8("domainID", g.domainID).Msgf("Listener paused at block %s", startBlock)
+	ticker := time.NewTicker(pauseCheckInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !g.pauser.DomainPaused(g.domainID) {
+			break
+		}
+	}
+	log.Info().Uint8("domainID", g.domainID).Msgf("Listener resumed at block %s", startBlock)
+}
+
+// BlockListenerGate is the ListenerGate of listeners handling events block by block
+type BlockListenerGate struct {
+	*ListenerGate
+}
+
+func NewBlockListenerGate(pauser DomainPauseChecker, domainID uint8) *BlockListenerGate {
+	return &BlockListenerGate{
+		ListenerGate: NewListenerGate(pauser, domainID),
+	}
+}
+
+// HandleEvents blocks while the domain is paused
+func (g *BlockListenerGate) HandleEvents(startBlock *big.Int) error {
+	g.wait(startBlock)
+	return nil
+}
@@ -0,0 +1,195 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pause
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	PAUSE_PREFIX       = "pause:"
+	DOMAIN_PAUSE_KEY   = "pause:domain:%d"
+	ROUTE_PAUSE_KEY    = "pause:route:source:%d:destination:%d"
+	RESOURCE_PAUSE_KEY = "pause:resource:%x"
+)
+
+type PauseDB interface {
+	SetByKey(key []byte, value []byte) error
+	DeleteByKey(key []byte) error
+	IterateByPrefix(prefix []byte, fn func(key []byte, value []byte) error) error
+}
+
+// Target is a paused domain, route or resource.
+// Exactly one of the domain ID, the route source and destination or the resource ID is set.
+type Target struct {
+	DomainID    uint8  `json:"domainID,omitempty"`
+	Source      uint8  `json:"source,omitempty"`
+	Destination uint8  `json:"destination,omitempty"`
+	ResourceID  string `json:"resourceID,omitempty"`
+}
+
+// Key validates the target and returns its store key
+func (t Target) Key() (string, error) {
+	isDomain := t.DomainID != 0
+	isRoute := t.Source != 0 || t.Destination != 0
+	isResource := t.ResourceID != ""
+	switch {
+	case isDomain && !isRoute && !isResource:
+		return fmt.Sprintf(DOMAIN_PAUSE_KEY, t.DomainID), nil
+	case isRoute && !isDomain && !isResource:
+		if t.Source == 0 || t.Destination == 0 {
+			return "", fmt.Errorf("route requires source and destination")
+		}
+		return fmt.Sprintf(ROUTE_PAUSE_KEY, t.Source, t.Destination), nil
+	case isResource && !isDomain && !isRoute:
+		resourceBytes, err := hex.DecodeString(strings.TrimPrefix(t.ResourceID, "0x"))
+		if err != nil || len(resourceBytes) != 32 {
+			return "", fmt.Errorf("invalid resource ID %s", t.ResourceID)
+		}
+		return fmt.Sprintf(RESOURCE_PAUSE_KEY, resourceBytes), nil
+	default:
+		return "", fmt.Errorf("exactly one of domain, route or resource has to be provided")
+	}
+}
+
+// Pause is a paused target with the time and the origin of the pause
+type Pause struct {
+	Target
+	Key      string    `json:"key"`
+	PausedBy string    `json:"pausedBy"`
+	PausedAt time.Time `json:"pausedAt"`
+}
+
+// Pauser holds the operator controlled pause state of domains, routes and resources.
+// The state is persisted so pauses survive restarts.
+type Pauser struct {
+	db     PauseDB
+	pauses map[string]Pause
+	lock   sync.RWMutex
+}
+
+// NewPauser loads pauses stored by previous runs
+func NewPauser(db PauseDB) (*Pauser, error) {
+	p := &Pauser{
+		db:     db,
+		pauses: make(map[string]Pause),
+	}
+	err := db.IterateByPrefix([]byte(PAUSE_PREFIX), func(key []byte, value []byte) error {
+		pause := Pause{}
+		err := json.Unmarshal(value, &pause)
+		if err != nil {
+			return fmt.Errorf("invalid pause %s: %w", string(key), err)
+		}
+
+		p.pauses[string(key)] = pause
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for key := range p.pauses {
+		log.Warn().Msgf("Relaying paused for %s", key)
+	}
+	return p, nil
+}
+
+// Pause pauses the target. Pausing an already paused target is a no-op.
+func (p *Pauser) Pause(target Target, pausedBy string) error {
+	key, err := target.Key()
+	if err != nil {
+		return err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if _, ok := p.pauses[key]; ok {
+		return nil
+	}
+
+	pause := Pause{
+		Target:   target,
+		Key:      key,
+		PausedBy: pausedBy,
+		PausedAt: time.Now(),
+	}
+	value, err := json.Marshal(pause)
+	if err != nil {
+		return err
+	}
+	err = p.db.SetByKey([]byte(key), value)
+	if err != nil {
+		return err
+	}
+
+	p.pauses[key] = pause
+	log.Warn().Msgf("Paused relaying for %s by %s", key, pausedBy)
+	return nil
+}
+
+// Resume resumes the target. Resuming a target that is not paused is a no-op.
+func (p *Pauser) Resume(target Target) error {
+	key, err := target.Key()
+	if err != nil {
+		return err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if _, ok := p.pauses[key]; !ok {
+		return nil
+	}
+
+	err = p.db.DeleteByKey([]byte(key))
+	if err != nil {
+		return err
+	}
+
+	delete(p.pauses, key)
+	log.Warn().Msgf("Resumed relaying for %s", key)
+	return nil
+}
+
+// DomainPaused returns true if the domain is paused
+func (p *Pauser) DomainPaused(domainID uint8) bool {
+	return p.paused(fmt.Sprintf(DOMAIN_PAUSE_KEY, domainID))
+}
+
+// RoutePaused returns true if the destination domain or the route is paused
+func (p *Pauser) RoutePaused(source, destination uint8) bool {
+	return p.paused(fmt.Sprintf(DOMAIN_PAUSE_KEY, destination)) || p.paused(fmt.Sprintf(ROUTE_PAUSE_KEY, source, destination))
+}
+
+// ResourcePaused returns true if the resource is paused
+func (p *Pauser) ResourcePaused(resourceID [32]byte) bool {
+	return p.paused(fmt.Sprintf(RESOURCE_PAUSE_KEY, resourceID))
+}
+
+// State returns all pauses ordered by their key
+func (p *Pauser) State() []Pause {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	pauses := make([]Pause, 0, len(p.pauses))
+	for _, pause := range p.pauses {
+		pauses = append(pauses, pause)
+	}
+	sort.Slice(pauses, func(i, j int) bool { return pauses[i].Key < pauses[j].Key })
+	return pauses
+}
+
+func (p *Pauser) paused(key string) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	_, ok := p.pauses[key]
+	return ok
+}
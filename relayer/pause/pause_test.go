@@ -0,0 +1,272 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pause
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/relayer/dedup"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/ChainSafe/sygma-relayer/store/lvldb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/suite"
+	mock_relayer "github.com/sygmaprotocol/sygma-core/mock"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+	"go.uber.org/mock/gomock"
+)
+
+const testResourceID = "0x0000000000000000000000000000000000000000000000000000000000000001"
+
+type recordingAuditor struct {
+	records []map[string]interface{}
+}
+
+func (a *recordingAuditor) Record(component string, action string, details map[string]interface{}) {
+	a.records = append(a.records, details)
+}
+
+type PauserTestSuite struct {
+	suite.Suite
+	db     *lvldb.LVLDB
+	pauser *Pauser
+}
+
+func TestRunPauserTestSuite(t *testing.T) {
+	suite.Run(t, new(PauserTestSuite))
+}
+
+func (s *PauserTestSuite) SetupTest() {
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	s.db = db
+	s.pauser, err = NewPauser(db)
+	s.Nil(err)
+}
+
+func (s *PauserTestSuite) TearDownTest() {
+	_ = s.db.Close()
+}
+
+func (s *PauserTestSuite) Test_Key_InvalidTarget() {
+	for _, target := range []Target{
+		{},
+		{DomainID: 1, Source: 1, Destination: 2},
+		{Source: 1},
+		{ResourceID: "0x01"},
+		{DomainID: 1, ResourceID: testResourceID},
+	} {
+		_, err := target.Key()
+		s.NotNil(err, "%+v", target)
+	}
+}
+
+func (s *PauserTestSuite) Test_Paused() {
+	s.Nil(s.pauser.Pause(Target{DomainID: 3}, OperatorOrigin))
+	s.Nil(s.pauser.Pause(Target{Source: 1, Destination: 2}, OperatorOrigin))
+	s.Nil(s.pauser.Pause(Target{ResourceID: testResourceID}, OperatorOrigin))
+
+	s.True(s.pauser.DomainPaused(3))
+	s.False(s.pauser.DomainPaused(1))
+	s.True(s.pauser.RoutePaused(1, 2))
+	s.True(s.pauser.RoutePaused(1, 3))
+	s.False(s.pauser.RoutePaused(2, 1))
+	s.False(s.pauser.RoutePaused(3, 1))
+	s.True(s.pauser.ResourcePaused([32]byte{31: 1}))
+	s.False(s.pauser.ResourcePaused([32]byte{31: 2}))
+}
+
+func (s *PauserTestSuite) Test_PausesPersistedAcrossRestarts() {
+	s.Nil(s.pauser.Pause(Target{DomainID: 3}, OperatorOrigin))
+	s.Nil(s.pauser.Pause(Target{ResourceID: testResourceID}, "peer"))
+	s.Nil(s.pauser.Pause(Target{Source: 1, Destination: 2}, OperatorOrigin))
+	s.Nil(s.pauser.Resume(Target{Source: 1, Destination: 2}))
+
+	pauser, err := NewPauser(s.db)
+
+	s.Nil(err)
+	state := pauser.State()
+	s.Equal(len(state), 2)
+	s.Equal(state[0].Key, "pause:domain:3")
+	s.Equal(state[0].PausedBy, OperatorOrigin)
+	s.Equal(state[1].Key, "pause:resource:0000000000000000000000000000000000000000000000000000000000000001")
+	s.Equal(state[1].PausedBy, "peer")
+	s.True(pauser.ResourcePaused([32]byte{31: 1}))
+	s.False(pauser.RoutePaused(1, 2))
+}
+
+type PausingChainTestSuite struct {
+	suite.Suite
+	db        *lvldb.LVLDB
+	propStore *store.PropStore
+	mockChain *mock_relayer.MockRelayedChain
+	pauser    *Pauser
+	chain     *PausingChain
+}
+
+func TestRunPausingChainTestSuite(t *testing.T) {
+	suite.Run(t, new(PausingChainTestSuite))
+}
+
+func (s *PausingChainTestSuite) SetupTest() {
+	pauseCheckInterval = 10 * time.Millisecond
+	ctrl := gomock.NewController(s.T())
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	s.db = db
+	s.propStore = store.NewPropStore(db)
+	s.mockChain = mock_relayer.NewMockRelayedChain(ctrl)
+	s.mockChain.EXPECT().DomainID().Return(uint8(2)).AnyTimes()
+	s.pauser, err = NewPauser(db)
+	s.Nil(err)
+	s.chain = NewPausingChain(s.mockChain, s.pauser, s.propStore)
+}
+
+func (s *PausingChainTestSuite) TearDownTest() {
+	_ = s.db.Close()
+}
+
+func (s *PausingChainTestSuite) Test_Write_NotPaused() {
+	props := []*proposal.Proposal{
+		proposal.NewProposal(1, 2, transfer.TransferProposalData{ResourceId: [32]byte{1}}, "1-2", transfer.TransferProposalType),
+	}
+	s.Nil(s.pauser.Pause(Target{DomainID: 3}, OperatorOrigin))
+	s.Nil(s.pauser.Pause(Target{Source: 2, Destination: 1}, OperatorOrigin))
+	s.mockChain.EXPECT().Write(props).Return(nil)
+
+	err := s.chain.Write(props)
+
+	s.Nil(err)
+}
+
+func (s *PausingChainTestSuite) Test_Write_BlocksUntilRouteResumed() {
+	props := []*proposal.Proposal{
+		proposal.NewProposal(1, 2, transfer.TransferProposalData{ResourceId: [32]byte{1}}, "1-2", transfer.TransferProposalType),
+	}
+	s.Nil(s.pauser.Pause(Target{Source: 1, Destination: 2}, OperatorOrigin))
+	written := make(chan error)
+	s.mockChain.EXPECT().Write(props).Return(nil)
+
+	go func() {
+		written <- s.chain.Write(props)
+	}()
+
+	select {
+	case <-written:
+		s.Fail("paused proposals written")
+	case <-time.After(50 * time.Millisecond):
+	}
+	s.Nil(s.pauser.Resume(Target{Source: 1, Destination: 2}))
+	s.Nil(<-written)
+}
+
+func (s *PausingChainTestSuite) Test_Write_RefusesPausedResource() {
+	allowedProp := proposal.NewProposal(1, 2, transfer.TransferProposalData{ResourceId: [32]byte{31: 2}, DepositNonce: 1}, "1-2", transfer.TransferProposalType)
+	pausedProp := proposal.NewProposal(1, 2, transfer.TransferProposalData{ResourceId: [32]byte{31: 1}, DepositNonce: 2}, "1-2", transfer.TransferProposalType)
+	s.Nil(s.pauser.Pause(Target{ResourceID: testResourceID}, OperatorOrigin))
+	s.mockChain.EXPECT().Write([]*proposal.Proposal{allowedProp}).Return(nil)
+
+	err := s.chain.Write([]*proposal.Proposal{allowedProp, pausedProp})
+
+	s.Nil(err)
+	record, err := s.propStore.PropRecord(1, 2, 2)
+	s.Nil(err)
+	s.Equal(record.Status, store.FailedProp)
+}
+
+func (s *PausingChainTestSuite) Test_Write_AllResourcesPaused() {
+	pausedProp := proposal.NewProposal(1, 2, transfer.TransferProposalData{ResourceId: [32]byte{31: 1}, DepositNonce: 2}, "1-2", transfer.TransferProposalType)
+	s.Nil(s.pauser.Pause(Target{ResourceID: testResourceID}, OperatorOrigin))
+
+	err := s.chain.Write([]*proposal.Proposal{pausedProp})
+
+	s.Nil(err)
+}
+
+func (s *PausingChainTestSuite) Test_Write_PausedResourceRelayedAfterResume() {
+	chain := NewPausingChain(dedup.NewDeduplicatingChain(s.mockChain, store.NewSeenStore(s.db), s.propStore), s.pauser, s.propStore)
+	m := message.NewMessage(1, 2, transfer.TransferMessageData{ResourceId: [32]byte{31: 1}, DepositNonce: 2}, "1-2", transfer.TransferMessageType, time.Time{})
+	pausedProp := proposal.NewProposal(1, 2, transfer.TransferProposalData{ResourceId: [32]byte{31: 1}, DepositNonce: 2}, "1-2", transfer.TransferProposalType)
+	s.mockChain.EXPECT().ReceiveMessage(m).Return(pausedProp, nil)
+	s.Nil(s.pauser.Pause(Target{ResourceID: testResourceID}, OperatorOrigin))
+
+	prop, err := chain.ReceiveMessage(m)
+	s.Nil(err)
+	err = chain.Write([]*proposal.Proposal{prop})
+	s.Nil(err)
+
+	s.Nil(s.pauser.Resume(Target{ResourceID: testResourceID}))
+	retried := message.NewMessage(1, 2, transfer.TransferMessageData{ResourceId: [32]byte{31: 1}, DepositNonce: 2}, "retry-1-2", transfer.TransferMessageType, time.Time{})
+	retriedProp := proposal.NewProposal(1, 2, transfer.TransferProposalData{ResourceId: [32]byte{31: 1}, DepositNonce: 2}, "retry-1-2", transfer.TransferProposalType)
+	s.mockChain.EXPECT().ReceiveMessage(retried).Return(retriedProp, nil)
+	s.mockChain.EXPECT().Write([]*proposal.Proposal{retriedProp}).Return(nil)
+
+	prop, err = chain.ReceiveMessage(retried)
+	s.Nil(err)
+	s.Equal(prop, retriedProp)
+	err = chain.Write([]*proposal.Proposal{prop})
+	s.Nil(err)
+}
+
+func (s *PausingChainTestSuite) Test_ListenerGate_BlocksUntilDomainResumed() {
+	gate := NewListenerGate(s.pauser, 1)
+	s.Nil(s.pauser.Pause(Target{DomainID: 1}, OperatorOrigin))
+	handled := make(chan error)
+
+	go func() {
+		handled <- gate.HandleEvents(big.NewInt(100), big.NewInt(105))
+	}()
+
+	select {
+	case <-handled:
+		s.Fail("paused listener advanced")
+	case <-time.After(50 * time.Millisecond):
+	}
+	s.Nil(s.pauser.Resume(Target{DomainID: 1}))
+	s.Nil(<-handled)
+}
+
+func (s *PausingChainTestSuite) Test_HandlePauseMessage() {
+	auditor := &recordingAuditor{}
+	controller := NewController(s.pauser, nil, nil, auditor, true)
+	payload, _ := json.Marshal(PauseMessage{
+		Action: PauseAction,
+		Target: Target{DomainID: 1},
+	})
+
+	err := controller.HandlePauseMessage(&comm.WrappedMessage{
+		MessageType: comm.PauseMsg,
+		Payload:     payload,
+		From:        peer.ID("peer"),
+	})
+
+	s.Nil(err)
+	s.True(s.pauser.DomainPaused(1))
+	s.Equal(controller.State()[0].PausedBy, peer.ID("peer").String())
+	s.Equal(len(auditor.records), 1)
+}
+
+func (s *PausingChainTestSuite) Test_HandlePauseMessage_InvalidAction() {
+	auditor := &recordingAuditor{}
+	controller := NewController(s.pauser, nil, nil, auditor, true)
+	payload, _ := json.Marshal(PauseMessage{
+		Action: "halt",
+		Target: Target{DomainID: 1},
+	})
+
+	err := controller.HandlePauseMessage(&comm.WrappedMessage{
+		MessageType: comm.PauseMsg,
+		Payload:     payload,
+		From:        peer.ID("peer"),
+	})
+
+	s.NotNil(err)
+	s.False(s.pauser.DomainPaused(1))
+	s.Equal(auditor.records[0]["error"], "unknown pause action halt")
+}
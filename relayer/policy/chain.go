@@ -0,0 +1,83 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/relayer"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+)
+
+const AuditComponent = "signingPolicy"
+
+type Auditor interface {
+	Record(component string, action string, details map[string]interface{})
+}
+
+type PolicyMetrics interface {
+	TrackPolicyRejection(source, destination uint8, rule string)
+}
+
+// SigningPolicyChain wraps a relayed chain and drops transfer messages rejected
+// by the local signing policy. Rejected transfers never become proposals so
+// the relayer neither starts nor joins signing sessions for them.
+type SigningPolicyChain struct {
+	relayer.RelayedChain
+	policy  *Policy
+	auditor Auditor
+	metrics PolicyMetrics
+}
+
+func NewSigningPolicyChain(chain relayer.RelayedChain, policy *Policy, auditor Auditor, metrics PolicyMetrics) *SigningPolicyChain {
+	return &SigningPolicyChain{
+		RelayedChain: chain,
+		policy:       policy,
+		auditor:      auditor,
+		metrics:      metrics,
+	}
+}
+
+// ReceiveMessage forwards the message to the wrapped chain if the transfer
+// is allowed by the policy. Rejected transfers are recorded in the audit log
+// and dropped by returning an empty proposal.
+func (c *SigningPolicyChain) ReceiveMessage(m *message.Message) (*proposal.Proposal, error) {
+	data, ok := m.Data.(transfer.TransferMessageData)
+	if m.Type != transfer.TransferMessageType || !ok {
+		return c.RelayedChain.ReceiveMessage(m)
+	}
+
+	err := c.policy.Evaluate(&transfer.TransferMessage{
+		Source:      m.Source,
+		Destination: m.Destination,
+		Data:        data,
+		Type:        m.Type,
+		ID:          m.ID,
+	})
+	var violation *Violation
+	if errors.As(err, &violation) {
+		log.Warn().Str("messageID", m.ID).Uint8("domainID", c.DomainID()).Msgf(
+			"Refusing to sign deposit %d-%d-%d: %s", m.Source, m.Destination, data.DepositNonce, violation)
+		c.auditor.Record(AuditComponent, "reject", map[string]interface{}{
+			"messageID":    m.ID,
+			"source":       m.Source,
+			"destination":  m.Destination,
+			"depositNonce": data.DepositNonce,
+			"resourceID":   fmt.Sprintf("%x", data.ResourceId),
+			"rule":         string(violation.Rule),
+			"reason":       violation.Reason,
+		})
+		c.metrics.TrackPolicyRejection(m.Source, m.Destination, string(violation.Rule))
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.RelayedChain.ReceiveMessage(m)
+}
@@ -0,0 +1,86 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package policy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/relayer/policy"
+	mock_policy "github.com/ChainSafe/sygma-relayer/relayer/policy/mock"
+	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	mock_relayer "github.com/sygmaprotocol/sygma-core/mock"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+	coreGomock "go.uber.org/mock/gomock"
+)
+
+type SigningPolicyChainTestSuite struct {
+	suite.Suite
+	mockChain   *mock_relayer.MockRelayedChain
+	mockAuditor *mock_policy.MockAuditor
+	mockMetrics *mock_policy.MockPolicyMetrics
+	chain       *policy.SigningPolicyChain
+}
+
+func TestRunSigningPolicyChainTestSuite(t *testing.T) {
+	suite.Run(t, new(SigningPolicyChainTestSuite))
+}
+
+func (s *SigningPolicyChainTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	coreCtrl := coreGomock.NewController(s.T())
+	s.mockChain = mock_relayer.NewMockRelayedChain(coreCtrl)
+	s.mockChain.EXPECT().DomainID().Return(uint8(2)).AnyTimes()
+	s.mockAuditor = mock_policy.NewMockAuditor(ctrl)
+	s.mockMetrics = mock_policy.NewMockPolicyMetrics(ctrl)
+	s.chain = policy.NewSigningPolicyChain(
+		s.mockChain,
+		policy.NewPolicy(relayer.SigningPolicyConfig{
+			Enabled: true,
+			Routes:  []relayer.PolicyRoute{{Source: 1, Destination: 2}},
+		}),
+		s.mockAuditor,
+		s.mockMetrics)
+}
+
+func (s *SigningPolicyChainTestSuite) Test_ReceiveMessage_NonTransferMessagePassedThrough() {
+	m := message.NewMessage(3, 2, retry.RetryMessageData{}, "retry-3-2", retry.RetryMessageType, time.Time{})
+	s.mockChain.EXPECT().ReceiveMessage(m).Return(nil, nil)
+
+	prop, err := s.chain.ReceiveMessage(m)
+
+	s.Nil(err)
+	s.Nil(prop)
+}
+
+func (s *SigningPolicyChainTestSuite) Test_ReceiveMessage_AllowedTransfer() {
+	m := message.NewMessage(1, 2, transfer.TransferMessageData{DepositNonce: 1}, "1-2", transfer.TransferMessageType, time.Time{})
+	expectedProp := proposal.NewProposal(1, 2, transfer.TransferProposalData{DepositNonce: 1}, "1-2", transfer.TransferProposalType)
+	s.mockChain.EXPECT().ReceiveMessage(m).Return(expectedProp, nil)
+
+	prop, err := s.chain.ReceiveMessage(m)
+
+	s.Nil(err)
+	s.Equal(prop, expectedProp)
+}
+
+func (s *SigningPolicyChainTestSuite) Test_ReceiveMessage_RejectedTransferDropped() {
+	m := message.NewMessage(3, 2, transfer.TransferMessageData{DepositNonce: 1}, "3-2", transfer.TransferMessageType, time.Time{})
+	s.mockAuditor.EXPECT().Record(policy.AuditComponent, "reject", gomock.Any()).Do(
+		func(component string, action string, details map[string]interface{}) {
+			s.Equal(details["rule"], string(policy.RouteRule))
+			s.Equal(details["depositNonce"], uint64(1))
+		})
+	s.mockMetrics.EXPECT().TrackPolicyRejection(uint8(3), uint8(2), string(policy.RouteRule))
+
+	prop, err := s.chain.ReceiveMessage(m)
+
+	s.Nil(err)
+	s.Nil(prop)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./relayer/policy/chain.go
+
+// Package mock_policy is a generated GoMock package.
+package mock_policy
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuditor is a mock of Auditor interface.
+type MockAuditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditorMockRecorder
+}
+
+// MockAuditorMockRecorder is the mock recorder for MockAuditor.
+type MockAuditorMockRecorder struct {
+	mock *MockAuditor
+}
+
+// NewMockAuditor creates a new mock instance.
+func NewMockAuditor(ctrl *gomock.Controller) *MockAuditor {
+	mock := &MockAuditor{ctrl: ctrl}
+	mock.recorder = &MockAuditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditor) EXPECT() *MockAuditorMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockAuditor) Record(component, action string, details map[string]interface{}) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", component, action, details)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditorMockRecorder) Record(component, action, details interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditor)(nil).Record), component, action, details)
+}
+
+// MockPolicyMetrics is a mock of PolicyMetrics interface.
+type MockPolicyMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyMetricsMockRecorder
+}
+
+// MockPolicyMetricsMockRecorder is the mock recorder for MockPolicyMetrics.
+type MockPolicyMetricsMockRecorder struct {
+	mock *MockPolicyMetrics
+}
+
+// NewMockPolicyMetrics creates a new mock instance.
+func NewMockPolicyMetrics(ctrl *gomock.Controller) *MockPolicyMetrics {
+	mock := &MockPolicyMetrics{ctrl: ctrl}
+	mock.recorder = &MockPolicyMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyMetrics) EXPECT() *MockPolicyMetricsMockRecorder {
+	return m.recorder
+}
+
+// TrackPolicyRejection mocks base method.
+func (m *MockPolicyMetrics) TrackPolicyRejection(source, destination uint8, rule string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackPolicyRejection", source, destination, rule)
+}
+
+// TrackPolicyRejection indicates an expected call of TrackPolicyRejection.
+func (mr *MockPolicyMetricsMockRecorder) TrackPolicyRejection(source, destination, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackPolicyRejection", reflect.TypeOf((*MockPolicyMetrics)(nil).TrackPolicyRejection), source, destination, rule)
+}
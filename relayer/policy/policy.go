@@ -0,0 +1,153 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package policy
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+)
+
+type Rule string
+
+const (
+	RouteRule     Rule = "route"
+	ResourceRule  Rule = "resource"
+	AmountRule    Rule = "maxAmount"
+	TargetRule    Rule = "target"
+	DepositorRule Rule = "depositor"
+)
+
+// Violation is returned when a transfer breaks a signing policy rule
+type Violation struct {
+	Rule   Rule
+	Reason string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("signing policy %s rule violated: %s", v.Rule, v.Reason)
+}
+
+// Policy is a local declarative policy that decides which transfers
+// the relayer is willing to sign
+type Policy struct {
+	enabled          bool
+	routes           map[relayer.PolicyRoute]bool
+	resources        map[[32]byte]bool
+	maxAmounts       map[[32]byte]*big.Int
+	targets          map[string]bool
+	deniedDepositors map[string]bool
+}
+
+func NewPolicy(config relayer.SigningPolicyConfig) *Policy {
+	p := &Policy{
+		enabled:          config.Enabled,
+		routes:           make(map[relayer.PolicyRoute]bool),
+		resources:        make(map[[32]byte]bool),
+		maxAmounts:       make(map[[32]byte]*big.Int),
+		targets:          make(map[string]bool),
+		deniedDepositors: make(map[string]bool),
+	}
+	for _, r := range config.Routes {
+		p.routes[r] = true
+	}
+	for _, r := range config.Resources {
+		p.resources[r] = true
+	}
+	for _, a := range config.MaxAmounts {
+		p.maxAmounts[a.ResourceID] = a.Limit
+	}
+	for _, t := range config.Targets {
+		p.targets[strings.ToLower(t)] = true
+	}
+	for _, d := range config.DeniedDepositors {
+		p.deniedDepositors[strings.ToLower(d)] = true
+	}
+	return p
+}
+
+// Evaluate returns a *Violation if the transfer breaks any of the policy rules.
+// Disabled policy allows all transfers.
+func (p *Policy) Evaluate(m *transfer.TransferMessage) error {
+	if !p.enabled {
+		return nil
+	}
+
+	if len(p.routes) != 0 && !p.routes[relayer.PolicyRoute{Source: m.Source, Destination: m.Destination}] {
+		return &Violation{Rule: RouteRule, Reason: fmt.Sprintf("route %d-%d not allowed", m.Source, m.Destination)}
+	}
+	if len(p.resources) != 0 && !p.resources[m.Data.ResourceId] {
+		return &Violation{Rule: ResourceRule, Reason: fmt.Sprintf("resource %x not allowed", m.Data.ResourceId)}
+	}
+
+	maxAmount, ok := p.maxAmounts[m.Data.ResourceId]
+	if ok {
+		amount, err := fungibleAmount(m)
+		if err != nil {
+			return &Violation{Rule: AmountRule, Reason: err.Error()}
+		}
+		if amount.Cmp(maxAmount) > 0 {
+			return &Violation{Rule: AmountRule, Reason: fmt.Sprintf("amount %s exceeds maximum %s", amount, maxAmount)}
+		}
+	}
+
+	if len(p.targets) != 0 && m.Data.Type == transfer.PermissionlessGenericTransfer {
+		target, err := payloadAddress(m, 1)
+		if err != nil {
+			return &Violation{Rule: TargetRule, Reason: err.Error()}
+		}
+		if !p.targets[target] {
+			return &Violation{Rule: TargetRule, Reason: fmt.Sprintf("target %s not allowed", target)}
+		}
+	}
+
+	for _, depositor := range depositors(m) {
+		if p.deniedDepositors[depositor] {
+			return &Violation{Rule: DepositorRule, Reason: fmt.Sprintf("depositor %s denied", depositor)}
+		}
+	}
+	return nil
+}
+
+func fungibleAmount(m *transfer.TransferMessage) (*big.Int, error) {
+	if m.Data.Type != transfer.FungibleTransfer || len(m.Data.Payload) == 0 {
+		return nil, fmt.Errorf("no fungible amount in %s transfer", m.Data.Type)
+	}
+	amount, ok := m.Data.Payload[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("wrong payload amount format")
+	}
+	return new(big.Int).SetBytes(amount), nil
+}
+
+func payloadAddress(m *transfer.TransferMessage, i int) (string, error) {
+	if len(m.Data.Payload) <= i {
+		return "", fmt.Errorf("missing payload address at %d", i)
+	}
+	address, ok := m.Data.Payload[i].([]byte)
+	if !ok {
+		return "", fmt.Errorf("wrong payload address format at %d", i)
+	}
+	return "0x" + hex.EncodeToString(address), nil
+}
+
+// depositors returns known depositor addresses of the transfer
+func depositors(m *transfer.TransferMessage) []string {
+	depositors := make([]string, 0)
+	depositor, ok := m.Data.Metadata[transfer.DepositorMetadataKey].(string)
+	if ok {
+		depositors = append(depositors, strings.ToLower(depositor))
+	}
+	if m.Data.Type == transfer.PermissionlessGenericTransfer {
+		depositor, err := payloadAddress(m, 3)
+		if err == nil {
+			depositors = append(depositors, depositor)
+		}
+	}
+	return depositors
+}
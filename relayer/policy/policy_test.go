@@ -0,0 +1,170 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package policy_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/relayer/policy"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	allowedResource = [32]byte{1}
+	allowedTarget   = common.HexToAddress("0x5C1f5961696BaD2e73f73417f07EF55C62a2dC5b")
+	deniedDepositor = common.HexToAddress("0x1c3A03D04c026b1f4B4208D2ce053c5686E6FB8d")
+)
+
+type PolicyTestSuite struct {
+	suite.Suite
+	policy *policy.Policy
+}
+
+func TestRunPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(PolicyTestSuite))
+}
+
+func (s *PolicyTestSuite) SetupTest() {
+	s.policy = policy.NewPolicy(relayer.SigningPolicyConfig{
+		Enabled:   true,
+		Routes:    []relayer.PolicyRoute{{Source: 1, Destination: 2}},
+		Resources: [][32]byte{allowedResource},
+		MaxAmounts: []relayer.ResourceLimit{
+			{ResourceID: allowedResource, Limit: big.NewInt(100)},
+		},
+		Targets:          []string{allowedTarget.Hex()},
+		DeniedDepositors: []string{deniedDepositor.Hex()},
+	})
+}
+
+func fungibleTransfer(amount int64) *transfer.TransferMessage {
+	return &transfer.TransferMessage{
+		Source:      1,
+		Destination: 2,
+		Data: transfer.TransferMessageData{
+			DepositNonce: 1,
+			ResourceId:   allowedResource,
+			Payload:      []interface{}{big.NewInt(amount).Bytes(), []byte{2}},
+			Type:         transfer.FungibleTransfer,
+		},
+	}
+}
+
+func permissionlessTransfer(target common.Address, depositor common.Address) *transfer.TransferMessage {
+	return &transfer.TransferMessage{
+		Source:      1,
+		Destination: 2,
+		Data: transfer.TransferMessageData{
+			DepositNonce: 1,
+			ResourceId:   [32]byte{2},
+			Payload: []interface{}{
+				[]byte{1},
+				target.Bytes(),
+				[]byte{},
+				depositor.Bytes(),
+				[]byte{},
+			},
+			Type: transfer.PermissionlessGenericTransfer,
+		},
+	}
+}
+
+func (s *PolicyTestSuite) assertViolation(err error, rule policy.Rule) {
+	var violation *policy.Violation
+	s.True(errors.As(err, &violation))
+	s.Equal(violation.Rule, rule)
+}
+
+func (s *PolicyTestSuite) Test_Evaluate_DisabledPolicy() {
+	p := policy.NewPolicy(relayer.SigningPolicyConfig{
+		Routes: []relayer.PolicyRoute{{Source: 3, Destination: 4}},
+	})
+
+	err := p.Evaluate(fungibleTransfer(1000))
+
+	s.Nil(err)
+}
+
+func (s *PolicyTestSuite) Test_Evaluate_AllowedTransfer() {
+	err := s.policy.Evaluate(fungibleTransfer(100))
+
+	s.Nil(err)
+}
+
+func (s *PolicyTestSuite) Test_Evaluate_RouteNotAllowed() {
+	m := fungibleTransfer(1)
+	m.Destination = 3
+
+	err := s.policy.Evaluate(m)
+
+	s.assertViolation(err, policy.RouteRule)
+}
+
+func (s *PolicyTestSuite) Test_Evaluate_ResourceNotAllowed() {
+	p := policy.NewPolicy(relayer.SigningPolicyConfig{
+		Enabled:   true,
+		Resources: [][32]byte{allowedResource},
+	})
+	m := fungibleTransfer(1)
+	m.Data.ResourceId = [32]byte{3}
+
+	err := p.Evaluate(m)
+
+	s.assertViolation(err, policy.ResourceRule)
+}
+
+func (s *PolicyTestSuite) Test_Evaluate_AmountExceeded() {
+	err := s.policy.Evaluate(fungibleTransfer(101))
+
+	s.assertViolation(err, policy.AmountRule)
+}
+
+func (s *PolicyTestSuite) Test_Evaluate_TargetNotAllowed() {
+	p := policy.NewPolicy(relayer.SigningPolicyConfig{
+		Enabled: true,
+		Targets: []string{allowedTarget.Hex()},
+	})
+
+	err := p.Evaluate(permissionlessTransfer(common.HexToAddress("0x01"), common.HexToAddress("0x02")))
+
+	s.assertViolation(err, policy.TargetRule)
+}
+
+func (s *PolicyTestSuite) Test_Evaluate_AllowedTarget() {
+	p := policy.NewPolicy(relayer.SigningPolicyConfig{
+		Enabled: true,
+		Targets: []string{allowedTarget.Hex()},
+	})
+
+	err := p.Evaluate(permissionlessTransfer(allowedTarget, common.HexToAddress("0x02")))
+
+	s.Nil(err)
+}
+
+func (s *PolicyTestSuite) Test_Evaluate_DeniedPermissionlessDepositor() {
+	p := policy.NewPolicy(relayer.SigningPolicyConfig{
+		Enabled:          true,
+		DeniedDepositors: []string{deniedDepositor.Hex()},
+	})
+
+	err := p.Evaluate(permissionlessTransfer(allowedTarget, deniedDepositor))
+
+	s.assertViolation(err, policy.DepositorRule)
+}
+
+func (s *PolicyTestSuite) Test_Evaluate_DeniedMetadataDepositor() {
+	m := fungibleTransfer(1)
+	m.Data.Metadata = map[string]interface{}{
+		transfer.DepositorMetadataKey: deniedDepositor.Hex(),
+	}
+
+	err := s.policy.Evaluate(m)
+
+	s.assertViolation(err, policy.DepositorRule)
+}
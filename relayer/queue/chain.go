@@ -0,0 +1,99 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package queue
+
+import (
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/relayer"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+)
+
+type Acknowledger interface {
+	Ack(msgs []*message.Message) error
+	Fail(msgs []*message.Message) error
+}
+
+// AcknowledgingChain wraps a relayed chain and acknowledges queued messages
+// after proposals created from them are successfully written.
+type AcknowledgingChain struct {
+	relayer.RelayedChain
+	acknowledger Acknowledger
+
+	propMessages map[*proposal.Proposal]*message.Message
+	propLock     sync.Mutex
+}
+
+func NewAcknowledgingChain(chain relayer.RelayedChain, acknowledger Acknowledger) *AcknowledgingChain {
+	return &AcknowledgingChain{
+		RelayedChain: chain,
+		acknowledger: acknowledger,
+		propMessages: make(map[*proposal.Proposal]*message.Message),
+	}
+}
+
+// ReceiveMessage converts the message into a proposal and remembers the message
+// so it can be acknowledged after the proposal is written. Messages that
+// do not result in a proposal, like retries, are acknowledged immediately.
+// Messages that fail to convert are recorded as failed deliveries.
+func (c *AcknowledgingChain) ReceiveMessage(m *message.Message) (*proposal.Proposal, error) {
+	prop, err := c.RelayedChain.ReceiveMessage(m)
+	if err != nil {
+		c.fail([]*message.Message{m})
+		return nil, err
+	}
+
+	if prop == nil {
+		c.ack([]*message.Message{m})
+		return nil, nil
+	}
+
+	c.propLock.Lock()
+	c.propMessages[prop] = m
+	c.propLock.Unlock()
+	return prop, nil
+}
+
+// Write submits proposals on-chain and acknowledges related messages on success.
+// Messages of failed writes are recorded as failed deliveries and replayed on the
+// next start until they fail MaxDeliveryFailures times.
+func (c *AcknowledgingChain) Write(props []*proposal.Proposal) error {
+	msgs := make([]*message.Message, 0)
+	c.propLock.Lock()
+	for _, prop := range props {
+		m, ok := c.propMessages[prop]
+		if !ok {
+			continue
+		}
+
+		msgs = append(msgs, m)
+		delete(c.propMessages, prop)
+	}
+	c.propLock.Unlock()
+
+	err := c.RelayedChain.Write(props)
+	if err != nil {
+		c.fail(msgs)
+		return err
+	}
+
+	c.ack(msgs)
+	return nil
+}
+
+func (c *AcknowledgingChain) ack(msgs []*message.Message) {
+	err := c.acknowledger.Ack(msgs)
+	if err != nil {
+		log.Err(err).Uint8("domainID", c.DomainID()).Msgf("Failed acknowledging messages")
+	}
+}
+
+func (c *AcknowledgingChain) fail(msgs []*message.Message) {
+	err := c.acknowledger.Fail(msgs)
+	if err != nil {
+		log.Err(err).Uint8("domainID", c.DomainID()).Msgf("Failed recording failed message deliveries")
+	}
+}
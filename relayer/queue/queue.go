@@ -0,0 +1,319 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package queue
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/retry"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+var (
+	MESSAGE_PREFIX     = "queue:message:"
+	MESSAGE_KEY        = "queue:message:source:%d:block:%020d:seq:%020d"
+	DEAD_LETTER_PREFIX = "queue:dead:"
+)
+
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// MaxDeliveryFailures is the number of failed deliveries after which an unacknowledged
+// message is moved to the dead letter prefix on replay instead of being replayed
+var MaxDeliveryFailures uint64 = 5
+
+func init() {
+	// concrete types that can be found inside message data interfaces
+	gob.Register(transfer.TransferMessageData{})
+	gob.Register(retry.RetryMessageData{})
+	gob.Register([]byte{})
+	gob.Register([]*big.Int{})
+	gob.Register(uint64(0))
+}
+
+type QueueStorer interface {
+	WriteBatch(batch *leveldb.Batch) error
+	IterateByPrefix(prefix []byte, fn func(key []byte, value []byte) error) error
+}
+
+type queuedMessage struct {
+	Source      uint8
+	Destination uint8
+	Data        interface{}
+	ID          string
+	Type        message.MessageType
+	Timestamp   time.Time
+	Failures    uint64
+}
+
+type queuedKey struct {
+	key      []byte
+	failures uint64
+}
+
+// DeadLetter is a message that failed delivery MaxDeliveryFailures times
+type DeadLetter struct {
+	Key      string
+	Message  *message.Message
+	Failures uint64
+}
+
+// MessageQueue is a durable queue between listeners and the relayer. Messages are persisted
+// before they are sent to the relayer and are removed only after they are acknowledged.
+type MessageQueue struct {
+	db      QueueStorer
+	msgChan chan []*message.Message
+
+	seq     uint64
+	keys    map[*message.Message]queuedKey
+	keyLock sync.Mutex
+}
+
+func NewMessageQueue(db QueueStorer, msgChan chan []*message.Message) *MessageQueue {
+	return &MessageQueue{
+		db:      db,
+		msgChan: msgChan,
+		seq:     uint64(time.Now().UnixNano()),
+		keys:    make(map[*message.Message]queuedKey),
+	}
+}
+
+// Enqueue atomically stores messages keyed by the block height they were found in
+// and sends them to the relayer after they are persisted. Listeners advance their
+// block only after messages of the block are enqueued.
+func (q *MessageQueue) Enqueue(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+	batch := new(leveldb.Batch)
+	keys := make(map[*message.Message][]byte)
+	for _, msgs := range domainMessages {
+		for _, m := range msgs {
+			value, err := encodeMessage(m, 0)
+			if err != nil {
+				return err
+			}
+
+			key := []byte(fmt.Sprintf(MESSAGE_KEY, m.Source, block, atomic.AddUint64(&q.seq, 1)))
+			batch.Put(key, value)
+			keys[m] = key
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	err := q.db.WriteBatch(batch)
+	if err != nil {
+		return err
+	}
+
+	q.keyLock.Lock()
+	for m, key := range keys {
+		q.keys[m] = queuedKey{key: key}
+	}
+	q.keyLock.Unlock()
+
+	for _, msgs := range domainMessages {
+		if len(msgs) == 0 {
+			continue
+		}
+
+		go func(msgs []*message.Message) {
+			q.msgChan <- msgs
+		}(msgs)
+	}
+	return nil
+}
+
+// Ack removes messages from the queue. Messages that are not
+// in the queue are ignored.
+func (q *MessageQueue) Ack(msgs []*message.Message) error {
+	batch := new(leveldb.Batch)
+	q.keyLock.Lock()
+	for _, m := range msgs {
+		key, ok := q.keys[m]
+		if !ok {
+			continue
+		}
+
+		batch.Delete(key.key)
+		delete(q.keys, m)
+	}
+	q.keyLock.Unlock()
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	return q.db.WriteBatch(batch)
+}
+
+// Fail records a failed delivery of messages that stay in the queue.
+// Messages that are not in the queue are ignored.
+func (q *MessageQueue) Fail(msgs []*message.Message) error {
+	batch := new(leveldb.Batch)
+	q.keyLock.Lock()
+	for _, m := range msgs {
+		key, ok := q.keys[m]
+		if !ok {
+			continue
+		}
+
+		key.failures++
+		value, err := encodeMessage(m, key.failures)
+		if err != nil {
+			q.keyLock.Unlock()
+			return err
+		}
+		batch.Put(key.key, value)
+		q.keys[m] = key
+	}
+	q.keyLock.Unlock()
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	return q.db.WriteBatch(batch)
+}
+
+// Replay sends all unacknowledged messages to the relayer. Messages are
+// batched by destination and message ID in the order they were enqueued.
+// Messages that failed delivery MaxDeliveryFailures times are moved to the dead letter prefix.
+func (q *MessageQueue) Replay() error {
+	batches := make(map[string][]*message.Message)
+	order := make([]string, 0)
+	deadLetters := new(leveldb.Batch)
+	err := q.db.IterateByPrefix([]byte(MESSAGE_PREFIX), func(key []byte, value []byte) error {
+		m, failures, err := decodeMessage(value)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed decoding queued message %s", string(key))
+			return nil
+		}
+
+		if failures >= MaxDeliveryFailures {
+			log.Error().Str("messageID", m.ID).Msgf("Message %s failed delivery %d times, moving it to dead letters", string(key), failures)
+			deadLetters.Delete(key)
+			deadLetters.Put([]byte(DEAD_LETTER_PREFIX+strings.TrimPrefix(string(key), MESSAGE_PREFIX)), value)
+			return nil
+		}
+
+		q.keyLock.Lock()
+		q.keys[m] = queuedKey{key: key, failures: failures}
+		q.keyLock.Unlock()
+
+		batchID := fmt.Sprintf("%d-%s", m.Destination, m.ID)
+		if _, ok := batches[batchID]; !ok {
+			order = append(order, batchID)
+		}
+		batches[batchID] = append(batches[batchID], m)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if deadLetters.Len() > 0 {
+		err = q.db.WriteBatch(deadLetters)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, batchID := range order {
+		msgs := batches[batchID]
+		log.Info().Str("messageID", msgs[0].ID).Msgf("Replaying %d unacknowledged messages", len(msgs))
+		q.msgChan <- msgs
+	}
+	return nil
+}
+
+// DeadLetters returns messages moved to the dead letter prefix in the order they were enqueued
+func (q *MessageQueue) DeadLetters() ([]DeadLetter, error) {
+	deadLetters := make([]DeadLetter, 0)
+	err := q.db.IterateByPrefix([]byte(DEAD_LETTER_PREFIX), func(key []byte, value []byte) error {
+		m, failures, err := decodeMessage(value)
+		if err != nil {
+			return fmt.Errorf("failed decoding dead letter %s: %w", string(key), err)
+		}
+
+		deadLetters = append(deadLetters, DeadLetter{
+			Key:      strings.TrimPrefix(string(key), DEAD_LETTER_PREFIX),
+			Message:  m,
+			Failures: failures,
+		})
+		return nil
+	})
+	return deadLetters, err
+}
+
+// Requeue moves the dead letter back to the queue with a reset failure
+// count and sends it to the relayer
+func (q *MessageQueue) Requeue(key string) error {
+	deadLetters, err := q.DeadLetters()
+	if err != nil {
+		return err
+	}
+
+	for _, deadLetter := range deadLetters {
+		if deadLetter.Key != key {
+			continue
+		}
+
+		value, err := encodeMessage(deadLetter.Message, 0)
+		if err != nil {
+			return err
+		}
+		messageKey := []byte(MESSAGE_PREFIX + key)
+		batch := new(leveldb.Batch)
+		batch.Delete([]byte(DEAD_LETTER_PREFIX + key))
+		batch.Put(messageKey, value)
+		err = q.db.WriteBatch(batch)
+		if err != nil {
+			return err
+		}
+
+		q.keyLock.Lock()
+		q.keys[deadLetter.Message] = queuedKey{key: messageKey}
+		q.keyLock.Unlock()
+
+		log.Info().Str("messageID", deadLetter.Message.ID).Msgf("Requeued dead letter %s", key)
+		go func(msgs []*message.Message) {
+			q.msgChan <- msgs
+		}([]*message.Message{deadLetter.Message})
+		return nil
+	}
+	return ErrDeadLetterNotFound
+}
+
+func encodeMessage(m *message.Message, failures uint64) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(queuedMessage{
+		Source:      m.Source,
+		Destination: m.Destination,
+		Data:        m.Data,
+		ID:          m.ID,
+		Type:        m.Type,
+		Timestamp:   m.Timestamp,
+		Failures:    failures,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeMessage(value []byte) (*message.Message, uint64, error) {
+	var qm queuedMessage
+	err := gob.NewDecoder(bytes.NewReader(value)).Decode(&qm)
+	if err != nil {
+		return nil, 0, err
+	}
+	return message.NewMessage(qm.Source, qm.Destination, qm.Data, qm.ID, qm.Type, qm.Timestamp), qm.Failures, nil
+}
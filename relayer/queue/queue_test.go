@@ -0,0 +1,221 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package queue_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/queue"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ChainSafe/sygma-relayer/store/lvldb"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+)
+
+type MessageQueueTestSuite struct {
+	suite.Suite
+	db      *lvldb.LVLDB
+	msgChan chan []*message.Message
+	queue   *queue.MessageQueue
+}
+
+func TestRunMessageQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(MessageQueueTestSuite))
+}
+
+func (s *MessageQueueTestSuite) SetupTest() {
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	s.db = db
+	s.msgChan = make(chan []*message.Message, 2)
+	s.queue = queue.NewMessageQueue(s.db, s.msgChan)
+}
+
+func (s *MessageQueueTestSuite) TearDownTest() {
+	_ = s.db.Close()
+}
+
+func (s *MessageQueueTestSuite) testMessage(nonce uint64) *message.Message {
+	return message.NewMessage(1, 2, transfer.TransferMessageData{
+		DepositNonce: nonce,
+		ResourceId:   [32]byte{1},
+		Payload:      []interface{}{[]byte{1}, []byte{2}},
+		Type:         transfer.FungibleTransfer,
+	}, "messageID", transfer.TransferMessageType, time.Unix(100, 0))
+}
+
+func (s *MessageQueueTestSuite) Test_Enqueue_EmptyMessages() {
+	err := s.queue.Enqueue(big.NewInt(5), map[uint8][]*message.Message{})
+
+	s.Nil(err)
+	s.Equal(len(s.msgChan), 0)
+}
+
+func (s *MessageQueueTestSuite) Test_Enqueue_SendsAndStoresMessageWithBlock() {
+	msg := s.testMessage(1)
+
+	err := s.queue.Enqueue(big.NewInt(5), map[uint8][]*message.Message{2: {msg}})
+	msgs := <-s.msgChan
+
+	s.Nil(err)
+	s.Equal(msgs, []*message.Message{msg})
+	keys := make([]string, 0)
+	err = s.db.IterateByPrefix([]byte(queue.MESSAGE_PREFIX), func(key []byte, value []byte) error {
+		keys = append(keys, string(key))
+		return nil
+	})
+	s.Nil(err)
+	s.Equal(len(keys), 1)
+	s.Contains(keys[0], fmt.Sprintf("source:1:block:%020d", 5))
+}
+
+func (s *MessageQueueTestSuite) Test_Replay_UnacknowledgedMessages() {
+	msg := s.testMessage(1)
+	err := s.queue.Enqueue(big.NewInt(5), map[uint8][]*message.Message{2: {msg}})
+	s.Nil(err)
+	<-s.msgChan
+
+	err = queue.NewMessageQueue(s.db, s.msgChan).Replay()
+	msgs := <-s.msgChan
+
+	s.Nil(err)
+	s.Equal(len(msgs), 1)
+	s.Equal(msgs[0].Data, msg.Data)
+	s.Equal(msgs[0].ID, msg.ID)
+	s.Equal(msgs[0].Source, msg.Source)
+	s.Equal(msgs[0].Destination, msg.Destination)
+	s.True(msgs[0].Timestamp.Equal(msg.Timestamp))
+}
+
+func (s *MessageQueueTestSuite) Test_Replay_RestartsDoNotMoveMessageToDeadLetters() {
+	msg := s.testMessage(1)
+	err := s.queue.Enqueue(big.NewInt(5), map[uint8][]*message.Message{2: {msg}})
+	s.Nil(err)
+	<-s.msgChan
+
+	for i := uint64(0); i <= queue.MaxDeliveryFailures; i++ {
+		err = queue.NewMessageQueue(s.db, s.msgChan).Replay()
+		s.Nil(err)
+		<-s.msgChan
+	}
+
+	deadLetters, err := s.queue.DeadLetters()
+	s.Nil(err)
+	s.Equal(len(deadLetters), 0)
+}
+
+func (s *MessageQueueTestSuite) Test_Replay_MovesMessageToDeadLettersAfterMaxDeliveryFailures() {
+	msg := s.testMessage(1)
+	err := s.queue.Enqueue(big.NewInt(5), map[uint8][]*message.Message{2: {msg}})
+	s.Nil(err)
+	<-s.msgChan
+
+	chain := queue.NewAcknowledgingChain(&mockChain{writeErr: fmt.Errorf("error")}, s.queue)
+	for i := uint64(0); i < queue.MaxDeliveryFailures; i++ {
+		prop, err := chain.ReceiveMessage(msg)
+		s.Nil(err)
+		err = chain.Write([]*proposal.Proposal{prop})
+		s.NotNil(err)
+	}
+	err = queue.NewMessageQueue(s.db, s.msgChan).Replay()
+
+	s.Nil(err)
+	s.Equal(len(s.msgChan), 0)
+	deadLetters, err := s.queue.DeadLetters()
+	s.Nil(err)
+	s.Equal(len(deadLetters), 1)
+	s.Equal(deadLetters[0].Failures, queue.MaxDeliveryFailures)
+	s.Equal(deadLetters[0].Message.ID, msg.ID)
+	s.Contains(deadLetters[0].Key, fmt.Sprintf("source:1:block:%020d", 5))
+}
+
+func (s *MessageQueueTestSuite) Test_Requeue_MissingDeadLetter() {
+	err := s.queue.Requeue("source:1:block:00000000000000000005:seq:00000000000000000001:index:00000")
+
+	s.ErrorIs(err, queue.ErrDeadLetterNotFound)
+}
+
+func (s *MessageQueueTestSuite) Test_Requeue_SendsDeadLetterAndResetsFailures() {
+	msg := s.testMessage(1)
+	err := s.queue.Enqueue(big.NewInt(5), map[uint8][]*message.Message{2: {msg}})
+	s.Nil(err)
+	<-s.msgChan
+	chain := queue.NewAcknowledgingChain(&mockChain{writeErr: fmt.Errorf("error")}, s.queue)
+	for i := uint64(0); i < queue.MaxDeliveryFailures; i++ {
+		prop, _ := chain.ReceiveMessage(msg)
+		_ = chain.Write([]*proposal.Proposal{prop})
+	}
+	q := queue.NewMessageQueue(s.db, s.msgChan)
+	err = q.Replay()
+	s.Nil(err)
+	deadLetters, err := q.DeadLetters()
+	s.Nil(err)
+
+	err = q.Requeue(deadLetters[0].Key)
+	msgs := <-s.msgChan
+
+	s.Nil(err)
+	s.Equal(msgs[0].ID, msg.ID)
+	deadLetters, err = q.DeadLetters()
+	s.Nil(err)
+	s.Equal(len(deadLetters), 0)
+	err = queue.NewMessageQueue(s.db, s.msgChan).Replay()
+	s.Nil(err)
+	msgs = <-s.msgChan
+	s.Equal(msgs[0].ID, msg.ID)
+}
+
+func (s *MessageQueueTestSuite) Test_Replay_AcknowledgedMessagesSkipped() {
+	msg := s.testMessage(1)
+	err := s.queue.Enqueue(big.NewInt(5), map[uint8][]*message.Message{2: {msg}})
+	s.Nil(err)
+	<-s.msgChan
+
+	err = s.queue.Ack([]*message.Message{msg})
+	s.Nil(err)
+	err = queue.NewMessageQueue(s.db, s.msgChan).Replay()
+
+	s.Nil(err)
+	s.Equal(len(s.msgChan), 0)
+}
+
+func (s *MessageQueueTestSuite) Test_AcknowledgingChain_AcksAfterSuccessfulWrite() {
+	msg := s.testMessage(1)
+	err := s.queue.Enqueue(big.NewInt(5), map[uint8][]*message.Message{2: {msg}})
+	s.Nil(err)
+	<-s.msgChan
+
+	chain := queue.NewAcknowledgingChain(&mockChain{}, s.queue)
+	prop, err := chain.ReceiveMessage(msg)
+	s.Nil(err)
+	err = chain.Write([]*proposal.Proposal{prop})
+	s.Nil(err)
+
+	err = queue.NewMessageQueue(s.db, s.msgChan).Replay()
+	s.Nil(err)
+	s.Equal(len(s.msgChan), 0)
+}
+
+type mockChain struct {
+	writeErr error
+}
+
+func (c *mockChain) PollEvents(ctx context.Context) {}
+
+func (c *mockChain) ReceiveMessage(m *message.Message) (*proposal.Proposal, error) {
+	return proposal.NewProposal(m.Source, m.Destination, m.Data, m.ID, transfer.TransferProposalType), nil
+}
+
+func (c *mockChain) Write(props []*proposal.Proposal) error {
+	return c.writeErr
+}
+
+func (c *mockChain) DomainID() uint8 {
+	return 2
+}
@@ -0,0 +1,215 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package reorg
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+// RetainedBlocks is the number of blocks behind the latest processed block
+// for which hashes are kept. Reorgs deeper than this are re-scanned from the oldest kept hash.
+var RetainedBlocks uint64 = 10000
+
+type BlockHashFetcher interface {
+	// BlockHash returns the hash of the canonical block
+	BlockHash(block *big.Int) (string, error)
+	// ParentHash returns the parent hash of the canonical block
+	ParentHash(block *big.Int) (string, error)
+}
+
+type BlockHashStorer interface {
+	StoreBlockHash(domainID uint8, block uint64, hash string) error
+	BlockHashes(domainID uint8) ([]store.BlockHash, error)
+	DeleteBlockHashes(domainID uint8, from uint64, to uint64) error
+}
+
+type DepositProcessor interface {
+	ProcessDeposits(startBlock *big.Int, endBlock *big.Int) (map[uint8][]*message.Message, error)
+}
+
+type PropStorer interface {
+	IterateProps(fn func(key store.PropKey, record *store.PropRecord) error) error
+	StorePropReorg(source, destination uint8, depositNonce uint64, reason string) error
+}
+
+type MessageQueue interface {
+	Enqueue(block *big.Int, domainMessages map[uint8][]*message.Message) error
+}
+
+type ReorgMetrics interface {
+	TrackReorg(domainID uint8, depth uint64)
+	TrackReorgedDeposit(source, destination uint8)
+}
+
+// Detector is a listener event handler that records hashes of processed blocks and checks
+// on every poll that the new block range continues the processed chain.
+// If processed blocks were reorged, deposits from the reorged blocks are processed again and
+// proposals of deposits that vanished are marked as reorged.
+//
+// The detector has to be the first event handler of the listener.
+type Detector struct {
+	domainID         uint8
+	hashFetcher      BlockHashFetcher
+	hashStorer       BlockHashStorer
+	depositProcessor DepositProcessor
+	propStorer       PropStorer
+	msgQueue         MessageQueue
+	metrics          ReorgMetrics
+	log              zerolog.Logger
+}
+
+func NewDetector(
+	domainID uint8,
+	hashFetcher BlockHashFetcher,
+	hashStorer BlockHashStorer,
+	depositProcessor DepositProcessor,
+	propStorer PropStorer,
+	msgQueue MessageQueue,
+	metrics ReorgMetrics,
+) *Detector {
+	return &Detector{
+		domainID:         domainID,
+		hashFetcher:      hashFetcher,
+		hashStorer:       hashStorer,
+		depositProcessor: depositProcessor,
+		propStorer:       propStorer,
+		msgQueue:         msgQueue,
+		metrics:          metrics,
+		log:              log.With().Uint8("domainID", domainID).Logger(),
+	}
+}
+
+func (d *Detector) HandleEvents(startBlock *big.Int, endBlock *big.Int) error {
+	hashes, err := d.hashStorer.BlockHashes(d.domainID)
+	if err != nil {
+		return err
+	}
+
+	previous := new(big.Int).Sub(startBlock, big.NewInt(1))
+	if len(hashes) > 0 && hashes[0].Block == previous.Uint64() {
+		parentHash, err := d.hashFetcher.ParentHash(startBlock)
+		if err != nil {
+			return err
+		}
+
+		if parentHash != hashes[0].Hash {
+			err = d.handleReorg(hashes, previous)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	hash, err := d.hashFetcher.BlockHash(endBlock)
+	if err != nil {
+		return err
+	}
+	err = d.hashStorer.StoreBlockHash(d.domainID, endBlock.Uint64(), hash)
+	if err != nil {
+		return err
+	}
+
+	retainedFrom := uint64(0)
+	if endBlock.Uint64() > RetainedBlocks {
+		retainedFrom = endBlock.Uint64() - RetainedBlocks
+	}
+	return d.hashStorer.DeleteBlockHashes(d.domainID, retainedFrom, endBlock.Uint64())
+}
+
+// handleReorg finds the latest processed block that is still canonical
+// and re-processes deposits after it up to the last processed block
+func (d *Detector) handleReorg(hashes []store.BlockHash, lastProcessed *big.Int) error {
+	from := new(big.Int).SetUint64(hashes[len(hashes)-1].Block)
+	ancestorFound := false
+	for _, hash := range hashes[1:] {
+		canonicalHash, err := d.hashFetcher.BlockHash(new(big.Int).SetUint64(hash.Block))
+		if err != nil {
+			return err
+		}
+
+		if canonicalHash == hash.Hash {
+			from.SetUint64(hash.Block + 1)
+			ancestorFound = true
+			break
+		}
+	}
+	if !ancestorFound {
+		d.log.Error().Msgf("Reorg deeper than retained block hashes, re-scanning from block %s", from)
+	}
+
+	depth := new(big.Int).Sub(lastProcessed, from).Uint64() + 1
+	d.log.Warn().Msgf("Detected reorg of blocks %s-%s, re-scanning deposits", from, lastProcessed)
+	d.metrics.TrackReorg(d.domainID, depth)
+
+	domainDeposits, err := d.depositProcessor.ProcessDeposits(from, lastProcessed)
+	if err != nil {
+		return err
+	}
+	err = d.markVanishedDeposits(domainDeposits, from.Uint64(), lastProcessed.Uint64())
+	if err != nil {
+		return err
+	}
+	err = d.msgQueue.Enqueue(lastProcessed, domainDeposits)
+	if err != nil {
+		return err
+	}
+
+	if from.Uint64() == 0 {
+		return nil
+	}
+	return d.hashStorer.DeleteBlockHashes(d.domainID, 0, from.Uint64()-1)
+}
+
+// markVanishedDeposits marks proposals of deposits made in the reorged
+// blocks that are not part of the canonical chain anymore
+func (d *Detector) markVanishedDeposits(domainDeposits map[uint8][]*message.Message, from, to uint64) error {
+	found := make(map[store.PropKey]bool)
+	for destination, msgs := range domainDeposits {
+		for _, m := range msgs {
+			data, ok := m.Data.(transfer.TransferMessageData)
+			if !ok {
+				continue
+			}
+
+			found[store.PropKey{Source: d.domainID, Destination: destination, DepositNonce: data.DepositNonce}] = true
+		}
+	}
+
+	vanished := make([]store.PropKey, 0)
+	err := d.propStorer.IterateProps(func(key store.PropKey, record *store.PropRecord) error {
+		if key.Source != d.domainID || record.DepositBlock < from || record.DepositBlock > to {
+			return nil
+		}
+		if record.Status == store.ReorgedProp || found[key] {
+			return nil
+		}
+
+		if record.Status == store.ExecutedProp {
+			d.log.Error().Msgf("Executed deposit %d to domain %d vanished in reorg", key.DepositNonce, key.Destination)
+		} else {
+			d.log.Error().Msgf("Deposit %d to domain %d vanished in reorg", key.DepositNonce, key.Destination)
+		}
+		vanished = append(vanished, key)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range vanished {
+		err := d.propStorer.StorePropReorg(key.Source, key.Destination, key.DepositNonce, fmt.Sprintf("deposit vanished in reorg of blocks %d-%d", from, to))
+		if err != nil {
+			return err
+		}
+		d.metrics.TrackReorgedDeposit(key.Source, key.Destination)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package reorg_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/reorg"
+	mock_reorg "github.com/ChainSafe/sygma-relayer/relayer/reorg/mock"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/ChainSafe/sygma-relayer/store/lvldb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+type DetectorTestSuite struct {
+	suite.Suite
+	db                   *lvldb.LVLDB
+	hashStore            *store.BlockHashStore
+	propStore            *store.PropStore
+	mockHashFetcher      *mock_reorg.MockBlockHashFetcher
+	mockDepositProcessor *mock_reorg.MockDepositProcessor
+	mockMsgQueue         *mock_reorg.MockMessageQueue
+	mockMetrics          *mock_reorg.MockReorgMetrics
+	detector             *reorg.Detector
+}
+
+func TestRunDetectorTestSuite(t *testing.T) {
+	suite.Run(t, new(DetectorTestSuite))
+}
+
+func (s *DetectorTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	s.db = db
+	s.hashStore = store.NewBlockHashStore(db)
+	s.propStore = store.NewPropStore(db)
+	s.mockHashFetcher = mock_reorg.NewMockBlockHashFetcher(ctrl)
+	s.mockDepositProcessor = mock_reorg.NewMockDepositProcessor(ctrl)
+	s.mockMsgQueue = mock_reorg.NewMockMessageQueue(ctrl)
+	s.mockMetrics = mock_reorg.NewMockReorgMetrics(ctrl)
+	s.detector = reorg.NewDetector(1, s.mockHashFetcher, s.hashStore, s.mockDepositProcessor, s.propStore, s.mockMsgQueue, s.mockMetrics)
+}
+
+func (s *DetectorTestSuite) TearDownTest() {
+	_ = s.db.Close()
+}
+
+func (s *DetectorTestSuite) storeDeposit(destination uint8, nonce uint64, block uint64, status store.PropStatus) {
+	s.Nil(s.propStore.StorePropStatus(1, destination, nonce, status))
+	s.Nil(s.propStore.StorePropDeposit(1, destination, nonce, [32]byte{1}, block))
+}
+
+func (s *DetectorTestSuite) Test_FirstRange_StoresHash() {
+	s.mockHashFetcher.EXPECT().BlockHash(big.NewInt(14)).Return("0x14", nil)
+
+	err := s.detector.HandleEvents(big.NewInt(10), big.NewInt(14))
+
+	s.Nil(err)
+	hashes, err := s.hashStore.BlockHashes(1)
+	s.Nil(err)
+	s.Equal(hashes, []store.BlockHash{{Block: 14, Hash: "0x14"}})
+}
+
+func (s *DetectorTestSuite) Test_ContinuousChain() {
+	s.Nil(s.hashStore.StoreBlockHash(1, 9, "0x09"))
+	s.mockHashFetcher.EXPECT().ParentHash(big.NewInt(10)).Return("0x09", nil)
+	s.mockHashFetcher.EXPECT().BlockHash(big.NewInt(14)).Return("0x14", nil)
+
+	err := s.detector.HandleEvents(big.NewInt(10), big.NewInt(14))
+
+	s.Nil(err)
+	hashes, err := s.hashStore.BlockHashes(1)
+	s.Nil(err)
+	s.Equal(len(hashes), 2)
+}
+
+func (s *DetectorTestSuite) Test_FetchFails() {
+	s.Nil(s.hashStore.StoreBlockHash(1, 9, "0x09"))
+	s.mockHashFetcher.EXPECT().ParentHash(big.NewInt(10)).Return("", errors.New("error"))
+
+	err := s.detector.HandleEvents(big.NewInt(10), big.NewInt(14))
+
+	s.NotNil(err)
+}
+
+func (s *DetectorTestSuite) Test_Reorg_RescansAndMarksVanishedDeposits() {
+	s.Nil(s.hashStore.StoreBlockHash(1, 4, "0x04"))
+	s.Nil(s.hashStore.StoreBlockHash(1, 9, "0x09"))
+	s.Nil(s.hashStore.StoreBlockHash(1, 14, "0x14"))
+	s.storeDeposit(2, 1, 3, store.ExecutedProp)
+	s.storeDeposit(2, 2, 6, store.PendingProp)
+	s.storeDeposit(2, 3, 12, store.ExecutedProp)
+	s.storeDeposit(3, 4, 13, store.FailedProp)
+	s.mockHashFetcher.EXPECT().ParentHash(big.NewInt(15)).Return("0x14b", nil)
+	s.mockHashFetcher.EXPECT().BlockHash(big.NewInt(9)).Return("0x09b", nil)
+	s.mockHashFetcher.EXPECT().BlockHash(big.NewInt(4)).Return("0x04", nil)
+	deposits := map[uint8][]*message.Message{
+		2: {message.NewMessage(1, 2, transfer.TransferMessageData{DepositNonce: 3}, "1-2-5-14", transfer.TransferMessageType, time.Time{})},
+	}
+	s.mockDepositProcessor.EXPECT().ProcessDeposits(big.NewInt(5), big.NewInt(14)).Return(deposits, nil)
+	s.mockMsgQueue.EXPECT().Enqueue(big.NewInt(14), deposits).Return(nil)
+	s.mockMetrics.EXPECT().TrackReorg(uint8(1), uint64(10))
+	s.mockMetrics.EXPECT().TrackReorgedDeposit(uint8(1), uint8(2))
+	s.mockMetrics.EXPECT().TrackReorgedDeposit(uint8(1), uint8(3))
+	s.mockHashFetcher.EXPECT().BlockHash(big.NewInt(19)).Return("0x19", nil)
+
+	err := s.detector.HandleEvents(big.NewInt(15), big.NewInt(19))
+
+	s.Nil(err)
+	for _, nonce := range []uint64{1, 3} {
+		status, err := s.propStore.PropStatus(1, 2, nonce)
+		s.Nil(err)
+		s.Equal(status, store.ExecutedProp)
+	}
+	record, err := s.propStore.PropRecord(1, 2, 2)
+	s.Nil(err)
+	s.Equal(record.Status, store.ReorgedProp)
+	s.Equal(record.LastError, "deposit vanished in reorg of blocks 5-14")
+	status, err := s.propStore.PropStatus(1, 3, 4)
+	s.Nil(err)
+	s.Equal(status, store.ReorgedProp)
+	hashes, err := s.hashStore.BlockHashes(1)
+	s.Nil(err)
+	s.Equal(hashes, []store.BlockHash{{Block: 19, Hash: "0x19"}, {Block: 4, Hash: "0x04"}})
+}
+
+func (s *DetectorTestSuite) Test_Reorg_ProcessingFails() {
+	s.Nil(s.hashStore.StoreBlockHash(1, 9, "0x09"))
+	s.mockHashFetcher.EXPECT().ParentHash(big.NewInt(10)).Return("0x09b", nil)
+	s.mockMetrics.EXPECT().TrackReorg(uint8(1), uint64(1))
+	s.mockDepositProcessor.EXPECT().ProcessDeposits(big.NewInt(9), big.NewInt(9)).Return(nil, errors.New("error"))
+
+	err := s.detector.HandleEvents(big.NewInt(10), big.NewInt(14))
+
+	s.NotNil(err)
+	hashes, err := s.hashStore.BlockHashes(1)
+	s.Nil(err)
+	s.Equal(hashes, []store.BlockHash{{Block: 9, Hash: "0x09"}})
+}
@@ -0,0 +1,306 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./relayer/reorg/detector.go
+
+// Package mock_reorg is a generated GoMock package.
+package mock_reorg
+
+import (
+	big "math/big"
+	reflect "reflect"
+
+	store "github.com/ChainSafe/sygma-relayer/store"
+	gomock "github.com/golang/mock/gomock"
+	message "github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+// MockBlockHashFetcher is a mock of BlockHashFetcher interface.
+type MockBlockHashFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockHashFetcherMockRecorder
+}
+
+// MockBlockHashFetcherMockRecorder is the mock recorder for MockBlockHashFetcher.
+type MockBlockHashFetcherMockRecorder struct {
+	mock *MockBlockHashFetcher
+}
+
+// NewMockBlockHashFetcher creates a new mock instance.
+func NewMockBlockHashFetcher(ctrl *gomock.Controller) *MockBlockHashFetcher {
+	mock := &MockBlockHashFetcher{ctrl: ctrl}
+	mock.recorder = &MockBlockHashFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockHashFetcher) EXPECT() *MockBlockHashFetcherMockRecorder {
+	return m.recorder
+}
+
+// BlockHash mocks base method.
+func (m *MockBlockHashFetcher) BlockHash(block *big.Int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockHash", block)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockHash indicates an expected call of BlockHash.
+func (mr *MockBlockHashFetcherMockRecorder) BlockHash(block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockHash", reflect.TypeOf((*MockBlockHashFetcher)(nil).BlockHash), block)
+}
+
+// ParentHash mocks base method.
+func (m *MockBlockHashFetcher) ParentHash(block *big.Int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParentHash", block)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParentHash indicates an expected call of ParentHash.
+func (mr *MockBlockHashFetcherMockRecorder) ParentHash(block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParentHash", reflect.TypeOf((*MockBlockHashFetcher)(nil).ParentHash), block)
+}
+
+// MockBlockHashStorer is a mock of BlockHashStorer interface.
+type MockBlockHashStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockHashStorerMockRecorder
+}
+
+// MockBlockHashStorerMockRecorder is the mock recorder for MockBlockHashStorer.
+type MockBlockHashStorerMockRecorder struct {
+	mock *MockBlockHashStorer
+}
+
+// NewMockBlockHashStorer creates a new mock instance.
+func NewMockBlockHashStorer(ctrl *gomock.Controller) *MockBlockHashStorer {
+	mock := &MockBlockHashStorer{ctrl: ctrl}
+	mock.recorder = &MockBlockHashStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockHashStorer) EXPECT() *MockBlockHashStorerMockRecorder {
+	return m.recorder
+}
+
+// BlockHashes mocks base method.
+func (m *MockBlockHashStorer) BlockHashes(domainID uint8) ([]store.BlockHash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockHashes", domainID)
+	ret0, _ := ret[0].([]store.BlockHash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockHashes indicates an expected call of BlockHashes.
+func (mr *MockBlockHashStorerMockRecorder) BlockHashes(domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockHashes", reflect.TypeOf((*MockBlockHashStorer)(nil).BlockHashes), domainID)
+}
+
+// DeleteBlockHashes mocks base method.
+func (m *MockBlockHashStorer) DeleteBlockHashes(domainID uint8, from, to uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlockHashes", domainID, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlockHashes indicates an expected call of DeleteBlockHashes.
+func (mr *MockBlockHashStorerMockRecorder) DeleteBlockHashes(domainID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlockHashes", reflect.TypeOf((*MockBlockHashStorer)(nil).DeleteBlockHashes), domainID, from, to)
+}
+
+// StoreBlockHash mocks base method.
+func (m *MockBlockHashStorer) StoreBlockHash(domainID uint8, block uint64, hash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreBlockHash", domainID, block, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreBlockHash indicates an expected call of StoreBlockHash.
+func (mr *MockBlockHashStorerMockRecorder) StoreBlockHash(domainID, block, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreBlockHash", reflect.TypeOf((*MockBlockHashStorer)(nil).StoreBlockHash), domainID, block, hash)
+}
+
+// MockDepositProcessor is a mock of DepositProcessor interface.
+type MockDepositProcessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockDepositProcessorMockRecorder
+}
+
+// MockDepositProcessorMockRecorder is the mock recorder for MockDepositProcessor.
+type MockDepositProcessorMockRecorder struct {
+	mock *MockDepositProcessor
+}
+
+// NewMockDepositProcessor creates a new mock instance.
+func NewMockDepositProcessor(ctrl *gomock.Controller) *MockDepositProcessor {
+	mock := &MockDepositProcessor{ctrl: ctrl}
+	mock.recorder = &MockDepositProcessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDepositProcessor) EXPECT() *MockDepositProcessorMockRecorder {
+	return m.recorder
+}
+
+// ProcessDeposits mocks base method.
+func (m *MockDepositProcessor) ProcessDeposits(startBlock, endBlock *big.Int) (map[uint8][]*message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessDeposits", startBlock, endBlock)
+	ret0, _ := ret[0].(map[uint8][]*message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessDeposits indicates an expected call of ProcessDeposits.
+func (mr *MockDepositProcessorMockRecorder) ProcessDeposits(startBlock, endBlock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessDeposits", reflect.TypeOf((*MockDepositProcessor)(nil).ProcessDeposits), startBlock, endBlock)
+}
+
+// MockPropStorer is a mock of PropStorer interface.
+type MockPropStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPropStorerMockRecorder
+}
+
+// MockPropStorerMockRecorder is the mock recorder for MockPropStorer.
+type MockPropStorerMockRecorder struct {
+	mock *MockPropStorer
+}
+
+// NewMockPropStorer creates a new mock instance.
+func NewMockPropStorer(ctrl *gomock.Controller) *MockPropStorer {
+	mock := &MockPropStorer{ctrl: ctrl}
+	mock.recorder = &MockPropStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPropStorer) EXPECT() *MockPropStorerMockRecorder {
+	return m.recorder
+}
+
+// IterateProps mocks base method.
+func (m *MockPropStorer) IterateProps(fn func(store.PropKey, *store.PropRecord) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateProps", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IterateProps indicates an expected call of IterateProps.
+func (mr *MockPropStorerMockRecorder) IterateProps(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateProps", reflect.TypeOf((*MockPropStorer)(nil).IterateProps), fn)
+}
+
+// StorePropReorg mocks base method.
+func (m *MockPropStorer) StorePropReorg(source, destination uint8, depositNonce uint64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorePropReorg", source, destination, depositNonce, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StorePropReorg indicates an expected call of StorePropReorg.
+func (mr *MockPropStorerMockRecorder) StorePropReorg(source, destination, depositNonce, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePropReorg", reflect.TypeOf((*MockPropStorer)(nil).StorePropReorg), source, destination, depositNonce, reason)
+}
+
+// MockMessageQueue is a mock of MessageQueue interface.
+type MockMessageQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageQueueMockRecorder
+}
+
+// MockMessageQueueMockRecorder is the mock recorder for MockMessageQueue.
+type MockMessageQueueMockRecorder struct {
+	mock *MockMessageQueue
+}
+
+// NewMockMessageQueue creates a new mock instance.
+func NewMockMessageQueue(ctrl *gomock.Controller) *MockMessageQueue {
+	mock := &MockMessageQueue{ctrl: ctrl}
+	mock.recorder = &MockMessageQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageQueue) EXPECT() *MockMessageQueueMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockMessageQueue) Enqueue(block *big.Int, domainMessages map[uint8][]*message.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", block, domainMessages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockMessageQueueMockRecorder) Enqueue(block, domainMessages interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockMessageQueue)(nil).Enqueue), block, domainMessages)
+}
+
+// MockReorgMetrics is a mock of ReorgMetrics interface.
+type MockReorgMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockReorgMetricsMockRecorder
+}
+
+// MockReorgMetricsMockRecorder is the mock recorder for MockReorgMetrics.
+type MockReorgMetricsMockRecorder struct {
+	mock *MockReorgMetrics
+}
+
+// NewMockReorgMetrics creates a new mock instance.
+func NewMockReorgMetrics(ctrl *gomock.Controller) *MockReorgMetrics {
+	mock := &MockReorgMetrics{ctrl: ctrl}
+	mock.recorder = &MockReorgMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReorgMetrics) EXPECT() *MockReorgMetricsMockRecorder {
+	return m.recorder
+}
+
+// TrackReorg mocks base method.
+func (m *MockReorgMetrics) TrackReorg(domainID uint8, depth uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackReorg", domainID, depth)
+}
+
+// TrackReorg indicates an expected call of TrackReorg.
+func (mr *MockReorgMetricsMockRecorder) TrackReorg(domainID, depth interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackReorg", reflect.TypeOf((*MockReorgMetrics)(nil).TrackReorg), domainID, depth)
+}
+
+// TrackReorgedDeposit mocks base method.
+func (m *MockReorgMetrics) TrackReorgedDeposit(source, destination uint8) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackReorgedDeposit", source, destination)
+}
+
+// TrackReorgedDeposit indicates an expected call of TrackReorgedDeposit.
+func (mr *MockReorgMetricsMockRecorder) TrackReorgedDeposit(source, destination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackReorgedDeposit", reflect.TypeOf((*MockReorgMetrics)(nil).TrackReorgedDeposit), source, destination)
+}
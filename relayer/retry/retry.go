@@ -5,6 +5,7 @@ package retry
 
 import (
 	"math/big"
+	"strings"
 
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ChainSafe/sygma-relayer/store"
@@ -14,6 +15,8 @@ import (
 
 const (
 	RetryMessageType message.MessageType = "RetryMessage"
+	// RetryMessageIDPrefix prefixes IDs of all messages created by an explicit retry
+	RetryMessageIDPrefix = "retry-"
 )
 
 type RetryMessageData struct {
@@ -21,6 +24,8 @@ type RetryMessageData struct {
 	DestinationDomainID uint8
 	BlockHeight         *big.Int
 	ResourceID          [32]byte
+	// TxHash retries deposits of the transaction instead of the block and resource
+	TxHash string
 }
 
 type PropStorer interface {
@@ -47,20 +52,43 @@ func FilterDeposits(
 				continue
 			}
 
-			isExecuted, err := isExecuted(deposit, propStorer)
-			if err != nil {
-				log.Err(err).Str("messageID", deposit.ID).Msgf("Failed checking if deposit executed %+v", deposit)
-				continue
-			}
-			if isExecuted {
-				log.Debug().Str("messageID", deposit.ID).Msgf("Deposit marked as executed %+v", deposit)
-				continue
-			}
-
 			filteredDeposits = append(filteredDeposits, deposit)
 		}
 	}
-	return filteredDeposits, nil
+	return FilterExecuted(propStorer, filteredDeposits), nil
+}
+
+// FilterExecuted removes deposits that are marked as executed. Deposits stuck
+// as pending are marked as failed so they can be retried.
+func FilterExecuted(propStorer PropStorer, deposits []*message.Message) []*message.Message {
+	filteredDeposits := make([]*message.Message, 0)
+	for _, deposit := range deposits {
+		isExecuted, err := isExecuted(deposit, propStorer)
+		if err != nil {
+			log.Err(err).Str("messageID", deposit.ID).Msgf("Failed checking if deposit executed %+v", deposit)
+			continue
+		}
+		if isExecuted {
+			log.Debug().Str("messageID", deposit.ID).Msgf("Deposit marked as executed %+v", deposit)
+			continue
+		}
+
+		filteredDeposits = append(filteredDeposits, deposit)
+	}
+	return filteredDeposits
+}
+
+// IsRetry returns true if the message was created by an explicit retry
+func IsRetry(msg *message.Message) bool {
+	return strings.HasPrefix(msg.ID, RetryMessageIDPrefix)
+}
+
+// MarkRetried sets the retry message ID to the deposits so they
+// can be recognized as explicitly retried.
+func MarkRetried(deposits []*message.Message, retryMessageID string) {
+	for _, deposit := range deposits {
+		deposit.ID = retryMessageID
+	}
 }
 
 func isExecuted(msg *message.Message, propStorer PropStorer) (bool, error) {
@@ -0,0 +1,226 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package screening
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+const AuditComponent = "screening"
+
+// Format is the address format of a chain type
+type Format string
+
+const (
+	EVMFormat       Format = "evm"
+	SubstrateFormat Format = "substrate"
+	BTCFormat       Format = "btc"
+)
+
+type QuarantineStorer interface {
+	StorePropQuarantine(source, destination uint8, depositNonce uint64, reason string) error
+}
+
+type Auditor interface {
+	Record(component string, action string, details map[string]interface{})
+}
+
+// Screener quarantines transfers made by or sent to screened addresses.
+// The list of screened addresses is read from a local JSON file, keyed by
+// address format, and reloaded when the file changes.
+type Screener struct {
+	listFile       string
+	reloadInterval time.Duration
+	propStorer     QuarantineStorer
+	auditor        Auditor
+
+	addresses map[Format]map[string]bool
+	modTime   time.Time
+	lock      sync.RWMutex
+}
+
+// NewScreener loads the screening list if it is configured. Screeners
+// without a list don't flag any address.
+func NewScreener(config relayer.ScreeningConfig, propStorer QuarantineStorer, auditor Auditor) (*Screener, error) {
+	s := &Screener{
+		listFile:       config.ListFile,
+		reloadInterval: config.ReloadInterval,
+		propStorer:     propStorer,
+		auditor:        auditor,
+		addresses:      make(map[Format]map[string]bool),
+	}
+	if s.listFile == "" {
+		return s, nil
+	}
+
+	_, err := s.reload()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Start periodically reloads the screening list when the list file is modified.
+// Invalid lists are ignored and the previous list is kept.
+func (s *Screener) Start(ctx context.Context) {
+	if s.listFile == "" {
+		return
+	}
+
+	ticker := time.NewTicker(s.reloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			{
+				reloaded, err := s.reload()
+				if err != nil {
+					log.Err(err).Msgf("Failed reloading screening list %s", s.listFile)
+					continue
+				}
+				if reloaded {
+					log.Info().Msgf("Reloaded screening list %s", s.listFile)
+				}
+			}
+		case <-ctx.Done():
+			{
+				return
+			}
+		}
+	}
+}
+
+// Flagged returns true if the address is on the screening list
+func (s *Screener) Flagged(format Format, address string) bool {
+	key, err := normalize(format, address)
+	if err != nil {
+		return false
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.addresses[format][key]
+}
+
+// Screen quarantines the transfer message if the address is on the screening list.
+// Returns true if the transfer is quarantined and should not be relayed.
+func (s *Screener) Screen(m *message.Message, format Format, address string) bool {
+	if !s.Flagged(format, address) {
+		return false
+	}
+	data, ok := m.Data.(transfer.TransferMessageData)
+	if !ok {
+		return false
+	}
+
+	reason := fmt.Sprintf("%s address %s is screened", format, address)
+	log.Warn().Str("messageID", m.ID).Msgf("Quarantining deposit %d-%d-%d because %s", m.Source, m.Destination, data.DepositNonce, reason)
+	err := s.propStorer.StorePropQuarantine(m.Source, m.Destination, data.DepositNonce, reason)
+	if err != nil {
+		log.Err(err).Str("messageID", m.ID).Msgf("Failed storing quarantine of deposit %d-%d-%d", m.Source, m.Destination, data.DepositNonce)
+	}
+	s.auditor.Record(AuditComponent, "quarantine", map[string]interface{}{
+		"messageID":    m.ID,
+		"source":       m.Source,
+		"destination":  m.Destination,
+		"depositNonce": data.DepositNonce,
+		"resourceID":   fmt.Sprintf("%x", data.ResourceId),
+		"format":       string(format),
+		"address":      address,
+	})
+	return true
+}
+
+// reload reads the list file if it was modified since it was last read
+func (s *Screener) reload() (bool, error) {
+	info, err := os.Stat(s.listFile)
+	if err != nil {
+		return false, err
+	}
+	s.lock.RLock()
+	modTime := s.modTime
+	s.lock.RUnlock()
+	if info.ModTime().Equal(modTime) {
+		return false, nil
+	}
+
+	content, err := os.ReadFile(s.listFile)
+	if err != nil {
+		return false, err
+	}
+	list := make(map[Format][]string)
+	err = json.Unmarshal(content, &list)
+	if err != nil {
+		return false, fmt.Errorf("invalid screening list %s: %w", s.listFile, err)
+	}
+
+	addresses := make(map[Format]map[string]bool)
+	for format, formatAddresses := range list {
+		addresses[format] = make(map[string]bool)
+		for _, address := range formatAddresses {
+			key, err := normalize(format, address)
+			if err != nil {
+				return false, err
+			}
+			addresses[format][key] = true
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.addresses = addresses
+	s.modTime = info.ModTime()
+	return true, nil
+}
+
+// normalize converts the address into its canonical form so that different
+// representations of the same address match. Substrate addresses can be either
+// SS58 encoded or hex encoded account IDs.
+func normalize(format Format, address string) (string, error) {
+	address = strings.TrimSpace(address)
+	switch format {
+	case EVMFormat:
+		{
+			if !common.IsHexAddress(address) {
+				return "", fmt.Errorf("invalid evm address %s", address)
+			}
+			return strings.ToLower(common.HexToAddress(address).Hex()), nil
+		}
+	case SubstrateFormat:
+		{
+			accountID, err := hex.DecodeString(strings.TrimPrefix(address, "0x"))
+			if err == nil && len(accountID) == 32 {
+				return hex.EncodeToString(accountID), nil
+			}
+
+			_, accountID, err = subkey.SS58Decode(address)
+			if err != nil {
+				return "", fmt.Errorf("invalid substrate address %s: %w", address, err)
+			}
+			return hex.EncodeToString(accountID), nil
+		}
+	case BTCFormat:
+		{
+			if address == "" {
+				return "", fmt.Errorf("invalid btc address %s", address)
+			}
+			return address, nil
+		}
+	}
+	return "", fmt.Errorf("unknown address format %s", format)
+}
@@ -0,0 +1,130 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package screening_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/relayer/screening"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+type quarantine struct {
+	source       uint8
+	destination  uint8
+	depositNonce uint64
+	reason       string
+}
+
+type testPropStorer struct {
+	quarantines []quarantine
+}
+
+func (s *testPropStorer) StorePropQuarantine(source, destination uint8, depositNonce uint64, reason string) error {
+	s.quarantines = append(s.quarantines, quarantine{source, destination, depositNonce, reason})
+	return nil
+}
+
+type testAuditor struct {
+	actions []string
+}
+
+func (a *testAuditor) Record(component string, action string, details map[string]interface{}) {
+	a.actions = append(a.actions, component+":"+action)
+}
+
+type ScreenerTestSuite struct {
+	suite.Suite
+	listFile   string
+	propStorer *testPropStorer
+	auditor    *testAuditor
+}
+
+func TestRunScreenerTestSuite(t *testing.T) {
+	suite.Run(t, new(ScreenerTestSuite))
+}
+
+func (s *ScreenerTestSuite) SetupTest() {
+	s.listFile = filepath.Join(s.T().TempDir(), "screening.json")
+	s.propStorer = &testPropStorer{}
+	s.auditor = &testAuditor{}
+}
+
+func (s *ScreenerTestSuite) writeList(list string) {
+	err := os.WriteFile(s.listFile, []byte(list), 0600)
+	s.Nil(err)
+}
+
+func (s *ScreenerTestSuite) Test_NoListFile_NothingFlagged() {
+	screener, err := screening.NewScreener(relayer.ScreeningConfig{}, s.propStorer, s.auditor)
+	s.Nil(err)
+
+	s.False(screener.Flagged(screening.EVMFormat, "0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b"))
+}
+
+func (s *ScreenerTestSuite) Test_InvalidList() {
+	s.writeList(`{"evm": ["invalid"]}`)
+
+	_, err := screening.NewScreener(relayer.ScreeningConfig{ListFile: s.listFile}, s.propStorer, s.auditor)
+
+	s.NotNil(err)
+}
+
+func (s *ScreenerTestSuite) Test_Flagged_NormalizesAddresses() {
+	s.writeList(`{
+		"evm": ["0x5c1f5961696bad2e73f73417f07ef55c62a2dc5b"],
+		"substrate": ["5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"],
+		"btc": ["tb1qln69zuhdunc9stwfh6t7adexxrcr04ppy6thgm"]
+	}`)
+	screener, err := screening.NewScreener(relayer.ScreeningConfig{ListFile: s.listFile}, s.propStorer, s.auditor)
+	s.Nil(err)
+
+	s.True(screener.Flagged(screening.EVMFormat, "0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b"))
+	s.False(screener.Flagged(screening.EVMFormat, "0x0000000000000000000000000000000000000001"))
+	s.True(screener.Flagged(screening.SubstrateFormat, "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"))
+	s.False(screener.Flagged(screening.SubstrateFormat, "0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48"))
+	s.True(screener.Flagged(screening.BTCFormat, "tb1qln69zuhdunc9stwfh6t7adexxrcr04ppy6thgm"))
+	s.False(screener.Flagged(screening.EVMFormat, "tb1qln69zuhdunc9stwfh6t7adexxrcr04ppy6thgm"))
+}
+
+func (s *ScreenerTestSuite) Test_Screen_QuarantinesFlaggedTransfer() {
+	s.writeList(`{"evm": ["0x5c1f5961696bad2e73f73417f07ef55c62a2dc5b"]}`)
+	screener, err := screening.NewScreener(relayer.ScreeningConfig{ListFile: s.listFile}, s.propStorer, s.auditor)
+	s.Nil(err)
+	m := &message.Message{
+		Source:      1,
+		Destination: 2,
+		Data:        transfer.TransferMessageData{DepositNonce: 3},
+	}
+
+	s.False(screener.Screen(m, screening.EVMFormat, "0x0000000000000000000000000000000000000001"))
+	s.True(screener.Screen(m, screening.EVMFormat, "0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b"))
+
+	s.Equal([]quarantine{{1, 2, 3, "evm address 0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b is screened"}}, s.propStorer.quarantines)
+	s.Equal([]string{"screening:quarantine"}, s.auditor.actions)
+}
+
+func (s *ScreenerTestSuite) Test_Start_ReloadsModifiedList() {
+	s.writeList(`{"evm": []}`)
+	screener, err := screening.NewScreener(relayer.ScreeningConfig{ListFile: s.listFile, ReloadInterval: 10 * time.Millisecond}, s.propStorer, s.auditor)
+	s.Nil(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go screener.Start(ctx)
+
+	s.writeList(`{"evm": ["0x5c1f5961696bad2e73f73417f07ef55c62a2dc5b"]}`)
+	err = os.Chtimes(s.listFile, time.Now(), time.Now().Add(time.Minute))
+	s.Nil(err)
+
+	s.Eventually(func() bool {
+		return screener.Flagged(screening.EVMFormat, "0x5c1f5961696bad2e73f73417f07ef55c62a2dc5b")
+	}, time.Second, 10*time.Millisecond)
+}
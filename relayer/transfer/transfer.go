@@ -24,6 +24,8 @@ type TransferMessageData struct {
 	Metadata     map[string]interface{}
 	Payload      []interface{}
 	Type         TransferType
+	// DepositBlock is the source domain block the deposit was found in
+	DepositBlock uint64
 }
 
 const (
@@ -44,6 +46,41 @@ type TransferProposalData struct {
 	ResourceId   [32]byte
 	Metadata     map[string]interface{}
 	Data         []byte
+	// Type is the transfer type of the deposit the proposal was created from
+	Type TransferType
+}
+
+// SetDepositBlock records the source domain block the deposit was found in.
+// Messages that are not transfer messages are left unchanged.
+func SetDepositBlock(m *message.Message, block uint64) {
+	data, ok := m.Data.(TransferMessageData)
+	if !ok {
+		return
+	}
+
+	data.DepositBlock = block
+	m.Data = data
+}
+
+// DepositorMetadataKey is the metadata key of the address that made the deposit
+// on the source domain
+const DepositorMetadataKey = "depositor"
+
+// SetDepositor records the address that made the deposit on the source domain
+// in the message metadata. Messages that are not transfer messages are left unchanged.
+func SetDepositor(m *message.Message, depositor string) {
+	data, ok := m.Data.(TransferMessageData)
+	if !ok {
+		return
+	}
+
+	metadata := make(map[string]interface{})
+	for k, v := range data.Metadata {
+		metadata[k] = v
+	}
+	metadata[DepositorMetadataKey] = depositor
+	data.Metadata = metadata
+	m.Data = data
 }
 
 type TransferProposal struct {
@@ -0,0 +1,93 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+var (
+	BLOCK_HASH_PREFIX = "blockhash:%d:"
+	// block numbers are zero padded so keys are ordered by block
+	BLOCK_HASH_KEY = BLOCK_HASH_PREFIX + "%020d"
+)
+
+type BlockHashDB interface {
+	SetByKey(key []byte, value []byte) error
+	WriteBatch(batch *leveldb.Batch) error
+	IterateByPrefix(prefix []byte, fn func(key []byte, value []byte) error) error
+}
+
+// BlockHash is the hash of a block processed by the listener of the domain
+type BlockHash struct {
+	Block uint64
+	Hash  string
+}
+
+// BlockHashStore stores hashes of processed blocks per domain so
+// reorgs of already processed blocks can be detected
+type BlockHashStore struct {
+	db BlockHashDB
+}
+
+func NewBlockHashStore(db BlockHashDB) *BlockHashStore {
+	return &BlockHashStore{
+		db: db,
+	}
+}
+
+// StoreBlockHash stores the hash of the processed block
+func (s *BlockHashStore) StoreBlockHash(domainID uint8, block uint64, hash string) error {
+	return s.db.SetByKey([]byte(fmt.Sprintf(BLOCK_HASH_KEY, domainID, block)), []byte(hash))
+}
+
+// BlockHashes returns stored block hashes of the domain ordered from the latest block
+func (s *BlockHashStore) BlockHashes(domainID uint8) ([]BlockHash, error) {
+	hashes := make([]BlockHash, 0)
+	prefix := fmt.Sprintf(BLOCK_HASH_PREFIX, domainID)
+	err := s.db.IterateByPrefix([]byte(prefix), func(key []byte, value []byte) error {
+		var block uint64
+		_, err := fmt.Sscanf(strings.TrimPrefix(string(key), prefix), "%d", &block)
+		if err != nil {
+			return fmt.Errorf("invalid block hash key %s: %w", string(key), err)
+		}
+
+		hashes = append(hashes, BlockHash{
+			Block: block,
+			Hash:  string(value),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i].Block > hashes[j].Block })
+	return hashes, nil
+}
+
+// DeleteBlockHashes deletes hashes of blocks of the domain that are outside of the provided range.
+// Blocks above the range are deleted after a reorg and blocks below it are pruned.
+func (s *BlockHashStore) DeleteBlockHashes(domainID uint8, from uint64, to uint64) error {
+	hashes, err := s.BlockHashes(domainID)
+	if err != nil {
+		return err
+	}
+
+	batch := new(leveldb.Batch)
+	for _, hash := range hashes {
+		if hash.Block >= from && hash.Block <= to {
+			continue
+		}
+		batch.Delete([]byte(fmt.Sprintf(BLOCK_HASH_KEY, domainID, hash.Block)))
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+	return s.db.WriteBatch(batch)
+}
@@ -0,0 +1,69 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store_test
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/ChainSafe/sygma-relayer/store/lvldb"
+	"github.com/stretchr/testify/suite"
+)
+
+type BlockHashStoreTestSuite struct {
+	suite.Suite
+	db             *lvldb.LVLDB
+	blockHashStore *store.BlockHashStore
+}
+
+func TestRunBlockHashStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(BlockHashStoreTestSuite))
+}
+
+func (s *BlockHashStoreTestSuite) SetupTest() {
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	s.db = db
+	s.blockHashStore = store.NewBlockHashStore(db)
+}
+
+func (s *BlockHashStoreTestSuite) TearDownTest() {
+	_ = s.db.Close()
+}
+
+func (s *BlockHashStoreTestSuite) Test_BlockHashes_OrderedFromLatest() {
+	s.Nil(s.blockHashStore.StoreBlockHash(1, 9, "0x09"))
+	s.Nil(s.blockHashStore.StoreBlockHash(1, 100, "0x100"))
+	s.Nil(s.blockHashStore.StoreBlockHash(1, 20, "0x20"))
+	s.Nil(s.blockHashStore.StoreBlockHash(2, 50, "0x50"))
+
+	hashes, err := s.blockHashStore.BlockHashes(1)
+
+	s.Nil(err)
+	s.Equal(hashes, []store.BlockHash{
+		{Block: 100, Hash: "0x100"},
+		{Block: 20, Hash: "0x20"},
+		{Block: 9, Hash: "0x09"},
+	})
+}
+
+func (s *BlockHashStoreTestSuite) Test_DeleteBlockHashes() {
+	for _, block := range []uint64{5, 10, 15, 20} {
+		s.Nil(s.blockHashStore.StoreBlockHash(1, block, "hash"))
+	}
+	s.Nil(s.blockHashStore.StoreBlockHash(2, 20, "hash"))
+
+	err := s.blockHashStore.DeleteBlockHashes(1, 10, 15)
+
+	s.Nil(err)
+	hashes, err := s.blockHashStore.BlockHashes(1)
+	s.Nil(err)
+	s.Equal(hashes, []store.BlockHash{
+		{Block: 15, Hash: "hash"},
+		{Block: 10, Hash: "hash"},
+	})
+	hashes, err = s.blockHashStore.BlockHashes(2)
+	s.Nil(err)
+	s.Equal(len(hashes), 1)
+}
@@ -0,0 +1,54 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+var (
+	// BLOCK_KEY is the key under which the sygma-core BlockStore stores the latest block of a domain
+	BLOCK_KEY    = "chain:%d:block"
+	BLOCK_PREFIX = "chain:"
+)
+
+type BlockIterator interface {
+	IterateByPrefix(prefix []byte, fn func(key []byte, value []byte) error) error
+}
+
+// StoredBlock is the latest block processed by the listener of the domain
+type StoredBlock struct {
+	DomainID uint8    `json:"domainID"`
+	Block    *big.Int `json:"block"`
+}
+
+// StoredBlocks returns latest blocks of all domains in the blockstore ordered by domain ID
+func StoredBlocks(db BlockIterator) ([]StoredBlock, error) {
+	blocks := make([]StoredBlock, 0)
+	err := db.IterateByPrefix([]byte(BLOCK_PREFIX), func(key []byte, value []byte) error {
+		if !strings.HasSuffix(string(key), ":block") {
+			return nil
+		}
+
+		var domainID uint8
+		_, err := fmt.Sscanf(string(key), BLOCK_KEY, &domainID)
+		if err != nil {
+			return fmt.Errorf("invalid block key %s: %w", string(key), err)
+		}
+		blocks = append(blocks, StoredBlock{
+			DomainID: domainID,
+			Block:    new(big.Int).SetBytes(value),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].DomainID < blocks[j].DomainID })
+	return blocks, nil
+}
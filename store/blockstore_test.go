@@ -0,0 +1,56 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/ChainSafe/sygma-relayer/store/lvldb"
+	"github.com/stretchr/testify/suite"
+	coreStore "github.com/sygmaprotocol/sygma-core/store"
+)
+
+type StoredBlocksTestSuite struct {
+	suite.Suite
+	db         *lvldb.LVLDB
+	blockStore *coreStore.BlockStore
+}
+
+func TestRunStoredBlocksTestSuite(t *testing.T) {
+	suite.Run(t, new(StoredBlocksTestSuite))
+}
+
+func (s *StoredBlocksTestSuite) SetupTest() {
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	s.db = db
+	s.blockStore = coreStore.NewBlockStore(db)
+}
+
+func (s *StoredBlocksTestSuite) TearDownTest() {
+	_ = s.db.Close()
+}
+
+func (s *StoredBlocksTestSuite) Test_StoredBlocks_Empty() {
+	blocks, err := store.StoredBlocks(s.db)
+
+	s.Nil(err)
+	s.Equal(blocks, []store.StoredBlock{})
+}
+
+func (s *StoredBlocksTestSuite) Test_StoredBlocks_OrderedByDomain() {
+	s.Nil(s.blockStore.StoreBlock(big.NewInt(200), 12))
+	s.Nil(s.blockStore.StoreBlock(big.NewInt(100), 2))
+	s.Nil(s.db.SetByKey([]byte("chain:5:nonce"), []byte{1}))
+
+	blocks, err := store.StoredBlocks(s.db)
+
+	s.Nil(err)
+	s.Equal(blocks, []store.StoredBlock{
+		{DomainID: 2, Block: big.NewInt(100)},
+		{DomainID: 12, Block: big.NewInt(200)},
+	})
+}
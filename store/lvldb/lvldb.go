@@ -0,0 +1,64 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package lvldb
+
+import (
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// LVLDB extends the sygma-core level db key value store with
+// batched writes, deletes and prefix iteration
+type LVLDB struct {
+	db *leveldb.DB
+}
+
+func NewLvlDB(path string) (*LVLDB, error) {
+	ldb, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("levelDB.OpenFile fail: %w", err)
+	}
+	return &LVLDB{db: ldb}, nil
+}
+
+func (db *LVLDB) GetByKey(key []byte) ([]byte, error) {
+	return db.db.Get(key, nil)
+}
+
+func (db *LVLDB) SetByKey(key []byte, value []byte) error {
+	return db.db.Put(key, value, nil)
+}
+
+func (db *LVLDB) DeleteByKey(key []byte) error {
+	return db.db.Delete(key, nil)
+}
+
+// WriteBatch atomically applies all operations from the batch
+func (db *LVLDB) WriteBatch(batch *leveldb.Batch) error {
+	return db.db.Write(batch, nil)
+}
+
+// IterateByPrefix calls fn for every key with the provided prefix in
+// ascending key order. Iteration stops on the first error returned by fn.
+func (db *LVLDB) IterateByPrefix(prefix []byte, fn func(key []byte, value []byte) error) error {
+	iter := db.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		// iterator reuses underlying slices so they have to be copied
+		key := append([]byte{}, iter.Key()...)
+		value := append([]byte{}, iter.Value()...)
+		err := fn(key, value)
+		if err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+func (db *LVLDB) Close() error {
+	return db.db.Close()
+}
@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./store/propstore.go
+
+// Package mock_store is a generated GoMock package.
+package mock_store
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	leveldb "github.com/syndtr/goleveldb/leveldb"
+)
+
+// MockPropDB is a mock of PropDB interface.
+type MockPropDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockPropDBMockRecorder
+}
+
+// MockPropDBMockRecorder is the mock recorder for MockPropDB.
+type MockPropDBMockRecorder struct {
+	mock *MockPropDB
+}
+
+// NewMockPropDB creates a new mock instance.
+func NewMockPropDB(ctrl *gomock.Controller) *MockPropDB {
+	mock := &MockPropDB{ctrl: ctrl}
+	mock.recorder = &MockPropDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPropDB) EXPECT() *MockPropDBMockRecorder {
+	return m.recorder
+}
+
+// GetByKey mocks base method.
+func (m *MockPropDB) GetByKey(key []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByKey", key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByKey indicates an expected call of GetByKey.
+func (mr *MockPropDBMockRecorder) GetByKey(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByKey", reflect.TypeOf((*MockPropDB)(nil).GetByKey), key)
+}
+
+// IterateByPrefix mocks base method.
+func (m *MockPropDB) IterateByPrefix(prefix []byte, fn func([]byte, []byte) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateByPrefix", prefix, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IterateByPrefix indicates an expected call of IterateByPrefix.
+func (mr *MockPropDBMockRecorder) IterateByPrefix(prefix, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateByPrefix", reflect.TypeOf((*MockPropDB)(nil).IterateByPrefix), prefix, fn)
+}
+
+// SetByKey mocks base method.
+func (m *MockPropDB) SetByKey(key, value []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetByKey", key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetByKey indicates an expected call of SetByKey.
+func (mr *MockPropDBMockRecorder) SetByKey(key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetByKey", reflect.TypeOf((*MockPropDB)(nil).SetByKey), key, value)
+}
+
+// WriteBatch mocks base method.
+func (m *MockPropDB) WriteBatch(batch *leveldb.Batch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteBatch", batch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteBatch indicates an expected call of WriteBatch.
+func (mr *MockPropDBMockRecorder) WriteBatch(batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteBatch", reflect.TypeOf((*MockPropDB)(nil).WriteBatch), batch)
+}
@@ -5,28 +5,76 @@ package store
 
 import (
 	"bytes"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
-	"github.com/sygmaprotocol/sygma-core/store"
 	"github.com/syndtr/goleveldb/leveldb"
 )
 
 type PropStatus string
 
 var (
-	KEY                     = "source:%d:destination:%d:depositNonce:%d"
-	MissingProp  PropStatus = "missing"
-	PendingProp  PropStatus = "pending"
-	FailedProp   PropStatus = "failed"
-	ExecutedProp PropStatus = "executed"
+	KEY                         = "source:%d:destination:%d:depositNonce:%d"
+	PROP_PREFIX                 = "source:"
+	ROUTE_PREFIX                = "source:%d:destination:%d:depositNonce:"
+	STATUS_PREFIX               = "propstatus:%s:"
+	STATUS_KEY                  = STATUS_PREFIX + KEY
+	VERSION_KEY                 = "propstore:version"
+	PropStoreVersion            = "1"
+	MissingProp      PropStatus = "missing"
+	PendingProp      PropStatus = "pending"
+	FailedProp       PropStatus = "failed"
+	ExecutedProp     PropStatus = "executed"
+	QuarantinedProp  PropStatus = "quarantined"
+	ReorgedProp      PropStatus = "reorged"
 )
 
+// PropRecord tracks the lifecycle of a single proposal
+type PropRecord struct {
+	Status      PropStatus `json:"status"`
+	MessageID   string     `json:"messageID,omitempty"`
+	FirstSeen   time.Time  `json:"firstSeen"`
+	LastUpdated time.Time  `json:"lastUpdated"`
+	ExecutedAt  time.Time  `json:"executedAt"`
+	Attempts    uint64     `json:"attempts"`
+	SessionIDs  []string   `json:"sessionIDs,omitempty"`
+	TxHash      string     `json:"txHash,omitempty"`
+	LastError   string     `json:"lastError,omitempty"`
+	// ResourceID and DepositBlock locate the deposit on the source domain
+	ResourceID   string    `json:"resourceID,omitempty"`
+	DepositBlock uint64    `json:"depositBlock,omitempty"`
+	Sweeps       uint64    `json:"sweeps,omitempty"`
+	LastSwept    time.Time `json:"lastSwept"`
+}
+
+// PropKey identifies a proposal
+type PropKey struct {
+	Source       uint8
+	Destination  uint8
+	DepositNonce uint64
+}
+
+type PropDB interface {
+	GetByKey(key []byte) ([]byte, error)
+	SetByKey(key []byte, value []byte) error
+	WriteBatch(batch *leveldb.Batch) error
+	IterateByPrefix(prefix []byte, fn func(key []byte, value []byte) error) error
+}
+
+// PropStore stores proposal records keyed by source, destination and deposit nonce.
+// Records are additionally indexed by status while the primary key doubles
+// as the index by source and destination.
 type PropStore struct {
-	db store.KeyValueReaderWriter
+	db   PropDB
+	lock sync.Mutex
 }
 
-func NewPropStore(db store.KeyValueReaderWriter) *PropStore {
+func NewPropStore(db PropDB) *PropStore {
 	return &PropStore{
 		db: db,
 	}
@@ -34,32 +82,277 @@ func NewPropStore(db store.KeyValueReaderWriter) *PropStore {
 
 // StorePropStatus stores proposal status per proposal
 func (ns *PropStore) StorePropStatus(source, destination uint8, depositNonce uint64, status PropStatus) error {
-	key := bytes.Buffer{}
-	keyS := fmt.Sprintf(KEY, source, destination, depositNonce)
-	key.WriteString(keyS)
+	return ns.updatePropRecord(source, destination, depositNonce, func(record *PropRecord) {
+		record.Status = status
+	})
+}
 
-	err := ns.db.SetByKey(key.Bytes(), []byte(status))
+// StorePropDeposit records where the deposit of the proposal can be found on the source domain
+func (ns *PropStore) StorePropDeposit(source, destination uint8, depositNonce uint64, resourceID [32]byte, depositBlock uint64) error {
+	return ns.updatePropRecord(source, destination, depositNonce, func(record *PropRecord) {
+		record.ResourceID = hex.EncodeToString(resourceID[:])
+		if depositBlock != 0 {
+			record.DepositBlock = depositBlock
+		}
+	})
+}
+
+// StorePropAttempt marks the proposal as pending and records a new
+// execution attempt with the signing session ID
+func (ns *PropStore) StorePropAttempt(source, destination uint8, depositNonce uint64, messageID string, sessionID string) error {
+	return ns.updatePropRecord(source, destination, depositNonce, func(record *PropRecord) {
+		record.Status = PendingProp
+		record.MessageID = messageID
+		record.Attempts++
+		record.SessionIDs = append(record.SessionIDs, sessionID)
+	})
+}
+
+// StorePropExecution marks the proposal as executed. Transaction hash
+// is not overwritten if empty as only the relayer that sent the transaction knows it.
+func (ns *PropStore) StorePropExecution(source, destination uint8, depositNonce uint64, txHash string) error {
+	return ns.updatePropRecord(source, destination, depositNonce, func(record *PropRecord) {
+		record.Status = ExecutedProp
+		record.ExecutedAt = time.Now()
+		if txHash != "" {
+			record.TxHash = txHash
+		}
+	})
+}
+
+// StorePropFailure marks the proposal as failed and records the reason
+func (ns *PropStore) StorePropFailure(source, destination uint8, depositNonce uint64, failure error) error {
+	return ns.updatePropRecord(source, destination, depositNonce, func(record *PropRecord) {
+		record.Status = FailedProp
+		record.LastError = failure.Error()
+	})
+}
+
+// StorePropQuarantine marks the proposal as quarantined by address screening and records the reason
+func (ns *PropStore) StorePropQuarantine(source, destination uint8, depositNonce uint64, reason string) error {
+	return ns.updatePropRecord(source, destination, depositNonce, func(record *PropRecord) {
+		record.Status = QuarantinedProp
+		record.LastError = reason
+	})
+}
+
+// StorePropReorg marks the proposal as reorged because its deposit vanished from the source domain
+func (ns *PropStore) StorePropReorg(source, destination uint8, depositNonce uint64, reason string) error {
+	return ns.updatePropRecord(source, destination, depositNonce, func(record *PropRecord) {
+		record.Status = ReorgedProp
+		record.LastError = reason
+	})
+}
+
+// StorePropSweep records that the stuck proposal was resubmitted for execution
+func (ns *PropStore) StorePropSweep(source, destination uint8, depositNonce uint64) error {
+	return ns.updatePropRecord(source, destination, depositNonce, func(record *PropRecord) {
+		record.Sweeps++
+		record.LastSwept = time.Now()
+	})
+}
+
+// GetPropStatus
+func (ns *PropStore) PropStatus(source, destination uint8, depositNonce uint64) (PropStatus, error) {
+	record, err := ns.PropRecord(source, destination, depositNonce)
+	if err != nil {
+		return MissingProp, err
+	}
+
+	return record.Status, nil
+}
+
+// PropRecord returns the full proposal record. Proposals that
+// were never stored are returned with the missing status.
+func (ns *PropStore) PropRecord(source, destination uint8, depositNonce uint64) (*PropRecord, error) {
+	v, err := ns.db.GetByKey(propKey(source, destination, depositNonce))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return &PropRecord{Status: MissingProp}, nil
+		}
+		return &PropRecord{Status: MissingProp}, err
+	}
+
+	return decodePropRecord(v)
+}
+
+// IterateProps calls fn for every stored proposal
+func (ns *PropStore) IterateProps(fn func(key PropKey, record *PropRecord) error) error {
+	return ns.iterateRecords([]byte(PROP_PREFIX), fn)
+}
+
+// PropsByRoute calls fn for every proposal from source to destination domain
+func (ns *PropStore) PropsByRoute(source, destination uint8, fn func(key PropKey, record *PropRecord) error) error {
+	return ns.iterateRecords([]byte(fmt.Sprintf(ROUTE_PREFIX, source, destination)), fn)
+}
+
+// PropsByStatus calls fn for every proposal with the provided status
+func (ns *PropStore) PropsByStatus(status PropStatus, fn func(key PropKey, record *PropRecord) error) error {
+	keys := make([]PropKey, 0)
+	prefix := fmt.Sprintf(STATUS_PREFIX, status)
+	err := ns.db.IterateByPrefix([]byte(prefix), func(k []byte, _ []byte) error {
+		var key PropKey
+		_, err := fmt.Sscanf(strings.TrimPrefix(string(k), prefix), KEY, &key.Source, &key.Destination, &key.DepositNonce)
+		if err != nil {
+			return fmt.Errorf("invalid status index key %s: %w", string(k), err)
+		}
+		keys = append(keys, key)
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
+	for _, key := range keys {
+		record, err := ns.PropRecord(key.Source, key.Destination, key.DepositNonce)
+		if err != nil {
+			return err
+		}
+		err = fn(key, record)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// GetPropStatus
-func (ns *PropStore) PropStatus(source, destination uint8, depositNonce uint64) (PropStatus, error) {
-	key := bytes.Buffer{}
-	keyS := fmt.Sprintf(KEY, source, destination, depositNonce)
-	key.WriteString(keyS)
+// PruneExecutedProps deletes executed proposal records that were
+// executed before the provided time and returns the number of deleted records
+func (ns *PropStore) PruneExecutedProps(before time.Time) (int, error) {
+	ns.lock.Lock()
+	defer ns.lock.Unlock()
+
+	batch := new(leveldb.Batch)
+	err := ns.PropsByStatus(ExecutedProp, func(key PropKey, record *PropRecord) error {
+		executedAt := record.ExecutedAt
+		if executedAt.IsZero() {
+			executedAt = record.LastUpdated
+		}
+		if !executedAt.Before(before) {
+			return nil
+		}
 
-	v, err := ns.db.GetByKey(key.Bytes())
+		batch.Delete(propKey(key.Source, key.Destination, key.DepositNonce))
+		batch.Delete(statusKey(ExecutedProp, key.Source, key.Destination, key.DepositNonce))
+		return nil
+	})
 	if err != nil {
-		if errors.Is(err, leveldb.ErrNotFound) {
-			return MissingProp, nil
+		return 0, err
+	}
+	if batch.Len() == 0 {
+		return 0, nil
+	}
+
+	return batch.Len() / 2, ns.db.WriteBatch(batch)
+}
+
+// Migrate converts proposals stored as plain status strings into records and
+// builds the status index. It runs only once per database.
+func (ns *PropStore) Migrate() error {
+	ns.lock.Lock()
+	defer ns.lock.Unlock()
+
+	version, err := ns.db.GetByKey([]byte(VERSION_KEY))
+	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+		return err
+	}
+	if string(version) == PropStoreVersion {
+		return nil
+	}
+
+	batch := new(leveldb.Batch)
+	err = ns.IterateProps(func(key PropKey, record *PropRecord) error {
+		value, err := json.Marshal(record)
+		if err != nil {
+			return err
 		}
-		return MissingProp, err
+
+		batch.Put(propKey(key.Source, key.Destination, key.DepositNonce), value)
+		if record.Status != MissingProp {
+			batch.Put(statusKey(record.Status, key.Source, key.Destination, key.DepositNonce), []byte{})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	status := PropStatus(string(v))
-	return status, nil
+	batch.Put([]byte(VERSION_KEY), []byte(PropStoreVersion))
+	return ns.db.WriteBatch(batch)
+}
+
+func (ns *PropStore) iterateRecords(prefix []byte, fn func(key PropKey, record *PropRecord) error) error {
+	return ns.db.IterateByPrefix(prefix, func(k []byte, v []byte) error {
+		var key PropKey
+		_, err := fmt.Sscanf(string(k), KEY, &key.Source, &key.Destination, &key.DepositNonce)
+		if err != nil {
+			return fmt.Errorf("invalid proposal key %s: %w", string(k), err)
+		}
+
+		record, err := decodePropRecord(v)
+		if err != nil {
+			return err
+		}
+		return fn(key, record)
+	})
+}
+
+func (ns *PropStore) updatePropRecord(source, destination uint8, depositNonce uint64, update func(record *PropRecord)) error {
+	ns.lock.Lock()
+	defer ns.lock.Unlock()
+
+	record, err := ns.PropRecord(source, destination, depositNonce)
+	if err != nil {
+		return err
+	}
+	previousStatus := record.Status
+
+	now := time.Now()
+	if record.FirstSeen.IsZero() {
+		record.FirstSeen = now
+	}
+	record.LastUpdated = now
+	update(record)
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	batch := new(leveldb.Batch)
+	if previousStatus != record.Status {
+		batch.Delete(statusKey(previousStatus, source, destination, depositNonce))
+	}
+	// deposits recorded before the first execution attempt are not indexed
+	if record.Status != MissingProp {
+		batch.Put(statusKey(record.Status, source, destination, depositNonce), []byte{})
+	}
+	batch.Put(propKey(source, destination, depositNonce), value)
+	return ns.db.WriteBatch(batch)
+}
+
+// decodePropRecord decodes the stored proposal record. Proposals stored before records
+// were introduced contain only the status string and are migrated on the next update.
+func decodePropRecord(v []byte) (*PropRecord, error) {
+	if !bytes.HasPrefix(v, []byte("{")) {
+		return &PropRecord{Status: PropStatus(string(v))}, nil
+	}
+
+	record := &PropRecord{}
+	err := json.Unmarshal(v, record)
+	if err != nil {
+		return &PropRecord{Status: MissingProp}, err
+	}
+	return record, nil
+}
+
+func statusKey(status PropStatus, source, destination uint8, depositNonce uint64) []byte {
+	return []byte(fmt.Sprintf(STATUS_KEY, status, source, destination, depositNonce))
+}
+
+func propKey(source, destination uint8, depositNonce uint64) []byte {
+	key := bytes.Buffer{}
+	keyS := fmt.Sprintf(KEY, source, destination, depositNonce)
+	key.WriteString(keyS)
+	return key.Bytes()
 }
@@ -1,20 +1,24 @@
 package store_test
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/ChainSafe/sygma-relayer/store/lvldb"
+	mock_propstore "github.com/ChainSafe/sygma-relayer/store/mock"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
-	mock_store "github.com/sygmaprotocol/sygma-core/mock"
 	"github.com/syndtr/goleveldb/leveldb"
-	"go.uber.org/mock/gomock"
 )
 
 type PropStoreTestSuite struct {
 	suite.Suite
-	nonceStore           *store.PropStore
-	keyValueReaderWriter *mock_store.MockKeyValueReaderWriter
+	nonceStore *store.PropStore
+	propDB     *mock_propstore.MockPropDB
 }
 
 func TestRunPropStoreTestSuite(t *testing.T) {
@@ -23,13 +27,42 @@ func TestRunPropStoreTestSuite(t *testing.T) {
 
 func (s *PropStoreTestSuite) SetupTest() {
 	gomockController := gomock.NewController(s.T())
-	s.keyValueReaderWriter = mock_store.NewMockKeyValueReaderWriter(gomockController)
-	s.nonceStore = store.NewPropStore(s.keyValueReaderWriter)
+	s.propDB = mock_propstore.NewMockPropDB(gomockController)
+	s.nonceStore = store.NewPropStore(s.propDB)
+}
+
+type batchReplay struct {
+	puts    map[string][]byte
+	deletes []string
+}
+
+func (r *batchReplay) Put(key, value []byte) {
+	r.puts[string(key)] = value
+}
+
+func (r *batchReplay) Delete(key []byte) {
+	r.deletes = append(r.deletes, string(key))
+}
+
+func replayBatch(batch *leveldb.Batch) *batchReplay {
+	r := &batchReplay{puts: make(map[string][]byte)}
+	_ = batch.Replay(r)
+	return r
 }
 
 func (s *PropStoreTestSuite) Test_StorePropStatus_FailedStore() {
 	key := "source:1:destination:2:depositNonce:3"
-	s.keyValueReaderWriter.EXPECT().SetByKey([]byte(key), []byte(store.ExecutedProp)).Return(errors.New("error"))
+	s.propDB.EXPECT().GetByKey([]byte(key)).Return(nil, leveldb.ErrNotFound)
+	s.propDB.EXPECT().WriteBatch(gomock.Any()).Return(errors.New("error"))
+
+	err := s.nonceStore.StorePropStatus(1, 2, 3, store.ExecutedProp)
+
+	s.NotNil(err)
+}
+
+func (s *PropStoreTestSuite) Test_StorePropStatus_FailedFetch() {
+	key := "source:1:destination:2:depositNonce:3"
+	s.propDB.EXPECT().GetByKey([]byte(key)).Return(nil, errors.New("error"))
 
 	err := s.nonceStore.StorePropStatus(1, 2, 3, store.ExecutedProp)
 
@@ -38,16 +71,151 @@ func (s *PropStoreTestSuite) Test_StorePropStatus_FailedStore() {
 
 func (s *PropStoreTestSuite) TestStoreBlock_SuccessfulStore() {
 	key := "source:1:destination:2:depositNonce:3"
-	s.keyValueReaderWriter.EXPECT().SetByKey([]byte(key), []byte(store.ExecutedProp)).Return(nil)
+	s.propDB.EXPECT().GetByKey([]byte(key)).Return(nil, leveldb.ErrNotFound)
+	s.propDB.EXPECT().WriteBatch(gomock.Any()).DoAndReturn(func(batch *leveldb.Batch) error {
+		value := replayBatch(batch).puts[key]
+		record := &store.PropRecord{}
+		err := json.Unmarshal(value, record)
+		s.Nil(err)
+		s.Equal(record.Status, store.ExecutedProp)
+		s.False(record.FirstSeen.IsZero())
+		return nil
+	})
 
 	err := s.nonceStore.StorePropStatus(1, 2, 3, store.ExecutedProp)
 
 	s.Nil(err)
 }
 
+func (s *PropStoreTestSuite) Test_StorePropStatus_MigratesLegacyStatus() {
+	key := "source:1:destination:2:depositNonce:3"
+	s.propDB.EXPECT().GetByKey([]byte(key)).Return([]byte(store.PendingProp), nil)
+	s.propDB.EXPECT().WriteBatch(gomock.Any()).DoAndReturn(func(batch *leveldb.Batch) error {
+		value := replayBatch(batch).puts[key]
+		record := &store.PropRecord{}
+		err := json.Unmarshal(value, record)
+		s.Nil(err)
+		s.Equal(record.Status, store.FailedProp)
+		return nil
+	})
+
+	err := s.nonceStore.StorePropStatus(1, 2, 3, store.FailedProp)
+
+	s.Nil(err)
+}
+
+func (s *PropStoreTestSuite) Test_StorePropAttempt_IncrementsAttempts() {
+	key := "source:1:destination:2:depositNonce:3"
+	existing, _ := json.Marshal(store.PropRecord{
+		Status:     store.FailedProp,
+		Attempts:   1,
+		SessionIDs: []string{"session1"},
+		LastError:  "error",
+	})
+	s.propDB.EXPECT().GetByKey([]byte(key)).Return(existing, nil)
+	s.propDB.EXPECT().WriteBatch(gomock.Any()).DoAndReturn(func(batch *leveldb.Batch) error {
+		value := replayBatch(batch).puts[key]
+		record := &store.PropRecord{}
+		err := json.Unmarshal(value, record)
+		s.Nil(err)
+		s.Equal(record.Status, store.PendingProp)
+		s.Equal(record.Attempts, uint64(2))
+		s.Equal(record.SessionIDs, []string{"session1", "session2"})
+		s.Equal(record.MessageID, "messageID")
+		s.Equal(record.LastError, "error")
+		return nil
+	})
+
+	err := s.nonceStore.StorePropAttempt(1, 2, 3, "messageID", "session2")
+
+	s.Nil(err)
+}
+
+func (s *PropStoreTestSuite) Test_StorePropExecution_KeepsTxHashIfEmpty() {
+	key := "source:1:destination:2:depositNonce:3"
+	existing, _ := json.Marshal(store.PropRecord{
+		Status: store.PendingProp,
+		TxHash: "0x01",
+	})
+	s.propDB.EXPECT().GetByKey([]byte(key)).Return(existing, nil)
+	s.propDB.EXPECT().WriteBatch(gomock.Any()).DoAndReturn(func(batch *leveldb.Batch) error {
+		value := replayBatch(batch).puts[key]
+		record := &store.PropRecord{}
+		err := json.Unmarshal(value, record)
+		s.Nil(err)
+		s.Equal(record.Status, store.ExecutedProp)
+		s.Equal(record.TxHash, "0x01")
+		s.False(record.ExecutedAt.IsZero())
+		return nil
+	})
+
+	err := s.nonceStore.StorePropExecution(1, 2, 3, "")
+
+	s.Nil(err)
+}
+
+func (s *PropStoreTestSuite) Test_StorePropFailure_StoresError() {
+	key := "source:1:destination:2:depositNonce:3"
+	s.propDB.EXPECT().GetByKey([]byte(key)).Return(nil, leveldb.ErrNotFound)
+	s.propDB.EXPECT().WriteBatch(gomock.Any()).DoAndReturn(func(batch *leveldb.Batch) error {
+		value := replayBatch(batch).puts[key]
+		record := &store.PropRecord{}
+		err := json.Unmarshal(value, record)
+		s.Nil(err)
+		s.Equal(record.Status, store.FailedProp)
+		s.Equal(record.LastError, "execution reverted")
+		return nil
+	})
+
+	err := s.nonceStore.StorePropFailure(1, 2, 3, errors.New("execution reverted"))
+
+	s.Nil(err)
+}
+
+func (s *PropStoreTestSuite) Test_StorePropQuarantine_StoresReason() {
+	key := "source:1:destination:2:depositNonce:3"
+	s.propDB.EXPECT().GetByKey([]byte(key)).Return(nil, leveldb.ErrNotFound)
+	s.propDB.EXPECT().WriteBatch(gomock.Any()).DoAndReturn(func(batch *leveldb.Batch) error {
+		puts := replayBatch(batch).puts
+		value := puts[key]
+		record := &store.PropRecord{}
+		err := json.Unmarshal(value, record)
+		s.Nil(err)
+		s.Equal(record.Status, store.QuarantinedProp)
+		s.Equal(record.LastError, "depositor 0x01 is screened")
+		_, ok := puts["propstatus:quarantined:"+key]
+		s.True(ok)
+		return nil
+	})
+
+	err := s.nonceStore.StorePropQuarantine(1, 2, 3, "depositor 0x01 is screened")
+
+	s.Nil(err)
+}
+
+func (s *PropStoreTestSuite) Test_StorePropReorg_StoresReason() {
+	key := "source:1:destination:2:depositNonce:3"
+	s.propDB.EXPECT().GetByKey([]byte(key)).Return(nil, leveldb.ErrNotFound)
+	s.propDB.EXPECT().WriteBatch(gomock.Any()).DoAndReturn(func(batch *leveldb.Batch) error {
+		puts := replayBatch(batch).puts
+		record := &store.PropRecord{}
+		err := json.Unmarshal(puts[key], record)
+		s.Nil(err)
+		s.Equal(record.Status, store.ReorgedProp)
+		s.Equal(record.LastError, "deposit vanished in reorg of blocks 10-20")
+		_, ok := puts["propstatus:reorged:"+key]
+		s.True(ok)
+		return nil
+	})
+
+	err := s.nonceStore.StorePropReorg(1, 2, 3, "deposit vanished in reorg of blocks 10-20")
+
+	s.Nil(err)
+}
+
 func (s *PropStoreTestSuite) Test_GetPropStatus_FailedFetch() {
 	key := "source:1:destination:2:depositNonce:3"
-	s.keyValueReaderWriter.EXPECT().GetByKey([]byte(key)).Return(nil, errors.New("error"))
+	s.propDB.EXPECT().GetByKey([]byte(key)).Return(nil, errors.New("error"))
 
 	_, err := s.nonceStore.PropStatus(1, 2, 3)
 
@@ -56,7 +224,7 @@ func (s *PropStoreTestSuite) Test_GetPropStatus_FailedFetch() {
 
 func (s *PropStoreTestSuite) TestGetNonce_NonceNotFound() {
 	key := "source:1:destination:2:depositNonce:3"
-	s.keyValueReaderWriter.EXPECT().GetByKey([]byte(key)).Return(nil, leveldb.ErrNotFound)
+	s.propDB.EXPECT().GetByKey([]byte(key)).Return(nil, leveldb.ErrNotFound)
 
 	status, err := s.nonceStore.PropStatus(1, 2, 3)
 
@@ -66,10 +234,178 @@ func (s *PropStoreTestSuite) TestGetNonce_NonceNotFound() {
 
 func (s *PropStoreTestSuite) TestGetNonce_SuccessfulFetch() {
 	key := "source:1:destination:2:depositNonce:3"
-	s.keyValueReaderWriter.EXPECT().GetByKey([]byte(key)).Return([]byte(store.ExecutedProp), nil)
+	s.propDB.EXPECT().GetByKey([]byte(key)).Return([]byte(store.ExecutedProp), nil)
 
 	status, err := s.nonceStore.PropStatus(1, 2, 3)
 
 	s.Nil(err)
 	s.Equal(status, store.ExecutedProp)
 }
+
+func (s *PropStoreTestSuite) Test_PropRecord_SuccessfulFetch() {
+	key := "source:1:destination:2:depositNonce:3"
+	existing, _ := json.Marshal(store.PropRecord{
+		Status:    store.ExecutedProp,
+		MessageID: "messageID",
+		Attempts:  1,
+		TxHash:    "0x01",
+	})
+	s.propDB.EXPECT().GetByKey([]byte(key)).Return(existing, nil)
+
+	record, err := s.nonceStore.PropRecord(1, 2, 3)
+
+	s.Nil(err)
+	s.Equal(record.Status, store.ExecutedProp)
+	s.Equal(record.MessageID, "messageID")
+	s.Equal(record.Attempts, uint64(1))
+	s.Equal(record.TxHash, "0x01")
+}
+
+func (s *PropStoreTestSuite) Test_StorePropStatus_UpdatesStatusIndex() {
+	key := "source:1:destination:2:depositNonce:3"
+	existing, _ := json.Marshal(store.PropRecord{Status: store.PendingProp})
+	s.propDB.EXPECT().GetByKey([]byte(key)).Return(existing, nil)
+	s.propDB.EXPECT().WriteBatch(gomock.Any()).DoAndReturn(func(batch *leveldb.Batch) error {
+		replay := replayBatch(batch)
+		s.Equal(replay.deletes, []string{"propstatus:pending:" + key})
+		_, ok := replay.puts["propstatus:executed:"+key]
+		s.True(ok)
+		return nil
+	})
+
+	err := s.nonceStore.StorePropStatus(1, 2, 3, store.ExecutedProp)
+
+	s.Nil(err)
+}
+
+type PropStoreQueryTestSuite struct {
+	suite.Suite
+	db        *lvldb.LVLDB
+	propStore *store.PropStore
+}
+
+func TestRunPropStoreQueryTestSuite(t *testing.T) {
+	suite.Run(t, new(PropStoreQueryTestSuite))
+}
+
+func (s *PropStoreQueryTestSuite) SetupTest() {
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	s.db = db
+	s.propStore = store.NewPropStore(db)
+}
+
+func (s *PropStoreQueryTestSuite) TearDownTest() {
+	_ = s.db.Close()
+}
+
+func (s *PropStoreQueryTestSuite) Test_PropsByStatus() {
+	s.Nil(s.propStore.StorePropStatus(1, 2, 1, store.PendingProp))
+	s.Nil(s.propStore.StorePropStatus(1, 2, 2, store.PendingProp))
+	s.Nil(s.propStore.StorePropStatus(1, 3, 3, store.PendingProp))
+	s.Nil(s.propStore.StorePropStatus(1, 2, 2, store.ExecutedProp))
+
+	keys := make([]store.PropKey, 0)
+	err := s.propStore.PropsByStatus(store.PendingProp, func(key store.PropKey, record *store.PropRecord) error {
+		s.Equal(record.Status, store.PendingProp)
+		keys = append(keys, key)
+		return nil
+	})
+
+	s.Nil(err)
+	s.Equal(keys, []store.PropKey{
+		{Source: 1, Destination: 2, DepositNonce: 1},
+		{Source: 1, Destination: 3, DepositNonce: 3},
+	})
+}
+
+func (s *PropStoreQueryTestSuite) Test_StorePropDeposit_NotIndexedUntilAttempt() {
+	s.Nil(s.propStore.StorePropDeposit(1, 2, 1, [32]byte{1}, 100))
+
+	indexed := 0
+	err := s.propStore.PropsByStatus(store.MissingProp, func(key store.PropKey, record *store.PropRecord) error {
+		indexed++
+		return nil
+	})
+	s.Nil(err)
+	s.Equal(indexed, 0)
+
+	s.Nil(s.propStore.StorePropAttempt(1, 2, 1, "messageID", "session"))
+	record, err := s.propStore.PropRecord(1, 2, 1)
+	s.Nil(err)
+	s.Equal(record.Status, store.PendingProp)
+	s.Equal(record.DepositBlock, uint64(100))
+	s.Equal(record.ResourceID, "0100000000000000000000000000000000000000000000000000000000000000")
+}
+
+func (s *PropStoreQueryTestSuite) Test_StorePropSweep_IncrementsSweeps() {
+	s.Nil(s.propStore.StorePropStatus(1, 2, 1, store.FailedProp))
+	s.Nil(s.propStore.StorePropSweep(1, 2, 1))
+	s.Nil(s.propStore.StorePropSweep(1, 2, 1))
+
+	record, err := s.propStore.PropRecord(1, 2, 1)
+
+	s.Nil(err)
+	s.Equal(record.Status, store.FailedProp)
+	s.Equal(record.Sweeps, uint64(2))
+	s.False(record.LastSwept.IsZero())
+}
+
+func (s *PropStoreQueryTestSuite) Test_PropsByRoute() {
+	s.Nil(s.propStore.StorePropStatus(1, 2, 1, store.PendingProp))
+	s.Nil(s.propStore.StorePropStatus(1, 3, 2, store.PendingProp))
+	s.Nil(s.propStore.StorePropStatus(11, 2, 3, store.PendingProp))
+
+	keys := make([]store.PropKey, 0)
+	err := s.propStore.PropsByRoute(1, 2, func(key store.PropKey, record *store.PropRecord) error {
+		keys = append(keys, key)
+		return nil
+	})
+
+	s.Nil(err)
+	s.Equal(keys, []store.PropKey{{Source: 1, Destination: 2, DepositNonce: 1}})
+}
+
+func (s *PropStoreQueryTestSuite) Test_PruneExecutedProps() {
+	s.Nil(s.propStore.StorePropExecution(1, 2, 1, "0x01"))
+	s.Nil(s.propStore.StorePropStatus(1, 2, 2, store.PendingProp))
+
+	pruned, err := s.propStore.PruneExecutedProps(time.Now().Add(-time.Hour))
+	s.Nil(err)
+	s.Equal(pruned, 0)
+
+	pruned, err = s.propStore.PruneExecutedProps(time.Now().Add(time.Hour))
+	s.Nil(err)
+	s.Equal(pruned, 1)
+
+	status, err := s.propStore.PropStatus(1, 2, 1)
+	s.Nil(err)
+	s.Equal(status, store.MissingProp)
+	status, err = s.propStore.PropStatus(1, 2, 2)
+	s.Nil(err)
+	s.Equal(status, store.PendingProp)
+	err = s.propStore.PropsByStatus(store.ExecutedProp, func(key store.PropKey, record *store.PropRecord) error {
+		return fmt.Errorf("unexpected executed proposal %+v", key)
+	})
+	s.Nil(err)
+}
+
+func (s *PropStoreQueryTestSuite) Test_Migrate_LegacyStatuses() {
+	s.Nil(s.db.SetByKey([]byte("source:1:destination:2:depositNonce:1"), []byte(store.FailedProp)))
+
+	err := s.propStore.Migrate()
+	s.Nil(err)
+
+	keys := make([]store.PropKey, 0)
+	err = s.propStore.PropsByStatus(store.FailedProp, func(key store.PropKey, record *store.PropRecord) error {
+		keys = append(keys, key)
+		return nil
+	})
+	s.Nil(err)
+	s.Equal(keys, []store.PropKey{{Source: 1, Destination: 2, DepositNonce: 1}})
+	value, err := s.db.GetByKey([]byte("source:1:destination:2:depositNonce:1"))
+	s.Nil(err)
+	record := &store.PropRecord{}
+	s.Nil(json.Unmarshal(value, record))
+	s.Equal(record.Status, store.FailedProp)
+}
@@ -0,0 +1,50 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sygmaprotocol/sygma-core/store"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+var (
+	SEEN_KEY = "seen:source:%d:destination:%d:depositNonce:%d:resourceID:%x"
+	seenFlag = []byte{1}
+)
+
+// SeenStore persists deposits that were already relayed to the destination domain
+type SeenStore struct {
+	db store.KeyValueReaderWriter
+}
+
+func NewSeenStore(db store.KeyValueReaderWriter) *SeenStore {
+	return &SeenStore{
+		db: db,
+	}
+}
+
+// MarkSeen marks the deposit as relayed
+func (s *SeenStore) MarkSeen(source, destination uint8, depositNonce uint64, resourceID [32]byte) error {
+	return s.db.SetByKey(seenKey(source, destination, depositNonce, resourceID), seenFlag)
+}
+
+// IsSeen returns true if the deposit was already relayed
+func (s *SeenStore) IsSeen(source, destination uint8, depositNonce uint64, resourceID [32]byte) (bool, error) {
+	_, err := s.db.GetByKey(seenKey(source, destination, depositNonce, resourceID))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func seenKey(source, destination uint8, depositNonce uint64, resourceID [32]byte) []byte {
+	return []byte(fmt.Sprintf(SEEN_KEY, source, destination, depositNonce, resourceID))
+}
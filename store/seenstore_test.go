@@ -0,0 +1,75 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/stretchr/testify/suite"
+	mock_store "github.com/sygmaprotocol/sygma-core/mock"
+	"github.com/syndtr/goleveldb/leveldb"
+	"go.uber.org/mock/gomock"
+)
+
+var seenKey = "seen:source:1:destination:2:depositNonce:3:resourceID:0100000000000000000000000000000000000000000000000000000000000000"
+
+type SeenStoreTestSuite struct {
+	suite.Suite
+	seenStore            *store.SeenStore
+	keyValueReaderWriter *mock_store.MockKeyValueReaderWriter
+}
+
+func TestRunSeenStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(SeenStoreTestSuite))
+}
+
+func (s *SeenStoreTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.keyValueReaderWriter = mock_store.NewMockKeyValueReaderWriter(gomockController)
+	s.seenStore = store.NewSeenStore(s.keyValueReaderWriter)
+}
+
+func (s *SeenStoreTestSuite) Test_MarkSeen_FailedStore() {
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte(seenKey), []byte{1}).Return(errors.New("error"))
+
+	err := s.seenStore.MarkSeen(1, 2, 3, [32]byte{1})
+
+	s.NotNil(err)
+}
+
+func (s *SeenStoreTestSuite) Test_MarkSeen_SuccessfulStore() {
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte(seenKey), []byte{1}).Return(nil)
+
+	err := s.seenStore.MarkSeen(1, 2, 3, [32]byte{1})
+
+	s.Nil(err)
+}
+
+func (s *SeenStoreTestSuite) Test_IsSeen_FailedFetch() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte(seenKey)).Return(nil, errors.New("error"))
+
+	_, err := s.seenStore.IsSeen(1, 2, 3, [32]byte{1})
+
+	s.NotNil(err)
+}
+
+func (s *SeenStoreTestSuite) Test_IsSeen_NotFound() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte(seenKey)).Return(nil, leveldb.ErrNotFound)
+
+	seen, err := s.seenStore.IsSeen(1, 2, 3, [32]byte{1})
+
+	s.Nil(err)
+	s.False(seen)
+}
+
+func (s *SeenStoreTestSuite) Test_IsSeen_Seen() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte(seenKey)).Return([]byte{1}, nil)
+
+	seen, err := s.seenStore.IsSeen(1, 2, 3, [32]byte{1})
+
+	s.Nil(err)
+	s.True(seen)
+}
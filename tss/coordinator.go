@@ -6,6 +6,7 @@ package tss
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -25,6 +26,7 @@ var (
 	initiatePeriod     = 15 * time.Second
 	coordinatorTimeout = 3 * time.Minute
 	tssTimeout         = 15 * time.Minute
+	depositGracePeriod = 5 * time.Minute
 )
 
 type TssProcess interface {
@@ -37,6 +39,21 @@ type TssProcess interface {
 	ValidCoordinators() []peer.ID
 }
 
+// VerifiableProcess is a tss process that signs a message every participant
+// derives independently from its own view of the source chain.
+// Participants refuse to sign if the coordinator message hash differs from their own.
+type VerifiableProcess interface {
+	MessageHash() string
+}
+
+// PreferredCoordinatorsProcess is a tss process that prefers a subset of valid coordinators
+// based on the local view of the relayer. The static coordinator is still elected from all
+// valid coordinators so that relayers agree on it, while preferred coordinators are used
+// when the coordinator is re-elected on retry.
+type PreferredCoordinatorsProcess interface {
+	PreferredCoordinators() []peer.ID
+}
+
 type Coordinator struct {
 	host           host.Host
 	communication  comm.Communication
@@ -48,6 +65,12 @@ type Coordinator struct {
 	CoordinatorTimeout time.Duration
 	TssTimeout         time.Duration
 	InitiatePeriod     time.Duration
+	// DepositGracePeriod is the time participants have to observe the deposits
+	// of a verifiable process and reply ready before the coordinator gives up
+	DepositGracePeriod time.Duration
+	// RequireMessageHashes refuses coordinator messages without message hashes
+	// once every relayer of the committee runs a version that sends them
+	RequireMessageHashes bool
 }
 
 func NewCoordinator(
@@ -65,7 +88,23 @@ func NewCoordinator(
 		CoordinatorTimeout: coordinatorTimeout,
 		TssTimeout:         tssTimeout,
 		InitiatePeriod:     initiatePeriod,
+		DepositGracePeriod: depositGracePeriod,
+	}
+}
+
+// ActiveSessions returns IDs of tss sessions that are currently executing
+func (c *Coordinator) ActiveSessions() []string {
+	c.processLock.Lock()
+	defer c.processLock.Unlock()
+
+	sessions := make([]string, 0)
+	for sessionID, pending := range c.pendingProcesses {
+		if pending {
+			sessions = append(sessions, sessionID)
+		}
 	}
+	sort.Strings(sessions)
+	return sessions
 }
 
 // Execute calculates process leader and coordinates party readiness and start the tss processes.
@@ -204,6 +243,11 @@ func (c *Coordinator) watchExecution(ctx context.Context, tssProcess TssProcess,
 // start initiates listeners for coordinator and participants with static calculated coordinator
 func (c *Coordinator) start(ctx context.Context, tssProcesses []TssProcess, coordinator peer.ID, resultChn chan interface{}, excludedPeers []peer.ID) error {
 	if coordinator.Pretty() == c.host.ID().Pretty() {
+		if !slices.Contains(preferredCoordinators(tssProcesses[0]), coordinator) {
+			// stepping aside times out like on participants so the coordinator is re-elected
+			log.Warn().Str("SessionID", tssProcesses[0].SessionID()).Msgf("Relayer is not a preferred coordinator, waiting for retry")
+			return c.waitForStart(ctx, tssProcesses, resultChn, coordinator, c.CoordinatorTimeout)
+		}
 		return c.initiate(ctx, tssProcesses, resultChn, excludedPeers)
 	} else {
 		return c.waitForStart(ctx, tssProcesses, resultChn, coordinator, c.CoordinatorTimeout)
@@ -214,7 +258,7 @@ func (c *Coordinator) start(ctx context.Context, tssProcesses []TssProcess, coor
 // an expected error ocurred during regular tss execution
 func (c *Coordinator) retry(ctx context.Context, tssProcesses []TssProcess, resultChn chan interface{}, excludedPeers []peer.ID) error {
 	coordinatorElector := c.electorFactory.CoordinatorElector(tssProcesses[0].SessionID(), elector.Bully)
-	coordinator, err := coordinatorElector.Coordinator(ctx, common.ExcludePeers(tssProcesses[0].ValidCoordinators(), excludedPeers))
+	coordinator, err := coordinatorElector.Coordinator(ctx, common.ExcludePeers(preferredCoordinators(tssProcesses[0]), excludedPeers))
 	if err != nil {
 		return err
 	}
@@ -223,16 +267,18 @@ func (c *Coordinator) retry(ctx context.Context, tssProcesses []TssProcess, resu
 }
 
 // broadcastInitiateMsg sends TssInitiateMsg to all peers
-func (c *Coordinator) broadcastInitiateMsg(sessionID string) {
+func (c *Coordinator) broadcastInitiateMsg(sessionID string, initiateMsgBytes []byte) {
 	log.Debug().Str("SessionID", sessionID).Msgf("broadcasted initiate message")
 	_ = c.communication.Broadcast(
-		c.host.Peerstore().Peers(), []byte{}, comm.TssInitiateMsg, sessionID,
+		c.host.Peerstore().Peers(), initiateMsgBytes, comm.TssInitiateMsg, sessionID,
 	)
 }
 
 // initiate sends initiate message to all peers and waits
 // for ready response. After tss process declares that enough
 // peers are ready, start message is broadcasted and tss process is started.
+// Verifiable processes fail if not enough participants observed the signed
+// message within the deposit grace period.
 func (c *Coordinator) initiate(ctx context.Context, tssProcesses []TssProcess, resultChn chan interface{}, excludedPeers []peer.ID) error {
 	readyChan := make(chan *comm.WrappedMessage)
 	readyPeers := make([]peer.ID, 0)
@@ -242,9 +288,22 @@ func (c *Coordinator) initiate(ctx context.Context, tssProcesses []TssProcess, r
 	subID := c.communication.Subscribe(tssProcess.SessionID(), comm.TssReadyMsg, readyChan)
 	defer c.communication.UnSubscribe(subID)
 
+	hashes := messageHashes(tssProcesses)
+	initiateMsgBytes, err := message.MarshalInitiateMessage(hashes)
+	if err != nil {
+		return err
+	}
+
+	var graceChn <-chan time.Time
+	if len(hashes) > 0 {
+		graceTimer := time.NewTimer(c.DepositGracePeriod)
+		defer graceTimer.Stop()
+		graceChn = graceTimer.C
+	}
+
 	ticker := time.NewTicker(c.InitiatePeriod)
 	defer ticker.Stop()
-	c.broadcastInitiateMsg(tssProcess.SessionID())
+	c.broadcastInitiateMsg(tssProcess.SessionID(), initiateMsgBytes)
 	for {
 		select {
 		case wMsg := <-readyChan:
@@ -262,7 +321,7 @@ func (c *Coordinator) initiate(ctx context.Context, tssProcesses []TssProcess, r
 				}
 
 				startParams := tssProcess.StartParams(readyPeers)
-				startMsgBytes, err := message.MarshalStartMessage(startParams)
+				startMsgBytes, err := message.MarshalStartMessage(startParams, hashes)
 				if err != nil {
 					return err
 				}
@@ -279,7 +338,11 @@ func (c *Coordinator) initiate(ctx context.Context, tssProcesses []TssProcess, r
 			}
 		case <-ticker.C:
 			{
-				c.broadcastInitiateMsg(tssProcess.SessionID())
+				c.broadcastInitiateMsg(tssProcess.SessionID(), initiateMsgBytes)
+			}
+		case <-graceChn:
+			{
+				return fmt.Errorf("only %d participants observed the signed message within %s", len(readyPeers), c.DepositGracePeriod)
 			}
 		case <-ctx.Done():
 			{
@@ -290,7 +353,9 @@ func (c *Coordinator) initiate(ctx context.Context, tssProcesses []TssProcess, r
 }
 
 // waitForStart responds to initiate messages and starts the tss process
-// when it receives the start message.
+// when it receives the start message. Initiate and start messages with message hashes
+// that differ from the locally derived ones are refused. Messages without hashes from
+// coordinators running older versions are accepted.
 func (c *Coordinator) waitForStart(
 	ctx context.Context,
 	tssProcesses []TssProcess,
@@ -318,6 +383,21 @@ func (c *Coordinator) waitForStart(
 					continue
 				}
 
+				var err error
+				initiateMsg := &message.InitiateMessage{}
+				if len(wMsg.Payload) > 0 {
+					initiateMsg, err = message.UnmarshalInitiateMessage(wMsg.Payload)
+					if err != nil {
+						log.Warn().Str("SessionID", tssProcess.SessionID()).Msgf("Received invalid initiate message from %s", wMsg.From.Pretty())
+						continue
+					}
+				}
+				err = c.verifyCoordinatorHashes(tssProcesses, initiateMsg.Hashes, wMsg.From)
+				if err != nil {
+					log.Error().Err(err).Str("SessionID", tssProcess.SessionID()).Msgf("Refusing initiate message from %s", wMsg.From.Pretty())
+					continue
+				}
+
 				coordinatorTimeoutTicker.Reset(timeout)
 
 				log.Debug().Str("SessionID", tssProcess.SessionID()).Msgf("sent ready message to %s", wMsg.From)
@@ -340,6 +420,10 @@ func (c *Coordinator) waitForStart(
 				if err != nil {
 					return err
 				}
+				err = c.verifyCoordinatorHashes(tssProcesses, msg.Hashes, startMsg.From)
+				if err != nil {
+					return err
+				}
 
 				p := pool.New().WithContext(ctx).WithCancelOnError()
 				for _, process := range tssProcesses {
@@ -361,3 +445,54 @@ func (c *Coordinator) waitForStart(
 		}
 	}
 }
+
+// preferredCoordinators returns coordinators the process prefers, or all valid coordinators
+// if the process has no preference
+func preferredCoordinators(tssProcess TssProcess) []peer.ID {
+	preferredProcess, ok := tssProcess.(PreferredCoordinatorsProcess)
+	if !ok {
+		return tssProcess.ValidCoordinators()
+	}
+	return preferredProcess.PreferredCoordinators()
+}
+
+// messageHashes returns message hashes of verifiable processes by session ID
+func messageHashes(tssProcesses []TssProcess) map[string]string {
+	hashes := make(map[string]string)
+	for _, process := range tssProcesses {
+		verifiableProcess, ok := process.(VerifiableProcess)
+		if !ok {
+			continue
+		}
+
+		hashes[process.SessionID()] = verifiableProcess.MessageHash()
+	}
+	return hashes
+}
+
+// verifyCoordinatorHashes verifies message hashes sent by the coordinator. Coordinators
+// running versions without verification send no hashes and are accepted so committees
+// can be upgraded one relayer at a time, unless hashes are required.
+func (c *Coordinator) verifyCoordinatorHashes(tssProcesses []TssProcess, hashes map[string]string, coordinator peer.ID) error {
+	if hashes == nil && !c.RequireMessageHashes {
+		log.Warn().Str("SessionID", tssProcesses[0].SessionID()).Msgf("Coordinator %s sent no message hashes, skipping verification", coordinator.Pretty())
+		return nil
+	}
+
+	return verifyMessageHashes(tssProcesses, hashes)
+}
+
+// verifyMessageHashes checks that coordinator message hashes match the locally derived ones
+func verifyMessageHashes(tssProcesses []TssProcess, hashes map[string]string) error {
+	for sessionID, expected := range messageHashes(tssProcesses) {
+		received := hashes[sessionID]
+		if received != expected {
+			return &VerificationError{
+				SessionID: sessionID,
+				Expected:  expected,
+				Received:  received,
+			}
+		}
+	}
+	return nil
+}
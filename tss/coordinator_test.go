@@ -0,0 +1,183 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tss_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/comm/elector"
+	mock_comm "github.com/ChainSafe/sygma-relayer/comm/mock"
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/tss"
+	mock_tss "github.com/ChainSafe/sygma-relayer/tss/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/suite"
+)
+
+type verifiableProcess struct {
+	*mock_tss.MockTssProcess
+	hash string
+}
+
+func (p *verifiableProcess) MessageHash() string {
+	return p.hash
+}
+
+type preferredProcess struct {
+	*verifiableProcess
+	preferred []peer.ID
+}
+
+func (p *preferredProcess) PreferredCoordinators() []peer.ID {
+	return p.preferred
+}
+
+type CoordinatorTestSuite struct {
+	suite.Suite
+	host              host.Host
+	coordinatorPeer   peer.ID
+	mockCommunication *mock_comm.MockCommunication
+	process           *verifiableProcess
+	coordinator       *tss.Coordinator
+
+	subscriptions map[comm.MessageType]chan *comm.WrappedMessage
+	subscribed    chan comm.MessageType
+	lock          sync.Mutex
+}
+
+func TestRunCoordinatorTestSuite(t *testing.T) {
+	suite.Run(t, new(CoordinatorTestSuite))
+}
+
+func (s *CoordinatorTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	h, err := libp2p.New(libp2p.DisableRelay())
+	s.Nil(err)
+	s.host = h
+	coordinatorHost, err := libp2p.New(libp2p.DisableRelay())
+	s.Nil(err)
+	s.coordinatorPeer = coordinatorHost.ID()
+
+	s.subscriptions = make(map[comm.MessageType]chan *comm.WrappedMessage)
+	s.subscribed = make(chan comm.MessageType, 10)
+	s.mockCommunication = mock_comm.NewMockCommunication(ctrl)
+	s.mockCommunication.EXPECT().Subscribe("session", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(sessionID string, msgType comm.MessageType, channel chan *comm.WrappedMessage) comm.SubscriptionID {
+			s.lock.Lock()
+			s.subscriptions[msgType] = channel
+			s.lock.Unlock()
+			s.subscribed <- msgType
+			return comm.SubscriptionID(msgType.String())
+		}).AnyTimes()
+	s.mockCommunication.EXPECT().UnSubscribe(gomock.Any()).AnyTimes()
+	s.mockCommunication.EXPECT().CloseSession("session").AnyTimes()
+
+	s.process = &verifiableProcess{MockTssProcess: mock_tss.NewMockTssProcess(ctrl), hash: "hash"}
+	s.process.EXPECT().SessionID().Return("session").AnyTimes()
+	s.process.EXPECT().Stop().AnyTimes()
+	s.process.EXPECT().Retryable().Return(false).AnyTimes()
+
+	s.coordinator = tss.NewCoordinator(s.host, s.mockCommunication, elector.NewCoordinatorElectorFactory(s.host, relayer.BullyConfig{}))
+}
+
+func (s *CoordinatorTestSuite) send(msgType comm.MessageType, payload []byte) {
+	for {
+		subscribed := <-s.subscribed
+		if subscribed == msgType {
+			break
+		}
+	}
+	s.lock.Lock()
+	channel := s.subscriptions[msgType]
+	s.lock.Unlock()
+	channel <- &comm.WrappedMessage{
+		MessageType: msgType,
+		SessionID:   "session",
+		Payload:     payload,
+		From:        s.coordinatorPeer,
+	}
+}
+
+func (s *CoordinatorTestSuite) Test_Execute_LegacyCoordinatorWithoutHashes() {
+	s.process.EXPECT().ValidCoordinators().Return([]peer.ID{s.coordinatorPeer}).AnyTimes()
+	ready := make(chan struct{})
+	s.mockCommunication.EXPECT().Broadcast(peer.IDSlice{s.coordinatorPeer}, []byte{}, comm.TssReadyMsg, "session").DoAndReturn(
+		func(peers peer.IDSlice, msg []byte, msgType comm.MessageType, sessionID string) error {
+			close(ready)
+			return nil
+		})
+	s.process.EXPECT().Run(gomock.Any(), false, gomock.Any(), []byte("params")).Return(nil)
+
+	errChn := make(chan error)
+	go func() {
+		errChn <- s.coordinator.Execute(context.Background(), []tss.TssProcess{s.process}, make(chan interface{}))
+	}()
+
+	s.send(comm.TssInitiateMsg, []byte{})
+	<-ready
+	legacyStartMsg, _ := json.Marshal(struct {
+		Params []byte `json:"params"`
+	}{Params: []byte("params")})
+	s.send(comm.TssStartMsg, legacyStartMsg)
+
+	s.Nil(<-errChn)
+}
+
+func (s *CoordinatorTestSuite) Test_Execute_MissingHashesRefusedWhenRequired() {
+	s.process.EXPECT().ValidCoordinators().Return([]peer.ID{s.coordinatorPeer}).AnyTimes()
+	s.coordinator.CoordinatorTimeout = time.Millisecond * 500
+	s.coordinator.RequireMessageHashes = true
+
+	errChn := make(chan error)
+	go func() {
+		errChn <- s.coordinator.Execute(context.Background(), []tss.TssProcess{s.process}, make(chan interface{}))
+	}()
+
+	s.send(comm.TssInitiateMsg, []byte{})
+
+	s.Equal(<-errChn, &tss.CoordinatorError{Peer: s.coordinatorPeer})
+}
+
+func (s *CoordinatorTestSuite) Test_Execute_MismatchedHashRefused() {
+	s.process.EXPECT().ValidCoordinators().Return([]peer.ID{s.coordinatorPeer}).AnyTimes()
+	s.coordinator.CoordinatorTimeout = time.Millisecond * 500
+
+	errChn := make(chan error)
+	go func() {
+		errChn <- s.coordinator.Execute(context.Background(), []tss.TssProcess{s.process}, make(chan interface{}))
+	}()
+
+	s.send(comm.TssInitiateMsg, []byte(`{"hashes":{"session":"other"}}`))
+
+	s.NotNil(<-errChn)
+}
+
+func (s *CoordinatorTestSuite) Test_Execute_ParticipantsMissDepositsWithinGracePeriod() {
+	s.process.EXPECT().ValidCoordinators().Return([]peer.ID{s.host.ID()}).AnyTimes()
+	s.mockCommunication.EXPECT().Broadcast(gomock.Any(), gomock.Any(), comm.TssInitiateMsg, "session").Return(nil).AnyTimes()
+	s.coordinator.DepositGracePeriod = time.Millisecond * 100
+
+	err := s.coordinator.Execute(context.Background(), []tss.TssProcess{s.process}, make(chan interface{}))
+
+	s.NotNil(err)
+	s.Contains(err.Error(), "observed the signed message")
+}
+
+func (s *CoordinatorTestSuite) Test_Execute_NotPreferredCoordinatorStepsAside() {
+	s.process.EXPECT().ValidCoordinators().Return([]peer.ID{s.host.ID()}).AnyTimes()
+	s.coordinator.CoordinatorTimeout = time.Millisecond * 100
+	process := &preferredProcess{verifiableProcess: s.process, preferred: []peer.ID{s.coordinatorPeer}}
+
+	err := s.coordinator.Execute(context.Background(), []tss.TssProcess{process}, make(chan interface{}))
+
+	s.Equal(err, &tss.CoordinatorError{Peer: s.host.ID()})
+}
@@ -121,6 +121,47 @@ func (s *SigningTestSuite) Test_SigningTimeout() {
 	s.NotNil(err)
 }
 
+func (s *SigningTestSuite) Test_MismatchedMessage_ParticipantsRefuseToSign() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		fetcher := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../../test/keyshares/%d.keyshare", i))
+
+		msg := big.NewInt(int64(i + 1))
+		signing, err := signing.NewSigning(msg, "signing3", "signing3", host, &communication, fetcher)
+		if err != nil {
+			panic(err)
+		}
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinator := tss.NewCoordinator(host, &communication, electorFactory)
+		coordinator.CoordinatorTimeout = time.Second
+		coordinator.TssTimeout = 3 * time.Second
+		coordinators = append(coordinators, coordinator)
+		processes = append(processes, signing)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	resultChn := make(chan interface{}, 3)
+	pool := pool.New().WithContext(context.Background())
+	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, []tss.TssProcess{processes[i]}, resultChn)
+		})
+	}
+
+	err := pool.Wait()
+	s.NotNil(err)
+	s.Equal(0, len(resultChn))
+}
+
 func (s *SigningTestSuite) Test_PendingProcessExists() {
 	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
 	coordinators := []*tss.Coordinator{}
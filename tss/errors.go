@@ -24,3 +24,15 @@ type SubsetError struct {
 func (se *SubsetError) Error() string {
 	return fmt.Sprintf("party %s not in signing subset", se.Peer)
 }
+
+// VerificationError is returned when the message hash the coordinator started
+// the process with does not match the hash the participant derived itself.
+type VerificationError struct {
+	SessionID string
+	Expected  string
+	Received  string
+}
+
+func (ve *VerificationError) Error() string {
+	return fmt.Sprintf("session %s message hash %s does not match locally derived hash %s", ve.SessionID, ve.Received, ve.Expected)
+}
@@ -152,6 +152,11 @@ func (s *Signing) Ready(readyPeers []peer.ID, excludedPeers []peer.ID) (bool, er
 	return len(readyPeers) == s.key.Threshold+1, nil
 }
 
+// MessageHash returns the sighash this participant derived itself
+func (s *Signing) MessageHash() string {
+	return hex.EncodeToString(s.msg)
+}
+
 // ValidCoordinators returns only peers that have a valid keyshare
 func (s *Signing) ValidCoordinators() []peer.ID {
 	return s.key.Peers
@@ -36,13 +36,44 @@ func UnmarshalTssMessage(msgBytes []byte) (*TssMessage, error) {
 	return msg, nil
 }
 
+// InitiateMessage is sent by the coordinator to prepare participants for a tss process.
+// Hashes contains the message hash of each verifiable process by session ID.
+type InitiateMessage struct {
+	Hashes map[string]string `json:"hashes"`
+}
+
+func MarshalInitiateMessage(hashes map[string]string) ([]byte, error) {
+	initiateMessage := &InitiateMessage{
+		Hashes: hashes,
+	}
+
+	msgBytes, err := json.Marshal(initiateMessage)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return msgBytes, nil
+}
+
+func UnmarshalInitiateMessage(msgBytes []byte) (*InitiateMessage, error) {
+	msg := &InitiateMessage{}
+	err := json.Unmarshal(msgBytes, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
 type StartMessage struct {
-	Params []byte `json:"params"`
+	Params []byte            `json:"params"`
+	Hashes map[string]string `json:"hashes"`
 }
 
-func MarshalStartMessage(params []byte) ([]byte, error) {
+func MarshalStartMessage(params []byte, hashes map[string]string) ([]byte, error) {
 	startSignMessage := &StartMessage{
 		Params: params,
+		Hashes: hashes,
 	}
 
 	msgBytes, err := json.Marshal(startSignMessage)
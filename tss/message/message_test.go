@@ -43,8 +43,9 @@ func TestRunStartMessageTestSuite(t *testing.T) {
 func (s *StartMessageTestSuite) Test_UnmarshaledMessageShouldBeEqual() {
 	originalMsg := &message.StartMessage{
 		Params: []byte("test"),
+		Hashes: map[string]string{"session": "hash"},
 	}
-	msgBytes, err := message.MarshalStartMessage(originalMsg.Params)
+	msgBytes, err := message.MarshalStartMessage(originalMsg.Params, originalMsg.Hashes)
 	s.Nil(err)
 
 	unmarshaledMsg, err := message.UnmarshalStartMessage(msgBytes)
@@ -52,3 +53,24 @@ func (s *StartMessageTestSuite) Test_UnmarshaledMessageShouldBeEqual() {
 
 	s.Equal(originalMsg, unmarshaledMsg)
 }
+
+type InitiateMessageTestSuite struct {
+	suite.Suite
+}
+
+func TestRunInitiateMessageTestSuite(t *testing.T) {
+	suite.Run(t, new(InitiateMessageTestSuite))
+}
+
+func (s *InitiateMessageTestSuite) Test_UnmarshaledMessageShouldBeEqual() {
+	originalMsg := &message.InitiateMessage{
+		Hashes: map[string]string{"session": "hash"},
+	}
+	msgBytes, err := message.MarshalInitiateMessage(originalMsg.Hashes)
+	s.Nil(err)
+
+	unmarshaledMsg, err := message.UnmarshalInitiateMessage(msgBytes)
+	s.Nil(err)
+
+	s.Equal(originalMsg, unmarshaledMsg)
+}
@@ -131,3 +131,77 @@ func (mr *MockTssProcessMockRecorder) ValidCoordinators() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidCoordinators", reflect.TypeOf((*MockTssProcess)(nil).ValidCoordinators))
 }
+
+// MockVerifiableProcess is a mock of VerifiableProcess interface.
+type MockVerifiableProcess struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerifiableProcessMockRecorder
+}
+
+// MockVerifiableProcessMockRecorder is the mock recorder for MockVerifiableProcess.
+type MockVerifiableProcessMockRecorder struct {
+	mock *MockVerifiableProcess
+}
+
+// NewMockVerifiableProcess creates a new mock instance.
+func NewMockVerifiableProcess(ctrl *gomock.Controller) *MockVerifiableProcess {
+	mock := &MockVerifiableProcess{ctrl: ctrl}
+	mock.recorder = &MockVerifiableProcessMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVerifiableProcess) EXPECT() *MockVerifiableProcessMockRecorder {
+	return m.recorder
+}
+
+// MessageHash mocks base method.
+func (m *MockVerifiableProcess) MessageHash() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MessageHash")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// MessageHash indicates an expected call of MessageHash.
+func (mr *MockVerifiableProcessMockRecorder) MessageHash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MessageHash", reflect.TypeOf((*MockVerifiableProcess)(nil).MessageHash))
+}
+
+// MockPreferredCoordinatorsProcess is a mock of PreferredCoordinatorsProcess interface.
+type MockPreferredCoordinatorsProcess struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreferredCoordinatorsProcessMockRecorder
+}
+
+// MockPreferredCoordinatorsProcessMockRecorder is the mock recorder for MockPreferredCoordinatorsProcess.
+type MockPreferredCoordinatorsProcessMockRecorder struct {
+	mock *MockPreferredCoordinatorsProcess
+}
+
+// NewMockPreferredCoordinatorsProcess creates a new mock instance.
+func NewMockPreferredCoordinatorsProcess(ctrl *gomock.Controller) *MockPreferredCoordinatorsProcess {
+	mock := &MockPreferredCoordinatorsProcess{ctrl: ctrl}
+	mock.recorder = &MockPreferredCoordinatorsProcessMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreferredCoordinatorsProcess) EXPECT() *MockPreferredCoordinatorsProcessMockRecorder {
+	return m.recorder
+}
+
+// PreferredCoordinators mocks base method.
+func (m *MockPreferredCoordinatorsProcess) PreferredCoordinators() []peer.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreferredCoordinators")
+	ret0, _ := ret[0].([]peer.ID)
+	return ret0
+}
+
+// PreferredCoordinators indicates an expected call of PreferredCoordinators.
+func (mr *MockPreferredCoordinatorsProcessMockRecorder) PreferredCoordinators() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreferredCoordinators", reflect.TypeOf((*MockPreferredCoordinatorsProcess)(nil).PreferredCoordinators))
+}